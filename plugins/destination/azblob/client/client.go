@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/delta"
 	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/message"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
 	"github.com/cloudquery/plugin-sdk/v4/writers/streamingbatchwriter"
 	"github.com/rs/zerolog"
@@ -16,8 +19,6 @@ import (
 
 type Client struct {
 	plugin.UnimplementedSource
-	streamingbatchwriter.IgnoreMigrateTable
-	streamingbatchwriter.UnimplementedDeleteStale
 
 	logger zerolog.Logger
 	spec   *Spec
@@ -25,6 +26,8 @@ type Client struct {
 	writer *streamingbatchwriter.StreamingBatchWriter
 
 	storageClient *azblob.Client
+
+	delta *delta.Tables
 }
 
 func New(ctx context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewClientOptions) (plugin.Client, error) {
@@ -61,6 +64,9 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, opts plugin.Ne
 	if err != nil {
 		return nil, fmt.Errorf("failed to write test file to Azure: %w", err)
 	}
+	if c.spec.TableFormat == TableFormatDelta {
+		c.delta = delta.NewTables(&azblobDeltaStore{client: c.storageClient, container: c.spec.Container}, c.Client, c.deltaTableRoot, "cloudquery-azblob-destination")
+	}
 
 	c.writer, err = streamingbatchwriter.New(c,
 		streamingbatchwriter.WithBatchSizeRows(*c.spec.BatchSize),
@@ -74,6 +80,24 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, opts plugin.Ne
 	return c, nil
 }
 
+func (c *Client) MigrateTable(ctx context.Context, msgs <-chan *message.WriteMigrateTable) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Migrate(ctx, msgs)
+	}
+	return streamingbatchwriter.IgnoreMigrateTable{}.MigrateTable(ctx, msgs)
+}
+
+func (c *Client) DeleteStale(ctx context.Context, msgs <-chan *message.WriteDeleteStale) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.DeleteStale(ctx, msgs)
+	}
+	return streamingbatchwriter.UnimplementedDeleteStale{}.DeleteStale(ctx, msgs)
+}
+
 func (c *Client) Close(ctx context.Context) error {
 	return c.writer.Close(ctx)
 }
+
+func (c *Client) deltaTableRoot(table string) string {
+	return path.Join(c.spec.Path, table)
+}
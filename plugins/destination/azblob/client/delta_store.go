@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/delta"
+)
+
+// azblobDeltaStore stores the Delta Lake tables in the container.
+// Commits are uploaded with an If-None-Match condition, so concurrent writers never overwrite each other.
+type azblobDeltaStore struct {
+	client    *azblob.Client
+	container string
+}
+
+func (s *azblobDeltaStore) Get(ctx context.Context, key string) ([]byte, error) {
+	response, err := s.client.DownloadStream(ctx, s.container, key, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, delta.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	return io.ReadAll(response.Body)
+}
+
+func (s *azblobDeltaStore) Put(ctx context.Context, key string, r io.Reader) error {
+	_, err := s.client.UploadStream(ctx, s.container, key, r, nil)
+	return err
+}
+
+func (s *azblobDeltaStore) PutIfAbsent(ctx context.Context, key string, data []byte) error {
+	_, err := s.client.UploadBuffer(ctx, s.container, key, data, &azblob.UploadBufferOptions{
+		AccessConditions: &blob.AccessConditions{
+			ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfNoneMatch: to.Ptr(azcore.ETagAny)},
+		},
+	})
+	if bloberror.HasCode(err, bloberror.BlobAlreadyExists, bloberror.ConditionNotMet) {
+		return delta.ErrObjectExists
+	}
+	return err
+}
+
+func (s *azblobDeltaStore) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteBlob(ctx, s.container, key, nil)
+	return err
+}
@@ -7,13 +7,20 @@ import (
 	"io"
 
 	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/partition"
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 )
 
 func (c *Client) Read(ctx context.Context, table *schema.Table, res chan<- arrow.Record) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Read(ctx, table, res)
+	}
 	if !c.spec.NoRotate {
 		return fmt.Errorf("reading is not supported when `no_rotate` is false. Table: %q", table.Name)
 	}
+	if partition.HasColumnVariables(c.spec.Path) {
+		return fmt.Errorf("reading is not supported when `path` contains {{COLUMN:...}} variables. Table: %q", table.Name)
+	}
 	name := fmt.Sprintf("%s/%s.%s", c.spec.Path, table.Name, c.spec.Format)
 
 	response, err := c.storageClient.DownloadStream(ctx, c.spec.Container, name, nil)
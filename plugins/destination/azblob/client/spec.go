@@ -2,17 +2,21 @@ package client
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cloudquery/filetypes/v4"
 	"github.com/cloudquery/plugin-sdk/v4/configtype"
 )
 
+const TableFormatDelta = "delta"
+
 type Spec struct {
 	StorageAccount string `json:"storage_account,omitempty"`
 	Container      string `json:"container,omitempty"`
 	Path           string `json:"path,omitempty"`
 	NoRotate       bool   `json:"no_rotate,omitempty"`
+	TableFormat    string `json:"table_format,omitempty"`
 	*filetypes.FileSpec
 
 	BatchSize      *int64               `json:"batch_size"`
@@ -59,6 +63,15 @@ func (s *Spec) Validate() error {
 	if s.Format == "" {
 		return fmt.Errorf("`format` is required")
 	}
+	switch s.TableFormat {
+	case "":
+	case TableFormatDelta:
+		if err := s.validateDelta(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported `table_format` %q, only %q is supported", s.TableFormat, TableFormatDelta)
+	}
 	if s.NoRotate && ((s.BatchSize != nil && *s.BatchSize > 0) || (s.BatchSizeBytes != nil && *s.BatchSizeBytes > 0) || (s.BatchTimeout != nil && s.BatchTimeout.Duration() > 0)) {
 		return fmt.Errorf("`no_rotate` cannot be used with non-zero `batch_size`, `batch_size_bytes` or `batch_timeout_ms`")
 	}
@@ -66,6 +79,19 @@ func (s *Spec) Validate() error {
 	return nil
 }
 
+func (s *Spec) validateDelta() error {
+	if s.Format != filetypes.FormatTypeParquet {
+		return fmt.Errorf("`table_format` %q requires the %q `format`", TableFormatDelta, filetypes.FormatTypeParquet)
+	}
+	if s.NoRotate {
+		return fmt.Errorf("`no_rotate` is not supported with `table_format` %q", TableFormatDelta)
+	}
+	if strings.Contains(s.Path, "{{") {
+		return fmt.Errorf("`path` should not contain variables when `table_format` is %q", TableFormatDelta)
+	}
+	return nil
+}
+
 func int64ptr(i int64) *int64 {
 	return &i
 }
@@ -50,6 +50,10 @@ func TestSpec_Validate(t *testing.T) {
 		{Give: Spec{Path: "test/path", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: false, StorageAccount: storage_account, Container: container, BatchSize: &zero, BatchSizeBytes: &zero, BatchTimeout: &dur0}, WantErr: false},
 		{Give: Spec{Path: "test/path", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: true, StorageAccount: storage_account, Container: container, BatchSize: &zero, BatchSizeBytes: &zero, BatchTimeout: &dur0}, WantErr: false},
 		{Give: Spec{Path: "test/path", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: true, StorageAccount: storage_account, Container: container, BatchSize: &one, BatchSizeBytes: &zero, BatchTimeout: &dur0}, WantErr: true},
+		{Give: Spec{Path: "test/path", FileSpec: &filetypes.FileSpec{Format: "parquet"}, StorageAccount: storage_account, Container: container, TableFormat: TableFormatDelta}, WantErr: false},
+		{Give: Spec{Path: "test/path", FileSpec: &filetypes.FileSpec{Format: "json"}, StorageAccount: storage_account, Container: container, TableFormat: TableFormatDelta}, WantErr: true},                    // delta requires parquet
+		{Give: Spec{Path: "test/path", FileSpec: &filetypes.FileSpec{Format: "parquet"}, NoRotate: true, StorageAccount: storage_account, Container: container, TableFormat: TableFormatDelta}, WantErr: true}, // file names are generated
+		{Give: Spec{Path: "test/{{COLUMN:id}}", FileSpec: &filetypes.FileSpec{Format: "parquet"}, StorageAccount: storage_account, Container: container, TableFormat: TableFormatDelta}, WantErr: true},
 	}
 	for i, tc := range cases {
 		tc := tc
@@ -5,22 +5,50 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/partition"
 	"github.com/cloudquery/filetypes/v4"
 	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/google/uuid"
 )
 
 func (c *Client) WriteTable(ctx context.Context, msgs <-chan *message.WriteInsert) error {
-	var s *filetypes.Stream
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Write(ctx, msgs)
+	}
+
+	var (
+		streams []*filetypes.Stream
+		byPath  = make(map[string]*filetypes.Stream)
+	)
 
 	for msg := range msgs {
-		if s == nil {
-			table := msg.GetTable()
+		parts := partition.Split(c.spec.Path, msg.Record)
+		err := c.writePartitions(ctx, msg.GetTable(), parts, byPath, &streams)
+		partition.Release(parts)
+		if err != nil {
+			finishStreamsWithError(streams, err)
+			return err
+		}
+	}
+
+	for i, s := range streams {
+		if err := s.Finish(); err != nil {
+			finishStreamsWithError(streams[i+1:], err)
+			return err
+		}
+	}
+	return nil
+}
 
-			name := fmt.Sprintf("%s/%s.%s.%s", c.spec.Path, table.Name, c.spec.Format, uuid.NewString())
+// writePartitions writes the partitions to the streams of their paths, starting the streams of new paths.
+func (c *Client) writePartitions(ctx context.Context, table *schema.Table, parts []partition.Partition, byPath map[string]*filetypes.Stream, streams *[]*filetypes.Stream) error {
+	for _, part := range parts {
+		s := byPath[part.Path]
+		if s == nil {
+			name := fmt.Sprintf("%s/%s.%s.%s", part.Path, table.Name, c.spec.Format, uuid.NewString())
 			if c.spec.NoRotate {
-				name = fmt.Sprintf("%s/%s.%s", c.spec.Path, table.Name, c.spec.Format)
+				name = fmt.Sprintf("%s/%s.%s", part.Path, table.Name, c.spec.Format)
 			}
 
 			var err error
@@ -31,17 +59,30 @@ func (c *Client) WriteTable(ctx context.Context, msgs <-chan *message.WriteInser
 			if err != nil {
 				return err
 			}
+			byPath[part.Path] = s
+			*streams = append(*streams, s)
 		}
 
-		if err := s.Write([]arrow.Record{msg.Record}); err != nil {
-			_ = s.FinishWithError(err)
+		if err := s.Write(part.Records); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	return s.Finish()
+func finishStreamsWithError(streams []*filetypes.Stream, err error) {
+	for _, s := range streams {
+		_ = s.FinishWithError(err)
+	}
 }
 
 func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) error {
-	return c.writer.Write(ctx, msgs)
+	if err := c.writer.Write(ctx, msgs); err != nil {
+		return err
+	}
+	if c.spec.TableFormat == TableFormatDelta {
+		// all batches are flushed by now, so the whole sync is committed at once
+		return c.delta.Commit(ctx)
+	}
+	return nil
 }
@@ -3,9 +3,11 @@ module github.com/cloudquery/cloudquery/plugins/destination/azblob
 go 1.20
 
 require (
+	github.com/Azure/azure-sdk-for-go/sdk/azcore v1.4.0
 	github.com/Azure/azure-sdk-for-go/sdk/azidentity v1.2.2
 	github.com/Azure/azure-sdk-for-go/sdk/storage/azblob v1.0.0
 	github.com/apache/arrow/go/v13 v13.0.0-20230630125530-5a06b2ec2a8e
+	github.com/cloudquery/cloudquery/plugins/destination/filetables v0.0.0-00010101000000-000000000000
 	github.com/cloudquery/filetypes/v4 v4.0.3
 	github.com/cloudquery/plugin-sdk/v4 v4.2.3
 	github.com/google/go-cmp v0.5.9
@@ -17,8 +19,9 @@ require (
 // TODO: remove once all updates are merged
 replace github.com/apache/arrow/go/v13 => github.com/cloudquery/arrow/go/v13 v13.0.0-20230717001540-8e2219bec8ee
 
+replace github.com/cloudquery/cloudquery/plugins/destination/filetables => ../filetables
+
 require (
-	github.com/Azure/azure-sdk-for-go/sdk/azcore v1.4.0 // indirect
 	github.com/Azure/azure-sdk-for-go/sdk/internal v1.2.0 // indirect
 	github.com/AzureAD/microsoft-authentication-library-for-go v0.9.0 // indirect
 	github.com/JohnCGriffin/overflow v0.0.0-20211019200055-46fa312c352c // indirect
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// api is the part of the BigQuery API used to create tables and write and delete rows,
+// so that the generated tables and queries can be tested with a fake.
+type api interface {
+	createTable(ctx context.Context, table string, md *bigquery.TableMetadata) error
+	deleteTable(ctx context.Context, table string) error
+	// put streams the rows into the table
+	put(ctx context.Context, table string, items []*item) error
+	// load appends the newline-delimited JSON rows to the table with a load job, and waits for it to complete
+	load(ctx context.Context, table string, rows io.Reader) error
+	// query runs the statement and waits for it to complete
+	query(ctx context.Context, stmt string, params []bigquery.QueryParameter) error
+}
+
+type bigQueryAPI struct {
+	client    *bigquery.Client
+	datasetID string
+}
+
+func (a *bigQueryAPI) createTable(ctx context.Context, table string, md *bigquery.TableMetadata) error {
+	return a.client.Dataset(a.datasetID).Table(table).Create(ctx, md)
+}
+
+func (a *bigQueryAPI) deleteTable(ctx context.Context, table string) error {
+	return a.client.Dataset(a.datasetID).Table(table).Delete(ctx)
+}
+
+func (a *bigQueryAPI) put(ctx context.Context, table string, items []*item) error {
+	inserter := a.client.Dataset(a.datasetID).Table(table).Inserter()
+	inserter.IgnoreUnknownValues = true
+	inserter.SkipInvalidRows = false
+	return inserter.Put(ctx, items)
+}
+
+func (a *bigQueryAPI) load(ctx context.Context, table string, rows io.Reader) error {
+	src := bigquery.NewReaderSource(rows)
+	src.SourceFormat = bigquery.JSON
+	src.IgnoreUnknownValues = true
+	loader := a.client.Dataset(a.datasetID).Table(table).LoaderFrom(src)
+	loader.CreateDisposition = bigquery.CreateNever
+	loader.WriteDisposition = bigquery.WriteAppend
+	loader.Location = a.client.Location
+	return wait(ctx, loader.Run)
+}
+
+func (a *bigQueryAPI) query(ctx context.Context, stmt string, params []bigquery.QueryParameter) error {
+	q := a.client.Query(stmt)
+	q.Parameters = params
+	q.Location = a.client.Location
+	return wait(ctx, q.Run)
+}
+
+// wait runs the job and waits for it to complete.
+func wait(ctx context.Context, run func(context.Context) (*bigquery.Job, error)) error {
+	job, err := run(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	return status.Err()
+}
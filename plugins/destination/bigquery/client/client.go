@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"cloud.google.com/go/bigquery"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
 	"github.com/cloudquery/plugin-sdk/v4/writers/batchwriter"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"google.golang.org/api/option"
 )
@@ -17,15 +21,24 @@ type Client struct {
 	logger zerolog.Logger
 	spec   Spec
 	client *bigquery.Client
+	api    api
 	writer *batchwriter.BatchWriter
 
-	batchwriter.UnimplementedDeleteStale
+	// syncID names the staging tables of the sync
+	syncID string
+	// stagingTables are the staging tables created during the sync, by table
+	stagingTables   map[string]*stagingTable
+	stagingTablesMu sync.Mutex
+	// loadOrder numbers the rows loaded into the staging tables
+	loadOrder atomic.Int64
 }
 
 func New(ctx context.Context, logger zerolog.Logger, specBytes []byte, opts plugin.NewClientOptions) (plugin.Client, error) {
 	var err error
 	c := &Client{
-		logger: logger.With().Str("module", "bq-dest").Logger(),
+		logger:        logger.With().Str("module", "bq-dest").Logger(),
+		syncID:        newSyncID(),
+		stagingTables: make(map[string]*stagingTable),
 	}
 	if opts.NoConnection {
 		return c, nil
@@ -54,10 +67,15 @@ func New(ctx context.Context, logger zerolog.Logger, specBytes []byte, opts plug
 	if err != nil {
 		return nil, err
 	}
+	c.api = &bigQueryAPI{client: c.client, datasetID: c.spec.DatasetID}
 
 	return c, nil
 }
 
+func newSyncID() string {
+	return strings.ReplaceAll(uuid.NewString(), "-", "")
+}
+
 func (c *Client) bqClient(ctx context.Context) (*bigquery.Client, error) {
 	opts := []option.ClientOption{option.WithRequestReason("CloudQuery BigQuery destination")}
 	if len(c.spec.ServiceAccountKeyJSON) != 0 {
@@ -77,5 +95,6 @@ func (c *Client) Close(ctx context.Context) error {
 	if err := c.writer.Close(ctx); err != nil {
 		return err
 	}
+	c.dropStagingTables(ctx)
 	return c.client.Close()
 }
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// DeleteStale deletes the rows written by the source before the sync time.
+// Rows still in the streaming buffer can't be deleted, which isn't an issue for tables with primary keys, as their rows
+// are loaded, or for syncs far enough apart for the rows of the previous one to have left it.
+func (c *Client) DeleteStale(ctx context.Context, msgs message.WriteDeleteStales) error {
+	for _, msg := range msgs {
+		params := []bigquery.QueryParameter{
+			{Name: "src", Value: msg.SourceName},
+			{Name: "t", Value: msg.SyncTime},
+		}
+		if err := c.api.query(ctx, c.deleteStaleSQL(msg.TableName), params); err != nil {
+			return fmt.Errorf("failed to delete stale rows of table %s: %w", msg.TableName, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) deleteStaleSQL(table string) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s = @src AND %s < @t",
+		c.tableRef(table), quoteColumn(schema.CqSourceNameColumn.Name), quoteColumn(schema.CqSyncTimeColumn.Name))
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+const (
+	// loadOrderColumn is the column of the staging tables numbering the rows in the order they were loaded,
+	// so the last row loaded for the primary keys is the one merged
+	loadOrderColumn = "_cq_load_order"
+	// staging tables are dropped once merged, and expire in case that fails
+	stagingTableExpiration = 24 * time.Hour
+)
+
+// stagingTable is the table the rows of a table with primary keys are loaded into during the sync,
+// to be merged into the table at the end of it.
+type stagingTable struct {
+	name  string
+	table *schema.Table
+}
+
+// tableRef returns the quoted, fully qualified name of the table.
+func (c *Client) tableRef(name string) string {
+	return fmt.Sprintf("`%s.%s.%s`", c.spec.ProjectID, c.spec.DatasetID, name)
+}
+
+func quoteColumn(name string) string {
+	return "`" + name + "`"
+}
+
+// stagingTable returns the name of the staging table of the table for this sync, creating it on first use.
+func (c *Client) stagingTable(ctx context.Context, table *schema.Table) (string, error) {
+	c.stagingTablesMu.Lock()
+	defer c.stagingTablesMu.Unlock()
+	if st, ok := c.stagingTables[table.Name]; ok {
+		return st.name, nil
+	}
+
+	name := table.Name + "_cq_staging_" + c.syncID
+	bqSchema := append(c.bigQuerySchemaForTable(table), &bigquery.FieldSchema{Name: loadOrderColumn, Type: bigquery.IntegerFieldType})
+	md := &bigquery.TableMetadata{
+		Name:           name,
+		Description:    fmt.Sprintf("CloudQuery staging table of %s", table.Name),
+		Schema:         bqSchema,
+		ExpirationTime: time.Now().Add(stagingTableExpiration),
+	}
+	if err := c.api.createTable(ctx, name, md); err != nil {
+		return "", err
+	}
+	c.stagingTables[table.Name] = &stagingTable{name: name, table: table}
+	return name, nil
+}
+
+// mergeStagingTables merges the staging tables of the sync into their tables, once all rows are loaded,
+// and drops them. The next sync gets new staging tables.
+func (c *Client) mergeStagingTables(ctx context.Context) error {
+	c.stagingTablesMu.Lock()
+	defer c.stagingTablesMu.Unlock()
+	tables := make([]string, 0, len(c.stagingTables))
+	for table := range c.stagingTables {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		st := c.stagingTables[table]
+		if err := c.api.query(ctx, c.mergeSQL(st.table, st.name), nil); err != nil {
+			return fmt.Errorf("failed to merge into BigQuery table %s: %w", table, err)
+		}
+		if err := c.api.deleteTable(ctx, st.name); err != nil {
+			c.logger.Warn().Err(err).Str("table", table).Str("staging_table", st.name).Msg("Failed to drop staging table")
+		}
+		delete(c.stagingTables, table)
+	}
+	c.syncID = newSyncID()
+	return nil
+}
+
+// dropStagingTables drops the staging tables that weren't merged, e.g. because the sync failed.
+// Failures are only logged, as the tables expire anyway.
+func (c *Client) dropStagingTables(ctx context.Context) {
+	c.stagingTablesMu.Lock()
+	defer c.stagingTablesMu.Unlock()
+	for table, st := range c.stagingTables {
+		if err := c.api.deleteTable(ctx, st.name); err != nil {
+			c.logger.Warn().Err(err).Str("table", table).Str("staging_table", st.name).Msg("Failed to drop staging table")
+			continue
+		}
+		delete(c.stagingTables, table)
+	}
+}
+
+// mergeSQL returns the statement merging the rows of the staging table into the table on its primary keys.
+// Rows with the same primary keys within the sync are deduplicated, keeping the last one loaded.
+func (c *Client) mergeSQL(table *schema.Table, staging string) string {
+	pks := table.PrimaryKeys()
+	partition := make([]string, len(pks))
+	on := make([]string, len(pks))
+	for i, pk := range pks {
+		partition[i] = quoteColumn(pk)
+		on[i] = fmt.Sprintf("t.%s = s.%s", quoteColumn(pk), quoteColumn(pk))
+	}
+
+	names := table.Columns.Names()
+	columns := make([]string, len(names))
+	values := make([]string, len(names))
+	set := make([]string, len(names))
+	for i, name := range names {
+		columns[i] = quoteColumn(name)
+		values[i] = "s." + quoteColumn(name)
+		set[i] = fmt.Sprintf("%s = s.%s", quoteColumn(name), quoteColumn(name))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("MERGE " + c.tableRef(table.Name) + " AS t USING (SELECT * EXCEPT (" + quoteColumn(loadOrderColumn) + ") FROM " + c.tableRef(staging))
+	sb.WriteString(" QUALIFY ROW_NUMBER() OVER (PARTITION BY " + strings.Join(partition, ", ") + " ORDER BY " + quoteColumn(loadOrderColumn) + " DESC) = 1) AS s")
+	sb.WriteString(" ON " + strings.Join(on, " AND "))
+	sb.WriteString(" WHEN MATCHED THEN UPDATE SET " + strings.Join(set, ", "))
+	sb.WriteString(" WHEN NOT MATCHED THEN INSERT (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(values, ", ") + ")")
+	return sb.String()
+}
@@ -22,12 +22,6 @@ const (
 func (c *Client) MigrateTables(ctx context.Context, msgs message.WriteMigrateTables) error {
 	eg, gctx := errgroup.WithContext(ctx)
 	eg.SetLimit(concurrentMigrations)
-	for _, msg := range msgs {
-		if len(msg.Table.PrimaryKeys()) > 0 {
-			return fmt.Errorf("primary keys are not supported by the BigQuery plugin (if you are trying to use it as a state backend, this is not currently supported)")
-		}
-	}
-
 	for _, msg := range msgs {
 		table := msg.Table
 		eg.Go(func() error {
@@ -47,7 +41,7 @@ func (c *Client) MigrateTables(ctx context.Context, msgs message.WriteMigrateTab
 				}
 			} else {
 				c.logger.Debug().Str("table", table.Name).Msg("Table doesn't exist, creating")
-				if err := c.createTable(gctx, c.client, table); err != nil {
+				if err := c.createTable(gctx, table); err != nil {
 					return err
 				}
 				err = c.waitForTableToExist(gctx, c.client, table)
@@ -201,7 +195,7 @@ func mergeSchemas(haveSchema, wantSchema bigquery.Schema) (bigquery.Schema, erro
 	return merged, nil
 }
 
-func (c *Client) createTable(ctx context.Context, client *bigquery.Client, table *schema.Table) error {
+func (c *Client) createTable(ctx context.Context, table *schema.Table) error {
 	bqSchema := c.bigQuerySchemaForTable(table)
 	tm := bigquery.TableMetadata{
 		Name:             table.Name,
@@ -209,8 +203,17 @@ func (c *Client) createTable(ctx context.Context, client *bigquery.Client, table
 		Description:      table.Description,
 		Schema:           bqSchema,
 		TimePartitioning: c.timePartitioning(),
+		Clustering:       c.clustering(table.Name),
+	}
+	return c.api.createTable(ctx, table.Name, &tm)
+}
+
+func (c *Client) clustering(table string) *bigquery.Clustering {
+	columns := c.spec.clusteringColumns(table)
+	if len(columns) == 0 {
+		return nil
 	}
-	return client.Dataset(c.spec.DatasetID).Table(table.Name).Create(ctx, &tm)
+	return &bigquery.Clustering{Fields: columns}
 }
 
 func (c *Client) timePartitioning() *bigquery.TimePartitioning {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/cloudquery/plugin-sdk/v4/configtype"
+	"github.com/cloudquery/plugin-sdk/v4/glob"
 )
 
 const (
@@ -13,6 +14,8 @@ const (
 	// documented BigQuery limit is 10MB, and we try to keep well below that as the size
 	// estimate is not exact and there are also limits on request size, apart from the batch size
 	batchSizeBytes = 5 * 1024 * 1024
+	// BigQuery tables can be clustered by up to four columns
+	maxClusteringColumns = 4
 )
 
 type TimePartitioningOption string
@@ -47,6 +50,14 @@ type Spec struct {
 	BatchSize             int                    `json:"batch_size"`
 	BatchSizeBytes        int                    `json:"batch_size_bytes"`
 	BatchTimeout          configtype.Duration    `json:"batch_timeout"`
+	Tables                []TableSpec            `json:"tables"`
+}
+
+// TableSpec clusters the tables matching any of the patterns.
+type TableSpec struct {
+	Tables []string `json:"tables"`
+	// Clustering are the columns the tables are clustered by when they are created
+	Clustering []string `json:"clustering"`
 }
 
 func (s *Spec) SetDefaults() {
@@ -74,6 +85,14 @@ func (s *Spec) Validate() error {
 	if err := s.TimePartitioning.Validate(); err != nil {
 		return fmt.Errorf("time_partitioning: %w", err)
 	}
+	for i, t := range s.Tables {
+		if len(t.Tables) == 0 {
+			return fmt.Errorf("tables[%d]: tables are required", i)
+		}
+		if len(t.Clustering) > maxClusteringColumns {
+			return fmt.Errorf("tables[%d]: tables can be clustered by up to %d columns", i, maxClusteringColumns)
+		}
+	}
 	if len(s.ServiceAccountKeyJSON) > 0 {
 		if err := isValidJson(s.ServiceAccountKeyJSON); err != nil {
 			return fmt.Errorf("invalid json for service_account_key_json: %w", err)
@@ -82,6 +101,19 @@ func (s *Spec) Validate() error {
 	return nil
 }
 
+// clusteringColumns returns the clustering columns of the first `tables` entry matching the table.
+// Tables matching no entry aren't clustered.
+func (s *Spec) clusteringColumns(table string) []string {
+	for _, t := range s.Tables {
+		for _, pattern := range t.Tables {
+			if glob.Glob(pattern, table) {
+				return t.Clustering
+			}
+		}
+	}
+	return nil
+}
+
 func isValidJson(content string) error {
 	var v map[string]any
 	err := json.Unmarshal([]byte(content), &v)
@@ -1,15 +1,19 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"cloud.google.com/go/bigquery"
 	"github.com/apache/arrow/go/v13/arrow"
 	"github.com/apache/arrow/go/v13/arrow/array"
 	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/cloudquery/plugin-sdk/v4/types"
 	"google.golang.org/api/googleapi"
 )
@@ -34,14 +38,40 @@ func (c *Client) Write(ctx context.Context, res <-chan message.WriteMessage) err
 	if err := c.writer.Flush(ctx); err != nil {
 		return fmt.Errorf("failed to flush: %w", err)
 	}
-	return nil
+	// all rows of the sync are loaded by now, so each staging table is merged once
+	return c.mergeStagingTables(ctx)
 }
 
-// WriteTableBatch(ctx context.Context, name string, msgs []*message.Insert) error
+// WriteTableBatch streams the rows into the table. The rows of tables with primary keys, which are kept
+// in the overwrite write modes, are loaded into the staging table of the sync instead, to be merged into the table
+// at the end of the sync. These are written with load jobs, as the rows in the streaming buffer can't be merged.
 func (c *Client) WriteTableBatch(ctx context.Context, name string, msgs message.WriteInserts) error {
-	inserter := c.client.Dataset(c.spec.DatasetID).Table(name).Inserter()
-	inserter.IgnoreUnknownValues = true
-	inserter.SkipInvalidRows = false
+	if len(msgs) == 0 {
+		return nil
+	}
+	table := msgs[0].GetTable()
+	if len(table.PrimaryKeys()) > 0 {
+		return c.stageTableBatch(ctx, table, msgs)
+	}
+	return c.put(ctx, name, c.items(msgs))
+}
+
+func (c *Client) stageTableBatch(ctx context.Context, table *schema.Table, msgs message.WriteInserts) error {
+	staging, err := c.stagingTable(ctx, table)
+	if err != nil {
+		return fmt.Errorf("failed to create staging table for table %s: %w", table.Name, err)
+	}
+	batch := c.items(msgs)
+	// the rows of a table are loaded one batch at a time, so numbering them here preserves the load order
+	first := c.loadOrder.Add(int64(len(batch))) - int64(len(batch))
+	for i, it := range batch {
+		it.cols[loadOrderColumn] = first + int64(i)
+	}
+	return c.load(ctx, table, staging, batch)
+}
+
+// items returns the rows of the records.
+func (c *Client) items(msgs message.WriteInserts) []*item {
 	batch := make([]*item, 0)
 	for _, msg := range msgs {
 		rec := msg.Record
@@ -60,13 +90,17 @@ func (c *Client) WriteTableBatch(ctx context.Context, name string, msgs message.
 			batch = append(batch, saver)
 		}
 	}
-	// flush final rows
+	return batch
+}
+
+// put streams the rows into the table with the given name.
+func (c *Client) put(ctx context.Context, name string, batch []*item) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, writeTimeout)
 	defer cancel()
 
-	for err := inserter.Put(timeoutCtx, batch); err != nil; err = inserter.Put(timeoutCtx, batch) {
-		// check if bigquery error is 404 (table does not exist yet), then wait a bit and retry until it does exist
-		if e, ok := err.(*googleapi.Error); ok && e.Code == 404 {
+	for err := c.api.put(timeoutCtx, name, batch); err != nil; err = c.api.put(timeoutCtx, name, batch) {
+		// check if the table does not exist yet, then wait a bit and retry until it does exist
+		if isNotFound(err) {
 			// retry
 			c.logger.Info().Str("table", name).Msg("Table does not exist yet, waiting for it to be created before retrying write")
 			time.Sleep(1 * time.Second)
@@ -78,6 +112,77 @@ func (c *Client) WriteTableBatch(ctx context.Context, name string, msgs message.
 	return nil
 }
 
+// load loads the rows of the table into the staging table with the given name.
+func (c *Client) load(ctx context.Context, table *schema.Table, name string, batch []*item) error {
+	rows, err := c.ndjson(table, batch)
+	if err != nil {
+		return fmt.Errorf("failed to encode rows of BigQuery table %s: %w", name, err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+
+	for err := c.api.load(timeoutCtx, name, bytes.NewReader(rows)); err != nil; err = c.api.load(timeoutCtx, name, bytes.NewReader(rows)) {
+		// check if the table does not exist yet, then wait a bit and retry until it does exist
+		if isNotFound(err) {
+			// retry
+			c.logger.Info().Str("table", name).Msg("Table does not exist yet, waiting for it to be created before retrying write")
+			time.Sleep(1 * time.Second)
+			continue
+		}
+		return fmt.Errorf("failed to load items into BigQuery table %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// isNotFound reports whether the error is a 404 of the API, or the not found error of a job.
+func isNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusNotFound
+	}
+	var jobErr *bigquery.Error
+	return errors.As(err, &jobErr) && jobErr.Reason == "notFound"
+}
+
+// ndjson encodes the rows as newline-delimited JSON. The values of the JSON columns, which are JSON encoded
+// strings, are written as JSON values, so that they aren't loaded as JSON strings.
+func (c *Client) ndjson(table *schema.Table, batch []*item) ([]byte, error) {
+	jsonColumns := make(map[string]bool)
+	for _, col := range table.Columns {
+		if c.DataTypeToBigQueryType(col.Type) == bigquery.JSONFieldType {
+			jsonColumns[col.Name] = true
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, it := range batch {
+		for name, v := range it.cols {
+			if jsonColumns[name] {
+				it.cols[name] = rawJSON(v)
+			}
+		}
+		if err := enc.Encode(it.cols); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func rawJSON(v bigquery.Value) bigquery.Value {
+	switch v := v.(type) {
+	case string:
+		return json.RawMessage(v)
+	case []any:
+		for i := range v {
+			v[i] = rawJSON(v[i])
+		}
+	}
+	return v
+}
+
 func (c *Client) getValueForBigQuery(col arrow.Array, i int) any {
 	switch v := col.(type) {
 	case *array.Struct:
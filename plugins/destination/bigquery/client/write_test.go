@@ -0,0 +1,279 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/rs/zerolog"
+)
+
+type fakeQuery struct {
+	stmt   string
+	params []bigquery.QueryParameter
+}
+
+// fakeAPI records the calls made to the BigQuery API.
+type fakeAPI struct {
+	created map[string]*bigquery.TableMetadata
+	deleted []string
+	// puts are the rows streamed into the tables
+	puts map[string][]*item
+	// loads are the rows loaded into the tables
+	loads   map[string][]map[string]any
+	queries []fakeQuery
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{
+		created: make(map[string]*bigquery.TableMetadata),
+		puts:    make(map[string][]*item),
+		loads:   make(map[string][]map[string]any),
+	}
+}
+
+func (a *fakeAPI) createTable(_ context.Context, table string, md *bigquery.TableMetadata) error {
+	a.created[table] = md
+	return nil
+}
+
+func (a *fakeAPI) deleteTable(_ context.Context, table string) error {
+	a.deleted = append(a.deleted, table)
+	return nil
+}
+
+func (a *fakeAPI) put(_ context.Context, table string, items []*item) error {
+	a.puts[table] = append(a.puts[table], items...)
+	return nil
+}
+
+func (a *fakeAPI) load(_ context.Context, table string, rows io.Reader) error {
+	dec := json.NewDecoder(rows)
+	for dec.More() {
+		var row map[string]any
+		if err := dec.Decode(&row); err != nil {
+			return err
+		}
+		a.loads[table] = append(a.loads[table], row)
+	}
+	return nil
+}
+
+func (a *fakeAPI) query(_ context.Context, stmt string, params []bigquery.QueryParameter) error {
+	a.queries = append(a.queries, fakeQuery{stmt: stmt, params: params})
+	return nil
+}
+
+func newFakeClient(spec Spec) (*Client, *fakeAPI) {
+	spec.ProjectID = "project"
+	spec.DatasetID = "dataset"
+	spec.SetDefaults()
+	fake := newFakeAPI()
+	return &Client{
+		logger:        zerolog.Nop(),
+		spec:          spec,
+		api:           fake,
+		syncID:        "sync",
+		stagingTables: make(map[string]*stagingTable),
+	}, fake
+}
+
+func testTable() *schema.Table {
+	return &schema.Table{
+		Name: "test_table",
+		Columns: schema.ColumnList{
+			schema.CqSourceNameColumn,
+			schema.CqSyncTimeColumn,
+			{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true},
+			{Name: "region", Type: arrow.BinaryTypes.String, PrimaryKey: true},
+			{Name: "name", Type: arrow.BinaryTypes.String},
+		},
+	}
+}
+
+func testInserts(table *schema.Table, ids ...string) message.WriteInserts {
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+	defer bldr.Release()
+	for _, id := range ids {
+		bldr.Field(0).(*array.StringBuilder).Append("source")
+		bldr.Field(1).(*array.TimestampBuilder).AppendTime(time.Now())
+		bldr.Field(2).(*array.StringBuilder).Append(id)
+		bldr.Field(3).(*array.StringBuilder).Append("us-east-1")
+		bldr.Field(4).(*array.StringBuilder).Append("name-" + id)
+	}
+	return message.WriteInserts{{Record: bldr.NewRecord()}}
+}
+
+func TestMergeSQL(t *testing.T) {
+	c, _ := newFakeClient(Spec{})
+	got := c.mergeSQL(testTable(), "test_table_cq_staging_sync")
+	want := "MERGE `project.dataset.test_table` AS t USING (SELECT * EXCEPT (`_cq_load_order`) FROM `project.dataset.test_table_cq_staging_sync`" +
+		" QUALIFY ROW_NUMBER() OVER (PARTITION BY `id`, `region` ORDER BY `_cq_load_order` DESC) = 1) AS s" +
+		" ON t.`id` = s.`id` AND t.`region` = s.`region`" +
+		" WHEN MATCHED THEN UPDATE SET `_cq_source_name` = s.`_cq_source_name`, `_cq_sync_time` = s.`_cq_sync_time`, `id` = s.`id`, `region` = s.`region`, `name` = s.`name`" +
+		" WHEN NOT MATCHED THEN INSERT (`_cq_source_name`, `_cq_sync_time`, `id`, `region`, `name`) VALUES (s.`_cq_source_name`, s.`_cq_sync_time`, s.`id`, s.`region`, s.`name`)"
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("mergeSQL mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestWriteTableBatchMerge(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeClient(Spec{})
+	table := testTable()
+
+	for _, ids := range [][]string{{"a", "b"}, {"c"}} {
+		if err := c.WriteTableBatch(ctx, table.Name, testInserts(table, ids...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the staging table is created once per sync, with a column for the load order
+	const staging = "test_table_cq_staging_sync"
+	if len(fake.created) != 1 || fake.created[staging] == nil {
+		t.Fatalf("got created tables %v, want only %s", fake.created, staging)
+	}
+	md := fake.created[staging]
+	if last := md.Schema[len(md.Schema)-1]; last.Name != loadOrderColumn {
+		t.Errorf("got last staging column %s, want %s", last.Name, loadOrderColumn)
+	}
+	if md.ExpirationTime.IsZero() {
+		t.Error("staging table doesn't expire")
+	}
+
+	if got := len(fake.loads[staging]); got != 3 {
+		t.Fatalf("got %d rows loaded into the staging table, want 3", got)
+	}
+	// the rows are numbered in load order across batches
+	for i, row := range fake.loads[staging] {
+		if row[loadOrderColumn] != float64(i) {
+			t.Errorf("row %v has load order %v, want %d", row["id"], row[loadOrderColumn], i)
+		}
+	}
+	if len(fake.loads[table.Name]) != 0 || len(fake.puts[table.Name]) != 0 {
+		t.Errorf("rows were written into the table directly")
+	}
+	if len(fake.queries) != 0 {
+		t.Fatalf("got %d queries before the end of the sync, want none", len(fake.queries))
+	}
+
+	// the staging table is merged once at the end of the sync, and dropped
+	if err := c.mergeStagingTables(ctx); err != nil {
+		t.Fatal(err)
+	}
+	want := []fakeQuery{{stmt: c.mergeSQL(table, staging)}}
+	if diff := cmp.Diff(fake.queries, want, cmp.AllowUnexported(fakeQuery{})); diff != "" {
+		t.Errorf("queries mismatch (-got +want):\n%s", diff)
+	}
+	if diff := cmp.Diff(fake.deleted, []string{staging}); diff != "" {
+		t.Errorf("dropped tables mismatch (-got +want):\n%s", diff)
+	}
+	if len(c.stagingTables) != 0 {
+		t.Errorf("got staging tables %v after the merge, want none", c.stagingTables)
+	}
+	if c.syncID == "sync" {
+		t.Error("the next sync reuses the staging table names")
+	}
+}
+
+func TestWriteTableBatchAppend(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeClient(Spec{})
+	table := testTable()
+	for i := range table.Columns {
+		table.Columns[i].PrimaryKey = false
+	}
+	if err := c.WriteTableBatch(ctx, table.Name, testInserts(table, "a", "b")); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(fake.puts[table.Name]); got != 2 {
+		t.Fatalf("got %d rows streamed into the table, want 2", got)
+	}
+	if len(fake.loads) != 0 || len(fake.created) != 0 || len(fake.queries) != 0 {
+		t.Errorf("got created tables %v and queries %v, want none", fake.created, fake.queries)
+	}
+}
+
+func TestWriteTableBatchJSON(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeClient(Spec{})
+	table := &schema.Table{
+		Name: "test_table",
+		Columns: schema.ColumnList{
+			{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true},
+			{Name: "json", Type: types.ExtensionTypes.JSON},
+			{Name: "map", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)},
+		},
+	}
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+	defer bldr.Release()
+	bldr.Field(0).(*array.StringBuilder).Append("a")
+	bldr.Field(1).(*types.JSONBuilder).Append(map[string]any{"a": 1})
+	m := bldr.Field(2).(*array.MapBuilder)
+	m.Append(true)
+	m.KeyBuilder().(*array.StringBuilder).Append("k")
+	m.ItemBuilder().(*array.StringBuilder).Append("v")
+	if err := c.WriteTableBatch(ctx, table.Name, message.WriteInserts{{Record: bldr.NewRecord()}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the JSON values are loaded into the staging table as JSON objects rather than JSON strings
+	want := []map[string]any{{
+		"id":            "a",
+		"json":          map[string]any{"a": float64(1)},
+		"map":           []any{map[string]any{"key": "k", "value": "v"}},
+		loadOrderColumn: float64(0),
+	}}
+	if diff := cmp.Diff(fake.loads["test_table_cq_staging_sync"], want); diff != "" {
+		t.Errorf("loaded rows mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestDeleteStale(t *testing.T) {
+	c, fake := newFakeClient(Spec{})
+	syncTime := time.Now()
+	err := c.DeleteStale(context.Background(), message.WriteDeleteStales{{TableName: "test_table", SourceName: "source", SyncTime: syncTime}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []fakeQuery{{
+		stmt: "DELETE FROM `project.dataset.test_table` WHERE `_cq_source_name` = @src AND `_cq_sync_time` < @t",
+		params: []bigquery.QueryParameter{
+			{Name: "src", Value: "source"},
+			{Name: "t", Value: syncTime},
+		},
+	}}
+	if diff := cmp.Diff(fake.queries, want, cmp.AllowUnexported(fakeQuery{})); diff != "" {
+		t.Errorf("queries mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestCreateTableClustering(t *testing.T) {
+	c, fake := newFakeClient(Spec{
+		Tables: []TableSpec{{Tables: []string{"test_*"}, Clustering: []string{"region", "id"}}},
+	})
+	ctx := context.Background()
+	for _, name := range []string{"test_table", "other_table"} {
+		table := testTable()
+		table.Name = name
+		if err := c.createTable(ctx, table); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if diff := cmp.Diff(fake.created["test_table"].Clustering, &bigquery.Clustering{Fields: []string{"region", "id"}}); diff != "" {
+		t.Errorf("clustering mismatch (-got +want):\n%s", diff)
+	}
+	if fake.created["other_table"].Clustering != nil {
+		t.Errorf("got clustering %v for table not matching any pattern", fake.created["other_table"].Clustering)
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
-	"github.com/cloudquery/plugin-sdk/v4/message"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
 	"github.com/cloudquery/plugin-sdk/v4/writers/batchwriter"
 	"github.com/goccy/go-json"
@@ -26,10 +25,6 @@ type Client struct {
 var _ plugin.Client = (*Client)(nil)
 var _ batchwriter.Client = (*Client)(nil)
 
-func (*Client) DeleteStale(context.Context, message.WriteDeleteStales) error {
-	return plugin.ErrNotImplemented
-}
-
 func (c *Client) Close(ctx context.Context) error {
 	if err := c.writer.Close(ctx); err != nil {
 		_ = c.conn.Close()
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/cloudquery/cloudquery/plugins/destination/clickhouse/queries"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// DeleteStale drops the partitions that only have stale rows, if the table is partitioned,
+// and removes the remaining stale rows with a lightweight delete.
+func (c *Client) DeleteStale(ctx context.Context, messages message.WriteDeleteStales) error {
+	for _, m := range messages {
+		if len(c.tableOptions(&schema.Table{Name: m.TableName}).PartitionBy) > 0 {
+			if err := c.dropStalePartitions(ctx, m); err != nil {
+				return err
+			}
+		}
+
+		query, params := queries.DeleteStale(m.TableName, c.spec.Cluster, m.SourceName, m.SyncTime)
+		if err := c.conn.Exec(lightweightDeleteContext(ctx), query, params...); err != nil {
+			return fmt.Errorf("failed to delete stale rows from %s: %w", m.TableName, err)
+		}
+	}
+	return nil
+}
+
+// lightweightDeleteContext enables lightweight deletes on servers before 23.3, where they're experimental,
+// and waits for the rows to be deleted before returning.
+func lightweightDeleteContext(ctx context.Context) context.Context {
+	return clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{
+		"allow_experimental_lightweight_delete": 1,
+		"mutations_sync":                        2,
+	}))
+}
+
+func (c *Client) dropStalePartitions(ctx context.Context, m *message.WriteDeleteStale) error {
+	query, params := queries.StalePartitions(m.TableName, m.SourceName, m.SyncTime)
+	rows, err := c.conn.Query(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to list stale partitions of %s: %w", m.TableName, err)
+	}
+	defer rows.Close()
+
+	var partitions []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		partitions = append(partitions, id)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range partitions {
+		c.logger.Debug().Str("table", m.TableName).Str("partition", id).Msg("Dropping stale partition")
+		if err := c.conn.Exec(ctx, queries.DropPartition(m.TableName, c.spec.Cluster, id)); err != nil {
+			return fmt.Errorf("failed to drop stale partition %s of %s: %w", id, m.TableName, err)
+		}
+	}
+	return nil
+}
@@ -26,6 +26,23 @@ func (c *Client) MigrateTables(ctx context.Context, messages message.WriteMigrat
 		return err
 	}
 
+	engines, err := c.getTableEngines(ctx)
+	if err != nil {
+		return err
+	}
+	forced := make(map[string]bool, len(messages))
+	for _, m := range messages {
+		forced[m.Table.Name] = m.MigrateForce
+	}
+
+	// the options are computed from the original tables, as canonized ones have no primary keys
+	options := make(map[string]*queries.TableOptions, len(messages))
+	for _, m := range messages {
+		opts := c.tableOptions(m.Table)
+		options[m.Table.Name] = opts
+		setNotNull(want.Get(m.Table.Name), have.Get(m.Table.Name), opts.NotNullColumns())
+	}
+
 	if err := c.checkForced(have, want, messages); err != nil {
 		return err
 	}
@@ -35,7 +52,7 @@ func (c *Client) MigrateTables(ctx context.Context, messages message.WriteMigrat
 	eg.SetLimit(maxConcurrentMigrate)
 
 	for _, want := range want {
-		want := want
+		want, options := want, options[want.Name]
 		eg.Go(func() (err error) {
 			c.logger.Info().Str("table", want.Name).Msg("Migrating table started")
 			defer func() {
@@ -48,10 +65,24 @@ func (c *Client) MigrateTables(ctx context.Context, messages message.WriteMigrat
 
 			have := have.Get(want.Name)
 			if have == nil {
-				return c.createTable(ctx, want)
+				return c.createTable(ctx, want, options)
 			}
 
-			return c.autoMigrate(ctx, have, want)
+			if engine := engines[want.Name]; engine != options.Engine.Name {
+				if !forced[want.Name] {
+					c.logger.Warn().Str("table", want.Name).Str("engine", engine).Str("configured_engine", options.Engine.Name).
+						Msg("table engine differs from the configured one, consider using 'migrate_mode: forced' to recreate the table")
+				} else {
+					c.logger.Info().Str("table", want.Name).Str("engine", engine).Str("configured_engine", options.Engine.Name).
+						Msg("Recreating table with the configured engine")
+					if err := c.dropTable(ctx, have); err != nil {
+						return err
+					}
+					return c.createTable(ctx, want, options)
+				}
+			}
+
+			return c.autoMigrate(ctx, have, want, options)
 		})
 	}
 
@@ -96,10 +127,27 @@ func unsafeChanges(changes []schema.TableColumnChange) []schema.TableColumnChang
 	return slices.Clip(unsafe)
 }
 
-func (c *Client) createTable(ctx context.Context, table *schema.Table) (err error) {
+// setNotNull marks the columns not Nullable, as they're created by queries.CreateTable.
+// Existing tables keep their columns as they are, as they may have been created with a different engine.
+func setNotNull(want, have *schema.Table, columns []string) {
+	for _, name := range columns {
+		idx := want.Columns.Index(name)
+		if idx < 0 {
+			continue
+		}
+		if have != nil {
+			if col := have.Columns.Get(name); col == nil || !col.NotNull {
+				continue
+			}
+		}
+		want.Columns[idx].NotNull = true
+	}
+}
+
+func (c *Client) createTable(ctx context.Context, table *schema.Table, options *queries.TableOptions) (err error) {
 	c.logger.Debug().Str("table", table.Name).Msg("Table doesn't exist, creating")
 
-	query, err := queries.CreateTable(table, c.spec.Cluster, c.spec.Engine)
+	query, err := queries.CreateTable(table, c.spec.Cluster, options)
 	if err != nil {
 		return err
 	}
@@ -131,7 +179,7 @@ func needsTableDrop(change schema.TableColumnChange) bool {
 	return true
 }
 
-func (c *Client) autoMigrate(ctx context.Context, have, want *schema.Table) error {
+func (c *Client) autoMigrate(ctx context.Context, have, want *schema.Table, options *queries.TableOptions) error {
 	changes := want.GetChanges(have)
 
 	if unsafe := unsafeChanges(changes); len(unsafe) > 0 {
@@ -140,7 +188,7 @@ func (c *Client) autoMigrate(ctx context.Context, have, want *schema.Table) erro
 			return err
 		}
 
-		return c.createTable(ctx, want)
+		return c.createTable(ctx, want, options)
 	}
 
 	for _, change := range changes {
@@ -13,7 +13,12 @@ import (
 
 func (c *Client) Read(ctx context.Context, table *schema.Table, res chan<- arrow.Record) error {
 	sc := table.ToArrowSchema()
-	query := queries.Read(table)
+	// the existing table may have been created with another engine than the configured one
+	engine, err := c.getTableEngine(ctx, table.Name)
+	if err != nil {
+		return err
+	}
+	query := queries.Read(table, (&queries.Engine{Name: engine}).Deduplicates())
 
 	rows, err := c.conn.Query(ctx, query)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/cloudquery/cloudquery/plugins/destination/clickhouse/queries"
 	"github.com/cloudquery/plugin-sdk/v4/configtype"
+	"github.com/cloudquery/plugin-sdk/v4/glob"
 )
 
 type Spec struct {
@@ -17,6 +18,8 @@ type Spec struct {
 
 	Engine *queries.Engine `json:"engine,omitempty"`
 
+	TableOptions []TableSpec `json:"table_options,omitempty"`
+
 	BatchSize      int                  `json:"batch_size,omitempty"`
 	BatchSizeBytes int                  `json:"batch_size_bytes,omitempty"`
 	BatchTimeout   *configtype.Duration `json:"batch_timeout,omitempty"`
@@ -49,11 +52,36 @@ func (s *Spec) Options() (*clickhouse.Options, error) {
 	return options, nil
 }
 
-func (s *Spec) SetDefaults() {
-	if s.Engine == nil {
-		s.Engine = queries.DefaultEngine()
+// TableSpec overrides the table options for the tables matching any of the patterns.
+// Options not set are taken from the next matching entry, or the defaults.
+type TableSpec struct {
+	Tables      []string        `json:"tables,omitempty"`
+	Engine      *queries.Engine `json:"engine,omitempty"`
+	OrderBy     []string        `json:"order_by,omitempty"`
+	PartitionBy string          `json:"partition_by,omitempty"`
+	TTL         string          `json:"ttl,omitempty"`
+}
+
+func (t *TableSpec) Validate() error {
+	if len(t.Tables) == 0 {
+		return fmt.Errorf("\"tables\" is required")
 	}
+	if t.Engine != nil {
+		return t.Engine.Validate()
+	}
+	return nil
+}
 
+func (t *TableSpec) matches(table string) bool {
+	for _, pattern := range t.Tables {
+		if glob.Glob(pattern, table) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Spec) SetDefaults() {
 	if s.BatchSize == 0 {
 		s.BatchSize = 10_000 // 10K
 	}
@@ -69,5 +97,15 @@ func (s *Spec) SetDefaults() {
 }
 
 func (s *Spec) Validate() error {
-	return s.Engine.Validate()
+	if s.Engine != nil {
+		if err := s.Engine.Validate(); err != nil {
+			return err
+		}
+	}
+	for i := range s.TableOptions {
+		if err := s.TableOptions[i].Validate(); err != nil {
+			return fmt.Errorf("invalid table_options[%d]: %w", i, err)
+		}
+	}
+	return nil
 }
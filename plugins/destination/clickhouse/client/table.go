@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	"github.com/cloudquery/cloudquery/plugins/destination/clickhouse/queries"
 	"github.com/cloudquery/plugin-sdk/v4/message"
@@ -24,3 +26,36 @@ func (c *Client) getTableDefinitions(ctx context.Context, messages message.Write
 
 	return queries.ScanTableSchemas(rows, messages)
 }
+
+// getTableEngines returns the engine names of the existing tables, by table name.
+func (c *Client) getTableEngines(ctx context.Context) (map[string]string, error) {
+	query, params := queries.GetTablesEngines(c.database)
+	rows, err := c.conn.Query(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	engines := make(map[string]string)
+	for rows.Next() {
+		var name, engine string
+		if err := rows.Scan(&name, &engine); err != nil {
+			return nil, err
+		}
+		engines[name] = engine
+	}
+	return engines, rows.Err()
+}
+
+// getTableEngine returns the engine name of the table, or an empty string if the table doesn't exist.
+func (c *Client) getTableEngine(ctx context.Context, table string) (string, error) {
+	query, params := queries.GetTableEngine(c.database, table)
+	var engine string
+	if err := c.conn.QueryRow(ctx, query, params...).Scan(&engine); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return engine, nil
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"github.com/cloudquery/cloudquery/plugins/destination/clickhouse/queries"
+	"github.com/cloudquery/cloudquery/plugins/destination/clickhouse/util"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// tableOptions returns the options of the table, merging the matching table_options entries in order.
+// Tables with primary keys default to ReplacingMergeTree sorted by the primary keys, unless an engine is configured,
+// so that writing rows again in overwrite mode replaces them.
+func (c *Client) tableOptions(table *schema.Table) *queries.TableOptions {
+	options := new(queries.TableOptions)
+	for _, t := range c.spec.TableOptions {
+		if !t.matches(table.Name) {
+			continue
+		}
+		if options.Engine == nil {
+			options.Engine = t.Engine
+		}
+		if len(options.OrderBy) == 0 {
+			options.OrderBy = t.OrderBy
+		}
+		if len(options.PartitionBy) == 0 {
+			options.PartitionBy = t.PartitionBy
+		}
+		if len(options.TTL) == 0 {
+			options.TTL = t.TTL
+		}
+	}
+
+	if options.Engine == nil {
+		options.Engine = c.spec.Engine
+	}
+	pks := table.PrimaryKeys()
+	if options.Engine == nil {
+		if len(pks) > 0 && table.Columns.Get(schema.CqSyncTimeColumn.Name) != nil {
+			options.Engine = queries.DefaultReplacingEngine()
+		} else {
+			options.Engine = queries.DefaultEngine()
+		}
+	}
+	if len(options.OrderBy) == 0 && len(pks) > 0 && options.Engine.Deduplicates() {
+		// rows are deduplicated by the sorting key
+		options.OrderBy = util.Sanitized(pks...)
+	}
+	return options
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/cloudquery/plugins/destination/clickhouse/queries"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableOptions(t *testing.T) {
+	c := &Client{spec: &Spec{TableOptions: []TableSpec{
+		{Tables: []string{"aws_ec2_*"}, PartitionBy: "toYYYYMM(`_cq_sync_time`)"},
+		{Tables: []string{"aws_*"}, Engine: &queries.Engine{Name: "ReplicatedMergeTree"}, TTL: "`_cq_sync_time` + INTERVAL 1 DAY"},
+	}}}
+
+	withPK := &schema.Table{
+		Name: "gcp_projects",
+		Columns: schema.ColumnList{
+			schema.CqSyncTimeColumn,
+			{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true},
+		},
+	}
+	require.Equal(t, &queries.TableOptions{
+		Engine:  queries.DefaultReplacingEngine(),
+		OrderBy: []string{"`id`"},
+	}, c.tableOptions(withPK))
+
+	withPK.Name = "aws_ec2_instances"
+	require.Equal(t, &queries.TableOptions{
+		Engine:      &queries.Engine{Name: "ReplicatedMergeTree"},
+		PartitionBy: "toYYYYMM(`_cq_sync_time`)",
+		TTL:         "`_cq_sync_time` + INTERVAL 1 DAY",
+	}, c.tableOptions(withPK))
+
+	require.Equal(t, &queries.TableOptions{Engine: queries.DefaultEngine()}, c.tableOptions(&schema.Table{Name: "gcp_logs"}))
+}
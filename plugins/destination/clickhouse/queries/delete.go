@@ -0,0 +1,38 @@
+package queries
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/cloudquery/cloudquery/plugins/destination/clickhouse/util"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+func staleParams(sourceName string, syncTime time.Time) []any {
+	return []any{
+		driver.NamedValue{Name: "sourceName", Value: sourceName},
+		driver.NamedValue{Name: "syncTime", Value: syncTime},
+	}
+}
+
+// StalePartitions returns the query selecting the IDs of the partitions that only have stale rows of the source.
+func StalePartitions(table string, sourceName string, syncTime time.Time) (query string, params []any) {
+	return "SELECT `_partition_id` FROM " + util.SanitizeID(table) +
+		" GROUP BY `_partition_id` HAVING countIf(" + staleCondition() + ") = count()", staleParams(sourceName, syncTime)
+}
+
+// DropPartition returns the query dropping the partition with the given ID.
+func DropPartition(table, cluster, partitionID string) string {
+	return "ALTER TABLE " + tableNamePart(table, cluster) + " DROP PARTITION ID '" + strings.ReplaceAll(partitionID, "'", "\\'") + "'"
+}
+
+// DeleteStale returns the lightweight delete query removing the stale rows of the source.
+func DeleteStale(table, cluster string, sourceName string, syncTime time.Time) (query string, params []any) {
+	return "DELETE FROM " + tableNamePart(table, cluster) + " WHERE " + staleCondition(), staleParams(sourceName, syncTime)
+}
+
+func staleCondition() string {
+	return util.SanitizeID(schema.CqSourceNameColumn.Name) + " = @sourceName AND " +
+		util.SanitizeID(schema.CqSyncTimeColumn.Name) + " < @syncTime"
+}
@@ -0,0 +1,29 @@
+package queries
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteStale(t *testing.T) {
+	query, params := DeleteStale("table_name", "", "source", time.Now())
+	ensureContents(t, query, "delete_stale.sql")
+	require.Len(t, params, 2)
+}
+
+func TestDeleteStaleOnCluster(t *testing.T) {
+	query, _ := DeleteStale("table_name", "my_cluster", "source", time.Now())
+	ensureContents(t, query, "delete_stale_cluster.sql")
+}
+
+func TestStalePartitions(t *testing.T) {
+	query, params := StalePartitions("table_name", "source", time.Now())
+	ensureContents(t, query, "stale_partitions.sql")
+	require.Len(t, params, 2)
+}
+
+func TestDropPartition(t *testing.T) {
+	ensureContents(t, DropPartition("table_name", "my_cluster", "202307"), "drop_partition_cluster.sql")
+}
@@ -1,21 +1,74 @@
 package queries
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/cloudquery/cloudquery/plugins/destination/clickhouse/util"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
 )
 
 const (
-	MergeTree = "MergeTree"
+	MergeTree          = "MergeTree"
+	ReplacingMergeTree = "ReplacingMergeTree"
 )
 
+// Identifier is an engine parameter referring to a column, such as the version column of ReplacingMergeTree.
+// Unlike string parameters, it isn't quoted as a string literal.
+// In the spec, it's given as `{"column": "<name>"}`.
+type Identifier string
+
+type identifierParam struct {
+	Column string `json:"column"`
+}
+
+func (i Identifier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(identifierParam{Column: string(i)})
+}
+
 type Engine struct {
 	Name       string `json:"name,omitempty"`
 	Parameters []any  `json:"parameters,omitempty"`
 }
 
+func (e *Engine) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name       string            `json:"name,omitempty"`
+		Parameters []json.RawMessage `json:"parameters,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	e.Name = raw.Name
+	e.Parameters = nil
+	for _, p := range raw.Parameters {
+		if trimmed := bytes.TrimSpace(p); len(trimmed) == 0 || trimmed[0] != '{' {
+			var v any
+			if err := json.Unmarshal(p, &v); err != nil {
+				return err
+			}
+			e.Parameters = append(e.Parameters, v)
+			continue
+		}
+
+		var id identifierParam
+		dec := json.NewDecoder(bytes.NewReader(p))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&id); err != nil {
+			return fmt.Errorf("invalid engine parameter %s: only {\"column\": \"<name>\"} objects are supported: %w", p, err)
+		}
+		if id.Column == "" {
+			return fmt.Errorf("invalid engine parameter %s: column name is required", p)
+		}
+		e.Parameters = append(e.Parameters, Identifier(id.Column))
+	}
+	return nil
+}
+
 func (e *Engine) String() string {
 	return e.Name + "(" + strings.Join(e.params(), ", ") + ")"
 }
@@ -25,6 +78,8 @@ func (e *Engine) params() []string {
 
 	for i, p := range e.Parameters {
 		switch t := p.(type) {
+		case Identifier:
+			res[i] = util.SanitizeID(string(t))
 		case string:
 			res[i] = "'" + t + "'"
 		case int:
@@ -60,7 +115,7 @@ func (e *Engine) Validate() error {
 
 	for _, p := range e.Parameters {
 		switch t := p.(type) {
-		case string, int, int32, int64, float32, float64, json.Number, bool: // supported types
+		case Identifier, string, int, int32, int64, float32, float64, json.Number, bool: // supported types
 		default:
 			return fmt.Errorf("unsupported engine option type %T", t)
 		}
@@ -69,6 +124,29 @@ func (e *Engine) Validate() error {
 	return nil
 }
 
+// Deduplicates returns whether the engine replaces rows with the same sorting key, so that reads should use FINAL.
+func (e *Engine) Deduplicates() bool {
+	return strings.HasSuffix(e.Name, ReplacingMergeTree)
+}
+
+// columns returns the columns referred to by the engine parameters.
+// They can't be Nullable.
+func (e *Engine) columns() []string {
+	var res []string
+	for _, p := range e.Parameters {
+		if id, ok := p.(Identifier); ok {
+			res = append(res, string(id))
+		}
+	}
+	return res
+}
+
 func DefaultEngine() *Engine {
 	return &Engine{Name: MergeTree}
 }
+
+// DefaultReplacingEngine is used for tables with primary keys,
+// so that rows written again in overwrite mode replace the ones from the previous syncs.
+func DefaultReplacingEngine() *Engine {
+	return &Engine{Name: ReplacingMergeTree, Parameters: []any{Identifier(schema.CqSyncTimeColumn.Name)}}
+}
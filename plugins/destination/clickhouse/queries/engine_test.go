@@ -0,0 +1,25 @@
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineUnmarshalJSON(t *testing.T) {
+	var e Engine
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"ReplacingMergeTree","parameters":[{"column":"updated_at"},"a",1,true]}`), &e))
+	require.Equal(t, Engine{Name: ReplacingMergeTree, Parameters: []any{Identifier("updated_at"), "a", float64(1), true}}, e)
+	require.Equal(t, "ReplacingMergeTree(`updated_at`, 'a', 1, true)", e.String())
+	require.Equal(t, []string{"updated_at"}, e.columns())
+
+	b, err := json.Marshal(&e)
+	require.NoError(t, err)
+	var roundTrip Engine
+	require.NoError(t, json.Unmarshal(b, &roundTrip))
+	require.Equal(t, e, roundTrip)
+
+	require.Error(t, json.Unmarshal([]byte(`{"name":"ReplacingMergeTree","parameters":[{"col":"updated_at"}]}`), &e))
+	require.Error(t, json.Unmarshal([]byte(`{"name":"ReplacingMergeTree","parameters":[{"column":""}]}`), &e))
+}
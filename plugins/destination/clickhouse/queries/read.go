@@ -7,7 +7,13 @@ import (
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 )
 
-func Read(table *schema.Table) string {
-	return "SELECT " + strings.Join(util.Sanitized(table.Columns.Names()...), ", ") +
+// Read returns the query selecting all rows of the table.
+// With final set, rows replaced by the table engine are skipped even if they weren't merged yet.
+func Read(table *schema.Table, final bool) string {
+	query := "SELECT " + strings.Join(util.Sanitized(table.Columns.Names()...), ", ") +
 		` FROM ` + util.SanitizeID(table.Name)
+	if final {
+		query += " FINAL"
+	}
+	return query
 }
@@ -17,5 +17,5 @@ func TestRead(t *testing.T) {
 			schema.CqSyncTimeColumn,
 			schema.Column{Name: "extra_col", Type: arrow.PrimitiveTypes.Float64},
 		},
-	}), "read.sql")
+	}, false), "read.sql")
 }
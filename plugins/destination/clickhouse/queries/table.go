@@ -13,6 +13,21 @@ func GetTablesSchema(database string) (query string, params []any) {
 	return tableSchemaQuery, []any{driver.NamedValue{Name: "databaseName", Value: database}}
 }
 
+// GetTablesEngines returns the query selecting the engine name of every table in the database.
+func GetTablesEngines(database string) (query string, params []any) {
+	const tablesEngineQuery = "SELECT `name`, `engine` FROM system.tables WHERE `database` = @databaseName"
+	return tablesEngineQuery, []any{driver.NamedValue{Name: "databaseName", Value: database}}
+}
+
+// GetTableEngine returns the query selecting the engine name of the table.
+func GetTableEngine(database, table string) (query string, params []any) {
+	const tableEngineQuery = "SELECT `engine` FROM system.tables WHERE `database` = @databaseName AND `name` = @tableName"
+	return tableEngineQuery, []any{
+		driver.NamedValue{Name: "databaseName", Value: database},
+		driver.NamedValue{Name: "tableName", Value: table},
+	}
+}
+
 // ScanTableSchemas doesn't close rows, so that's on caller.
 func ScanTableSchemas(rows driver.Rows, messages message.WriteMigrateTables) (schema.Tables, error) {
 	defs := make(map[string]schema.ColumnList, len(messages))
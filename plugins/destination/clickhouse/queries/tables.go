@@ -26,13 +26,31 @@ func sortKeys(table *schema.Table) []string {
 	return slices.Clip(keys)
 }
 
-func CreateTable(table *schema.Table, cluster string, engine *Engine) (string, error) {
+// TableOptions are the table engine and the clauses of CREATE TABLE that can be set per table.
+type TableOptions struct {
+	Engine *Engine
+	// OrderBy expressions, the sorting key is derived from the table columns if empty
+	OrderBy     []string
+	PartitionBy string
+	TTL         string
+}
+
+// NotNullColumns returns the columns that have to be created as not Nullable for the table options to be valid.
+func (o *TableOptions) NotNullColumns() []string {
+	return o.Engine.columns()
+}
+
+func CreateTable(table *schema.Table, cluster string, options *TableOptions) (string, error) {
+	notNull := options.NotNullColumns()
 	builder := strings.Builder{}
 	builder.WriteString("CREATE TABLE ")
 	builder.WriteString(tableNamePart(table.Name, cluster))
 	builder.WriteString(" (\n")
 	builder.WriteString("  ")
 	for i, col := range table.Columns {
+		if slices.Contains(notNull, col.Name) {
+			col.NotNull = true
+		}
 		definition, err := types.FieldDefinition(col.ToArrowField())
 		if err != nil {
 			return "", err
@@ -43,15 +61,28 @@ func CreateTable(table *schema.Table, cluster string, engine *Engine) (string, e
 		}
 	}
 	builder.WriteString("\n) ENGINE = ")
-	builder.WriteString(engine.String())
+	builder.WriteString(options.Engine.String())
 	builder.WriteString(" ORDER BY ")
-	if orderBy := sortKeys(table); len(orderBy) > 0 {
+	switch orderBy := sortKeys(table); {
+	case len(options.OrderBy) > 0:
+		builder.WriteString("(")
+		builder.WriteString(strings.Join(options.OrderBy, ", "))
+		builder.WriteString(")")
+	case len(orderBy) > 0:
 		builder.WriteString("(")
 		builder.WriteString(strings.Join(util.Sanitized(orderBy...), ", "))
 		builder.WriteString(")")
-	} else {
+	default:
 		builder.WriteString("tuple()")
 	}
+	if len(options.PartitionBy) > 0 {
+		builder.WriteString(" PARTITION BY ")
+		builder.WriteString(options.PartitionBy)
+	}
+	if len(options.TTL) > 0 {
+		builder.WriteString(" TTL ")
+		builder.WriteString(options.TTL)
+	}
 	builder.WriteString(" SETTINGS allow_nullable_key=1") // allows nullable keys
 
 	return builder.String(), nil
@@ -26,7 +26,7 @@ func TestCreateTable(t *testing.T) {
 			schema.Column{Name: "extra_inet_col", Type: types.NewInetType()},
 			schema.Column{Name: "extra_inet_arr_col", Type: arrow.ListOf(types.NewInetType())},
 		},
-	}, "", DefaultEngine())
+	}, "", &TableOptions{Engine: DefaultEngine()})
 	require.NoError(t, err)
 	ensureContents(t, query, "create_table.sql")
 }
@@ -39,7 +39,7 @@ func TestCreateTableNoOrderBy(t *testing.T) {
 			schema.Column{Name: "extra_inet_col", Type: types.NewInetType()},
 			schema.Column{Name: "extra_inet_arr_col", Type: arrow.ListOf(types.NewInetType())},
 		},
-	}, "", DefaultEngine())
+	}, "", &TableOptions{Engine: DefaultEngine()})
 	require.NoError(t, err)
 	ensureContents(t, query, "create_table_empty_order_by.sql")
 }
@@ -60,7 +60,7 @@ func TestCreateTableOnCluster(t *testing.T) {
 			schema.Column{Name: "extra_inet_col", Type: types.NewInetType()},
 			schema.Column{Name: "extra_inet_arr_col", Type: arrow.ListOf(types.NewInetType())},
 		},
-	}, "my_cluster", DefaultEngine())
+	}, "my_cluster", &TableOptions{Engine: DefaultEngine()})
 	require.NoError(t, err)
 	ensureContents(t, query, "create_table_cluster.sql")
 }
@@ -81,14 +81,33 @@ func TestCreateTableWithEngine(t *testing.T) {
 			schema.Column{Name: "extra_inet_col", Type: types.NewInetType()},
 			schema.Column{Name: "extra_inet_arr_col", Type: arrow.ListOf(types.NewInetType())},
 		},
-	}, "", &Engine{
+	}, "", &TableOptions{Engine: &Engine{
 		Name:       "ReplicatedMergeTree",
 		Parameters: []any{"a", "b", 1, int32(2), int64(3), float32(1.2), float64(3.4), json.Number("327"), false, true},
-	})
+	}})
 	require.NoError(t, err)
 	ensureContents(t, query, "create_table_engine.sql")
 }
 
+func TestCreateTableReplacing(t *testing.T) {
+	query, err := CreateTable(&schema.Table{
+		Name: "table_name",
+		Columns: schema.ColumnList{
+			schema.CqIDColumn,
+			schema.CqSourceNameColumn,
+			schema.CqSyncTimeColumn,
+			schema.Column{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true, NotNull: true},
+		},
+	}, "", &TableOptions{
+		Engine:      DefaultReplacingEngine(),
+		OrderBy:     []string{"`id`"},
+		PartitionBy: "toYYYYMM(`_cq_sync_time`)",
+		TTL:         "toDateTime(`_cq_sync_time`) + INTERVAL 30 DAY",
+	})
+	require.NoError(t, err)
+	ensureContents(t, query, "create_table_replacing.sql")
+}
+
 func TestDropTable(t *testing.T) {
 	query := DropTable(&schema.Table{Name: "table_name"}, "")
 
@@ -0,0 +1,6 @@
+CREATE TABLE `table_name` (
+  `_cq_id` UUID,
+  `_cq_source_name` Nullable(String),
+  `_cq_sync_time` DateTime64(6),
+  `id` String
+) ENGINE = ReplacingMergeTree(`_cq_sync_time`) ORDER BY (`id`) PARTITION BY toYYYYMM(`_cq_sync_time`) TTL toDateTime(`_cq_sync_time`) + INTERVAL 30 DAY SETTINGS allow_nullable_key=1
\ No newline at end of file
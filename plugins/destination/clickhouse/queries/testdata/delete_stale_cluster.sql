@@ -0,0 +1 @@
+DELETE FROM `table_name` ON CLUSTER `my_cluster` WHERE `_cq_source_name` = @sourceName AND `_cq_sync_time` < @syncTime
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `table_name` ON CLUSTER `my_cluster` DROP PARTITION ID '202307'
\ No newline at end of file
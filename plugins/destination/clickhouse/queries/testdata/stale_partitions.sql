@@ -0,0 +1 @@
+SELECT `_partition_id` FROM `table_name` GROUP BY `_partition_id` HAVING countIf(`_cq_source_name` = @sourceName AND `_cq_sync_time` < @syncTime) = count()
\ No newline at end of file
@@ -6,8 +6,10 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/cloudquery/plugin-sdk/v4/writers/batchwriter"
 	"github.com/rs/zerolog"
 
@@ -22,6 +24,10 @@ type Client struct {
 	logger    zerolog.Logger
 	spec      Spec
 	writer    *batchwriter.BatchWriter
+
+	// exportTables are the tables migrated in the current sync, exported to Parquet once it's done
+	exportTables   schema.Tables
+	exportTablesMu sync.Mutex
 }
 
 var _ plugin.Client = (*Client)(nil)
@@ -35,6 +41,9 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, _ plugin.NewCl
 		return nil, fmt.Errorf("failed to unmarshal spec: %w", err)
 	}
 	c.spec.SetDefaults()
+	if err := c.spec.Validate(); err != nil {
+		return nil, err
+	}
 	c.writer, err = batchwriter.New(c, batchwriter.WithBatchSize(c.spec.BatchSize), batchwriter.WithBatchSizeBytes(c.spec.BatchSizeBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create batch writer: %w", err)
@@ -54,6 +63,11 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, _ plugin.NewCl
 	if err != nil {
 		return nil, err
 	}
+	if c.spec.Schema != "" {
+		if err := c.exec(ctx, "CREATE SCHEMA IF NOT EXISTS "+sanitizeID(c.spec.Schema)); err != nil {
+			return nil, fmt.Errorf("failed to create schema %s: %w", c.spec.Schema, err)
+		}
+	}
 
 	return c, nil
 }
@@ -91,3 +105,27 @@ func (c *Client) exec(ctx context.Context, query string, args ...any) error {
 	}
 	return nil
 }
+
+// tableRef returns the quoted name of the table, qualified with the schema if it's set.
+func (c *Client) tableRef(name string) string {
+	if c.spec.Schema == "" {
+		return sanitizeID(name)
+	}
+	return sanitizeID(c.spec.Schema) + "." + sanitizeID(name)
+}
+
+// qualifiedName returns the unquoted name of the table, qualified with the schema if it's set.
+func (c *Client) qualifiedName(name string) string {
+	if c.spec.Schema == "" {
+		return name
+	}
+	return c.spec.Schema + "." + name
+}
+
+// schemaName returns the schema the tables are written to.
+func (c *Client) schemaName() string {
+	if c.spec.Schema == "" {
+		return "main"
+	}
+	return c.spec.Schema
+}
@@ -3,11 +3,18 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/cloudquery/plugin-sdk/v4/message"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/cloudquery/plugin-sdk/v4/types"
+	"github.com/rs/zerolog"
 )
 
 func TestPlugin(t *testing.T) {
@@ -47,3 +54,114 @@ func TestPlugin(t *testing.T) {
 		}),
 	)
 }
+
+func TestPluginSchema(t *testing.T) {
+	ctx := context.Background()
+	if err := types.RegisterAllExtensions(); err != nil {
+		t.Fatal(err)
+	}
+
+	p := plugin.NewPlugin("duckdb", "development", New)
+	spec := Spec{
+		ConnectionString: "?threads=1",
+		Schema:           "cloudquery",
+	}
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Init(ctx, specBytes, plugin.NewClientOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	plugin.TestWriterSuiteRunner(t,
+		p,
+		plugin.WriterTestSuiteTests{
+			SkipMigrate: true,
+		},
+		plugin.WithTestDataOptions(schema.TestSourceOptions{
+			SkipDurations:  true,
+			SkipIntervals:  true,
+			SkipTimes:      true,
+			SkipDates:      true,
+			SkipLargeTypes: true,
+		}),
+	)
+}
+
+func TestParquetExportAndComments(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	spec := Spec{
+		ConnectionString: "?threads=1",
+		Schema:           "cloudquery",
+		Comments:         true,
+		ParquetExport:    &ParquetExportSpec{Path: dir, PartitionBy: []string{"region"}},
+	}
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc, err := New(ctx, zerolog.Nop(), specBytes, plugin.NewClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := pc.(*Client)
+	defer c.Close(ctx)
+
+	partitioned := &schema.Table{
+		Name:        "test_partitioned",
+		Description: "A partitioned table",
+		Columns: schema.ColumnList{
+			{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true, Description: "The ID"},
+			{Name: "region", Type: arrow.BinaryTypes.String},
+		},
+	}
+	unpartitioned := &schema.Table{
+		Name:    "test_unpartitioned",
+		Columns: schema.ColumnList{{Name: "id", Type: arrow.BinaryTypes.String}},
+	}
+
+	msgs := make(chan message.WriteMessage, 4)
+	msgs <- &message.WriteMigrateTable{Table: partitioned}
+	msgs <- &message.WriteMigrateTable{Table: unpartitioned}
+	msgs <- &message.WriteInsert{Record: stringRecord(partitioned, []string{"a", "us-east-1"}, []string{"b", "eu-west-1"})}
+	msgs <- &message.WriteInsert{Record: stringRecord(unpartitioned, []string{"a"})}
+	close(msgs)
+	if err := c.Write(ctx, msgs); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, region := range []string{"us-east-1", "eu-west-1"} {
+		files, err := filepath.Glob(filepath.Join(dir, "test_partitioned", "region="+region, "*.parquet"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) == 0 {
+			t.Fatalf("no parquet files exported for region %s", region)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "test_unpartitioned.parquet")); err != nil {
+		t.Fatal(err)
+	}
+
+	var comment string
+	row := c.db.QueryRowContext(ctx, `select comment from "cloudquery"."_cq_comments" where table_name = 'test_partitioned' and column_name = 'id'`)
+	if err := row.Scan(&comment); err != nil {
+		t.Fatal(err)
+	}
+	if comment != "The ID" {
+		t.Fatalf("got column comment %q, want %q", comment, "The ID")
+	}
+}
+
+// stringRecord builds a record of the table, which must only have string columns.
+func stringRecord(table *schema.Table, rows ...[]string) arrow.Record {
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+	defer bldr.Release()
+	for _, row := range rows {
+		for i, v := range row {
+			bldr.Field(i).(*array.StringBuilder).Append(v)
+		}
+	}
+	return bldr.NewRecord()
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// commentsTable holds the descriptions of the tables and their columns, as DuckDB doesn't support comments on them.
+// Table descriptions have a NULL column_name.
+const commentsTable = "_cq_comments"
+
+func (c *Client) writeComments(ctx context.Context, tables schema.Tables) error {
+	ref := c.tableRef(commentsTable)
+	if err := c.exec(ctx, "CREATE TABLE IF NOT EXISTS "+ref+" (table_name VARCHAR NOT NULL, column_name VARCHAR, comment VARCHAR NOT NULL)"); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if err := c.exec(ctx, "DELETE FROM "+ref+" WHERE table_name = $1", table.Name); err != nil {
+			return err
+		}
+		if table.Description != "" {
+			if err := c.exec(ctx, "INSERT INTO "+ref+" (table_name, comment) VALUES ($1, $2)", table.Name, table.Description); err != nil {
+				return err
+			}
+		}
+		for _, col := range table.Columns {
+			if col.Description == "" {
+				continue
+			}
+			if err := c.exec(ctx, "INSERT INTO "+ref+" (table_name, column_name, comment) VALUES ($1, $2, $3)", table.Name, col.Name, col.Description); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -15,7 +15,7 @@ func (c *Client) DeleteStale(ctx context.Context, msgs message.WriteDeleteStales
 		syncTime := msg.SyncTime
 		var sb strings.Builder
 		sb.WriteString("delete from ")
-		sb.WriteString(sanitizeID(tableName))
+		sb.WriteString(c.tableRef(tableName))
 		sb.WriteString(" where ")
 		sb.WriteString(sanitizeID(schema.CqSourceNameColumn.Name))
 		sb.WriteString(" = $1 and ")
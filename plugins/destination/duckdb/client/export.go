@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+func (c *Client) addExportTables(tables schema.Tables) {
+	c.exportTablesMu.Lock()
+	defer c.exportTablesMu.Unlock()
+	for _, table := range tables {
+		// tables without columns aren't created
+		if len(table.Columns) > 0 && c.exportTables.Get(table.Name) == nil {
+			c.exportTables = append(c.exportTables, table)
+		}
+	}
+}
+
+// exportParquet copies the tables migrated in the sync to Parquet files, replacing the ones of the previous sync.
+func (c *Client) exportParquet(ctx context.Context) error {
+	c.exportTablesMu.Lock()
+	tables := c.exportTables
+	c.exportTables = nil
+	c.exportTablesMu.Unlock()
+
+	if err := os.MkdirAll(c.spec.ParquetExport.Path, 0o755); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if err := c.exportTable(ctx, table); err != nil {
+			return fmt.Errorf("failed to export table %s: %w", table.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) exportTable(ctx context.Context, table *schema.Table) error {
+	partitionBy := c.partitionColumns(table)
+	dst := filepath.Join(c.spec.ParquetExport.Path, table.Name)
+	if len(partitionBy) == 0 {
+		dst += ".parquet"
+	}
+	// partitioned exports are written to a directory, which COPY doesn't overwrite
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("copy " + c.tableRef(table.Name) + " to '" + strings.ReplaceAll(dst, "'", "''") + "' (FORMAT PARQUET")
+	if len(partitionBy) > 0 {
+		sb.WriteString(", PARTITION_BY (" + strings.Join(sanitized(partitionBy), ", ") + ")")
+	}
+	sb.WriteString(")")
+	return c.exec(ctx, sb.String())
+}
+
+// partitionColumns returns the columns to partition the table by, if it has all of them.
+func (c *Client) partitionColumns(table *schema.Table) []string {
+	for _, name := range c.spec.ParquetExport.PartitionBy {
+		if table.Columns.Get(name) == nil {
+			return nil
+		}
+	}
+	return c.spec.ParquetExport.PartitionBy
+}
@@ -11,7 +11,7 @@ import (
 
 const (
 	sqlTableInfo      = "PRAGMA table_info('%s');"
-	isColumnUniqueSQL = "select count(*) from duckdb_constraints where table_name = $1 and constraint_type = 'UNIQUE' and constraint_column_names=[$2]"
+	isColumnUniqueSQL = "select count(*) from duckdb_constraints where schema_name = $1 and table_name = $2 and constraint_type = 'UNIQUE' and constraint_column_names=[$3]"
 )
 
 type columnInfo struct {
@@ -182,11 +182,19 @@ func (c *Client) MigrateTables(ctx context.Context, msgs message.WriteMigrateTab
 		}
 	}
 
+	if c.spec.Comments {
+		if err := c.writeComments(ctx, tables); err != nil {
+			return fmt.Errorf("failed to write comments: %w", err)
+		}
+	}
+	if c.spec.ParquetExport != nil {
+		c.addExportTables(tables)
+	}
 	return nil
 }
 
 func (c *Client) recreateTable(ctx context.Context, table *schema.Table) error {
-	sql := "drop table if exists " + sanitizeID(table.Name)
+	sql := "drop table if exists " + c.tableRef(table.Name)
 	if err := c.exec(ctx, sql); err != nil {
 		return err
 	}
@@ -194,14 +202,14 @@ func (c *Client) recreateTable(ctx context.Context, table *schema.Table) error {
 }
 
 func (c *Client) addColumn(ctx context.Context, tableName string, columnName string, columnType string) error {
-	sql := "alter table " + sanitizeID(tableName) + " add column " + sanitizeID(columnName) + " " + columnType
+	sql := "alter table " + c.tableRef(tableName) + " add column " + sanitizeID(columnName) + " " + columnType
 	return c.exec(ctx, sql)
 }
 
 func (c *Client) createTableIfNotExist(ctx context.Context, tableName string, table *schema.Table) error {
 	var sb strings.Builder
 	sb.WriteString("CREATE TABLE IF NOT EXISTS ")
-	sb.WriteString(sanitizeID(tableName))
+	sb.WriteString(c.tableRef(tableName))
 	sb.WriteString(" (")
 	totalColumns := len(table.Columns)
 
@@ -238,7 +246,7 @@ func (c *Client) createTableIfNotExist(ctx context.Context, tableName string, ta
 }
 
 func (c *Client) isColumnUnique(ctx context.Context, tableName string, columName string) (bool, error) {
-	rows, err := c.db.QueryContext(ctx, isColumnUniqueSQL, tableName, columName)
+	rows, err := c.db.QueryContext(ctx, isColumnUniqueSQL, c.schemaName(), tableName, columName)
 	if err != nil {
 		return false, err
 	}
@@ -261,7 +269,7 @@ func (c *Client) isColumnUnique(ctx context.Context, tableName string, columName
 
 func (c *Client) getTableInfo(ctx context.Context, tableName string) (*tableInfo, error) {
 	info := tableInfo{}
-	rows, err := c.db.QueryContext(ctx, fmt.Sprintf(sqlTableInfo, tableName))
+	rows, err := c.db.QueryContext(ctx, fmt.Sprintf(sqlTableInfo, c.qualifiedName(tableName)))
 	if err != nil {
 		if strings.Contains(err.Error(), fmt.Sprintf("Table with name %s does not exist!", tableName)) {
 			// Table doesn't exist
@@ -30,7 +30,7 @@ func (c *Client) Read(ctx context.Context, table *schema.Table, res chan<- arrow
 	}
 
 	var sb strings.Builder
-	sb.WriteString("copy " + sanitizeID(table.Name) + " (")
+	sb.WriteString("copy " + c.tableRef(table.Name) + " (")
 	for i, col := range sc.Fields() {
 		sb.WriteString(sanitizeID(col.Name))
 		if i < len(sc.Fields())-1 {
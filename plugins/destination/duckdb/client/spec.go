@@ -1,5 +1,7 @@
 package client
 
+import "fmt"
+
 const (
 	defaultBatchSize      = 1000
 	defaultBatchSizeBytes = 1024 * 1024 * 4 // 10MB
@@ -7,9 +9,23 @@ const (
 
 type Spec struct {
 	ConnectionString string `json:"connection_string,omitempty"`
-	BatchSize        int    `json:"batch_size,omitempty"`
-	BatchSizeBytes   int    `json:"batch_size_bytes,omitempty"`
-	Debug            bool   `json:"debug,omitempty"`
+	// Schema is the schema the tables are written to, created if it doesn't exist. Defaults to the main schema.
+	Schema string `json:"schema,omitempty"`
+	// Comments enables persisting the table and column descriptions to the _cq_comments table.
+	Comments bool `json:"comments,omitempty"`
+	// ParquetExport enables copying the tables written in a sync to Parquet files once it's done.
+	ParquetExport  *ParquetExportSpec `json:"parquet_export,omitempty"`
+	BatchSize      int                `json:"batch_size,omitempty"`
+	BatchSizeBytes int                `json:"batch_size_bytes,omitempty"`
+	Debug          bool               `json:"debug,omitempty"`
+}
+
+type ParquetExportSpec struct {
+	// Path is the directory the tables are exported to, as <path>/<table>.parquet,
+	// or as <path>/<table>/<column>=<value>/... if they're partitioned.
+	Path string `json:"path"`
+	// PartitionBy are the columns the tables having all of them are partitioned by.
+	PartitionBy []string `json:"partition_by,omitempty"`
 }
 
 func (s *Spec) SetDefaults() {
@@ -20,3 +36,10 @@ func (s *Spec) SetDefaults() {
 		s.BatchSizeBytes = defaultBatchSizeBytes
 	}
 }
+
+func (s *Spec) Validate() error {
+	if s.ParquetExport != nil && s.ParquetExport.Path == "" {
+		return fmt.Errorf("parquet_export.path is required")
+	}
+	return nil
+}
@@ -51,12 +51,12 @@ func dtContainsList(dt arrow.DataType) bool {
 func (c *Client) upsert(ctx context.Context, tmpTableName string, table *schema.Table) error {
 	var sb strings.Builder
 	sb.WriteString("INSERT INTO ")
-	sb.WriteString(table.Name)
+	sb.WriteString(c.tableRef(table.Name))
 	sb.WriteString("(" + strings.Join(sanitized(table.Columns.Names()), ", ") + ")")
 	sb.WriteString(" SELECT ")
 	sb.WriteString(strings.Join(sanitized(table.Columns.Names()), ", "))
 	sb.WriteString(" FROM ")
-	sb.WriteString(tmpTableName)
+	sb.WriteString(c.tableRef(tmpTableName))
 	sb.WriteString(" ON CONFLICT (" + strings.Join(table.PrimaryKeys(), ", ") + ")")
 	indices := nonPkIndices(table)
 	if len(indices) == 0 {
@@ -96,21 +96,21 @@ func (c *Client) upsert(ctx context.Context, tmpTableName string, table *schema.
 
 func (c *Client) deleteByPK(ctx context.Context, tmpTableName string, table *schema.Table) error {
 	var sb strings.Builder
-	sb.WriteString("delete from " + table.Name + " using " + tmpTableName + " where ")
+	sb.WriteString("delete from " + c.tableRef(table.Name) + " using " + c.tableRef(tmpTableName) + " where ")
 	for i, col := range table.PrimaryKeys() {
 		if i > 0 {
 			sb.WriteString(" and ")
 		}
-		sb.WriteString(table.Name + "." + col)
+		sb.WriteString(c.tableRef(table.Name) + "." + sanitizeID(col))
 		sb.WriteString(" = ")
-		sb.WriteString(tmpTableName + "." + col)
+		sb.WriteString(c.tableRef(tmpTableName) + "." + sanitizeID(col))
 	}
 
 	return c.exec(ctx, sb.String())
 }
 
 func (c *Client) copyFromFile(ctx context.Context, tableName string, fileName string, table *schema.Table) error {
-	return c.exec(ctx, "copy "+tableName+
+	return c.exec(ctx, "copy "+c.tableRef(tableName)+
 		"("+strings.Join(sanitized(table.Columns.Names()), ", ")+
 		") from '"+fileName+"' (FORMAT PARQUET)")
 }
@@ -122,6 +122,11 @@ func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) er
 	if err := c.writer.Flush(ctx); err != nil {
 		return fmt.Errorf("failed to flush messages: %w", err)
 	}
+	if c.spec.ParquetExport != nil {
+		if err := c.exportParquet(ctx); err != nil {
+			return fmt.Errorf("failed to export tables to parquet: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -145,7 +150,7 @@ func (c *Client) WriteTableBatch(ctx context.Context, name string, msgs message.
 		return fmt.Errorf("failed to create table %s: %w", tmpTableName, err)
 	}
 	defer func() {
-		e := c.exec(ctx, "drop table "+tmpTableName)
+		e := c.exec(ctx, "drop table "+c.tableRef(tmpTableName))
 		if err == nil {
 			// we preserve original error, so update only on nil err
 			err = e
@@ -208,12 +213,12 @@ func (c *Client) deleteInsert(ctx context.Context, tmpTableName string, table *s
 
 	sb := new(strings.Builder)
 	sb.WriteString("INSERT INTO ")
-	sb.WriteString(table.Name)
+	sb.WriteString(c.tableRef(table.Name))
 	sb.WriteString("(" + strings.Join(sanitized(table.Columns.Names()), ", ") + ")")
 	sb.WriteString(" SELECT ")
 	sb.WriteString(strings.Join(sanitized(table.Columns.Names()), ", "))
 	sb.WriteString(" FROM ")
-	sb.WriteString(tmpTableName)
+	sb.WriteString(c.tableRef(tmpTableName))
 	sb.WriteString(" ON CONFLICT DO NOTHING")
 	query := sb.String()
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -13,6 +14,7 @@ import (
 	"github.com/cloudquery/plugin-sdk/v4/writers/batchwriter"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/rs/zerolog"
+	"golang.org/x/exp/slices"
 )
 
 type Client struct {
@@ -97,18 +99,72 @@ func (c *Client) Close(ctx context.Context) error {
 	return nil
 }
 
-func (*Client) getIndexNamePattern(table *schema.Table) string {
-	hasPrimaryKeys := len(table.PrimaryKeys()) > 0
-	if hasPrimaryKeys {
-		return table.Name
+// isDataStream reports whether the table is written to a data stream. Only append-only tables, i.e. tables without primary keys, can be.
+func (c *Client) isDataStream(table *schema.Table) bool {
+	return c.spec.DataStreams && len(table.PrimaryKeys()) == 0
+}
+
+func (c *Client) indexNameTemplate(table *schema.Table) string {
+	switch {
+	case c.spec.IndexName != "":
+		return c.spec.IndexName
+	case len(table.PrimaryKeys()) > 0:
+		return defaultIndexName
+	default:
+		return defaultAppendIndexName
+	}
+}
+
+// getIndexTemplateName returns the name of the index template of the table.
+func (c *Client) getIndexTemplateName(table *schema.Table) string {
+	return c.spec.IndexPrefix + table.Name
+}
+
+// getIndexNamePattern returns the pattern matching all indices of the table, or the name of its data stream.
+func (c *Client) getIndexNamePattern(table *schema.Table) string {
+	if c.isDataStream(table) {
+		return c.spec.IndexPrefix + table.Name
 	}
-	return table.Name + "-*"
+	return c.spec.IndexPrefix + indexNamePattern(c.indexNameTemplate(table), table.Name)
 }
 
-func (*Client) getIndexName(table *schema.Table, t time.Time) string {
-	hasPrimaryKeys := len(table.PrimaryKeys()) > 0
-	if hasPrimaryKeys {
-		return table.Name
+// getIndexName returns the index (or data stream) that records of the table written at the given time go to.
+func (c *Client) getIndexName(table *schema.Table, t time.Time) string {
+	if c.isDataStream(table) {
+		return c.spec.IndexPrefix + table.Name
 	}
-	return table.Name + "-" + t.Format("2006-01-02")
+	t = t.UTC()
+	return c.spec.IndexPrefix + strings.NewReplacer(
+		varTable, table.Name,
+		varYear, t.Format("2006"),
+		varMonth, t.Format("01"),
+		varDay, t.Format("02"),
+	).Replace(c.indexNameTemplate(table))
+}
+
+// getIndexNamePatterns returns the patterns matching all indices and data streams a table may have been written to,
+// for when only its name is known.
+func (c *Client) getIndexNamePatterns(tableName string) []string {
+	templates := []string{c.spec.IndexName}
+	if c.spec.IndexName == "" {
+		templates = []string{defaultIndexName, defaultAppendIndexName}
+	}
+	patterns := make([]string, 0, len(templates)+1)
+	for _, tmpl := range templates {
+		patterns = append(patterns, c.spec.IndexPrefix+indexNamePattern(tmpl, tableName))
+	}
+	if c.spec.DataStreams {
+		patterns = append(patterns, c.spec.IndexPrefix+tableName)
+	}
+	slices.Sort(patterns)
+	return slices.Compact(patterns)
+}
+
+func indexNamePattern(tmpl, tableName string) string {
+	return strings.NewReplacer(
+		varTable, tableName,
+		varYear, "*",
+		varMonth, "*",
+		varDay, "*",
+	).Replace(tmpl)
 }
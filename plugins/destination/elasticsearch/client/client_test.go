@@ -5,8 +5,15 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	cqtypes "github.com/cloudquery/plugin-sdk/v4/types"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPlugin(t *testing.T) {
@@ -37,3 +44,76 @@ func TestPlugin(t *testing.T) {
 		},
 	)
 }
+
+func TestGetIndexName(t *testing.T) {
+	pkTable := &schema.Table{Name: "test_pk", Columns: schema.ColumnList{{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true}}}
+	appendTable := &schema.Table{Name: "test_append", Columns: schema.ColumnList{{Name: "id", Type: arrow.BinaryTypes.String}}}
+	ts := time.Date(2023, 7, 5, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		spec    Spec
+		table   *schema.Table
+		name    string
+		pattern string
+	}{
+		{spec: Spec{}, table: pkTable, name: "test_pk", pattern: "test_pk"},
+		{spec: Spec{}, table: appendTable, name: "test_append-2023-07-05", pattern: "test_append-*-*-*"},
+		{spec: Spec{IndexPrefix: "cq-"}, table: pkTable, name: "cq-test_pk", pattern: "cq-test_pk"},
+		{spec: Spec{IndexName: "{{TABLE}}-{{YEAR}}.{{MONTH}}"}, table: pkTable, name: "test_pk-2023.07", pattern: "test_pk-*.*"},
+		{spec: Spec{IndexPrefix: "cq-", DataStreams: true}, table: appendTable, name: "cq-test_append", pattern: "cq-test_append"},
+		{spec: Spec{DataStreams: true}, table: pkTable, name: "test_pk", pattern: "test_pk"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		c := &Client{spec: &tc.spec}
+		require.Equal(t, tc.name, c.getIndexName(tc.table, ts))
+		require.Equal(t, tc.pattern, c.getIndexNamePattern(tc.table))
+	}
+}
+
+func TestSpecValidateIndexName(t *testing.T) {
+	for name, wantErr := range map[string]bool{
+		"{{TABLE}}":                       false,
+		"cq-{{TABLE}}-{{YEAR}}-{{MONTH}}": false,
+		"{{YEAR}}.{{TABLE}}":              false,
+		"data":                            true, // no table
+		"{{TABLE}}_{{YEAR}}":              true, // pattern would match other tables
+		"{{TABLE}}-{{UUID}}":              true, // unsupported variable
+		"{{TABLE}}-Data":                  true, // uppercase
+	} {
+		err := (&Spec{IndexName: name}).Validate()
+		if wantErr {
+			require.Error(t, err, name)
+		} else {
+			require.NoError(t, err, name)
+		}
+	}
+	require.Error(t, (&Spec{IndexPrefix: "_cq"}).Validate())
+}
+
+func TestInetValues(t *testing.T) {
+	values := []string{"10.0.0.1/32", "192.0.2.0/24", "2001:db8::1/128"}
+	bldr := cqtypes.NewInetBuilder(array.NewExtensionBuilder(memory.DefaultAllocator, cqtypes.NewInetType()))
+	defer bldr.Release()
+	for _, v := range values {
+		require.NoError(t, bldr.AppendValueFromString(v))
+	}
+	arr := bldr.NewArray()
+	defer arr.Release()
+
+	c := &Client{}
+	written := make([]any, arr.Len())
+	for i := range written {
+		written[i] = c.getValueForElasticsearch(arr, i)
+	}
+	require.Equal(t, []any{"10.0.0.1", "192.0.2.0/24", "2001:db8::1"}, written)
+
+	for _, v := range written {
+		require.NoError(t, appendValue(bldr, v))
+	}
+	read := bldr.NewArray()
+	defer read.Release()
+	for i, v := range values {
+		require.Equal(t, v, read.ValueStr(i))
+	}
+}
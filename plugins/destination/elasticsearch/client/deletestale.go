@@ -58,7 +58,13 @@ func (c *Client) DeleteStale(ctx context.Context, msgs message.WriteDeleteStales
 			}
 			req := deletebyquery.NewRequest()
 			req.Query = &q
-			return c.deleteStaleIndex(gctx, msg.TableName, req)
+			// only the table name is known here, so delete from every index the table may have been written to
+			for _, index := range c.getIndexNamePatterns(msg.TableName) {
+				if err := c.deleteStaleIndex(gctx, index, req); err != nil {
+					return err
+				}
+			}
+			return nil
 		})
 	}
 	return g.Wait()
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
 
 	"github.com/apache/arrow/go/v13/arrow"
@@ -15,6 +16,18 @@ import (
 	"golang.org/x/exp/maps"
 )
 
+// indexTemplate is the body of a composable index template.
+type indexTemplate struct {
+	IndexPatterns []string              `json:"index_patterns"`
+	DataStream    *struct{}             `json:"data_stream,omitempty"`
+	Template      indexTemplateSettings `json:"template"`
+}
+
+type indexTemplateSettings struct {
+	Settings map[string]any     `json:"settings,omitempty"`
+	Mappings *types.TypeMapping `json:"mappings"`
+}
+
 // Migrate creates or updates index templates.
 func (c *Client) MigrateTables(ctx context.Context, msgs message.WriteMigrateTables) error {
 	for _, msg := range msgs {
@@ -25,41 +38,13 @@ func (c *Client) MigrateTables(ctx context.Context, msgs message.WriteMigrateTab
 		}
 
 		if msg.MigrateForce {
-			var indicesToDelete []string
-			pat := c.getIndexNamePattern(table)
-			if strings.HasSuffix(pat, "*") {
-				resp, err := c.client.Indices.Get([]string{pat},
-					c.client.Indices.Get.WithContext(ctx),
-					c.client.Indices.Get.WithIgnoreUnavailable(true),
-					c.client.Indices.Get.WithFeatures("aliases"),
-				)
-				if err != nil {
-					return fmt.Errorf("failed to get indices: %w", err)
-				}
-				if resp.IsError() {
-					return fmt.Errorf("failed to get indices: %s", resp.String())
-				}
-
-				var indices map[string]any
-				if err := json.NewDecoder(resp.Body).Decode(&indices); err != nil {
-					return fmt.Errorf("failed to decode response body: %w", err)
-				}
-				_ = resp.Body.Close()
-
-				indicesToDelete = maps.Keys(indices)
-			} else {
-				indicesToDelete = []string{table.Name}
-			}
-
-			if len(indicesToDelete) > 0 {
-				if err := c.deleteIndices(ctx, indicesToDelete); err != nil {
-					return fmt.Errorf("failed to delete indices: %w", err)
-				}
+			if err := c.deleteTableIndices(ctx, table); err != nil {
+				return err
 			}
 		}
 
 		resp, err := c.client.Indices.PutIndexTemplate(
-			table.Name,
+			c.getIndexTemplateName(table),
 			strings.NewReader(tmpl),
 			c.client.Indices.PutIndexTemplate.WithContext(ctx),
 			c.client.Indices.PutIndexTemplate.WithCreate(false),
@@ -77,24 +62,70 @@ func (c *Client) MigrateTables(ctx context.Context, msgs message.WriteMigrateTab
 	return nil
 }
 
+// deleteTableIndices deletes all indices or the data stream of the table, so they're recreated from the new index template.
+func (c *Client) deleteTableIndices(ctx context.Context, table *schema.Table) error {
+	pat := c.getIndexNamePattern(table)
+	if c.isDataStream(table) {
+		if err := c.deleteDataStream(ctx, pat); err != nil {
+			return fmt.Errorf("failed to delete data stream: %w", err)
+		}
+		return nil
+	}
+
+	var indicesToDelete []string
+	if strings.Contains(pat, "*") {
+		resp, err := c.client.Indices.Get([]string{pat},
+			c.client.Indices.Get.WithContext(ctx),
+			c.client.Indices.Get.WithIgnoreUnavailable(true),
+			c.client.Indices.Get.WithFeatures("aliases"),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to get indices: %w", err)
+		}
+		if resp.IsError() {
+			return fmt.Errorf("failed to get indices: %s", resp.String())
+		}
+
+		var indices map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&indices); err != nil {
+			return fmt.Errorf("failed to decode response body: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		indicesToDelete = maps.Keys(indices)
+	} else {
+		indicesToDelete = []string{pat}
+	}
+
+	if len(indicesToDelete) > 0 {
+		if err := c.deleteIndices(ctx, indicesToDelete); err != nil {
+			return fmt.Errorf("failed to delete indices: %w", err)
+		}
+	}
+	return nil
+}
+
 func (c *Client) getIndexTemplate(table *schema.Table) (string, error) {
 	properties := map[string]types.Property{}
 	for _, col := range table.Columns {
-		properties[col.Name] = arrowTypeToElasticsearchProperty(col.Type)
-	}
-	tmp := types.IndexTemplate{
-		AllowAutoCreate: nil,
-		ComposedOf:      []string{},
-		DataStream:      nil,
-		IndexPatterns:   []string{c.getIndexNamePattern(table)},
-		Meta_:           nil,
-		Priority:        nil,
-		Template: &types.IndexTemplateSummary{
+		properties[col.Name] = columnProperty(col)
+	}
+	tmp := indexTemplate{
+		IndexPatterns: []string{c.getIndexNamePattern(table)},
+		Template: indexTemplateSettings{
 			Mappings: &types.TypeMapping{
 				Properties: properties,
 			},
 		},
-		Version: nil,
+	}
+	if c.isDataStream(table) {
+		tmp.DataStream = &struct{}{}
+		properties[dataStreamTimestampField] = types.NewDateProperty()
+	}
+	if c.spec.ILMPolicy != "" {
+		tmp.Template.Settings = map[string]any{
+			"index.lifecycle.name": c.spec.ILMPolicy,
+		}
 	}
 	b, err := json.Marshal(tmp)
 	return string(b), err
@@ -119,6 +150,34 @@ func (c *Client) deleteIndices(ctx context.Context, names []string) error {
 	return nil
 }
 
+func (c *Client) deleteDataStream(ctx context.Context, name string) error {
+	c.logger.Debug().Str("data_stream", name).Msg("deleting data stream")
+	resp, err := c.client.Indices.DeleteDataStream([]string{name},
+		c.client.Indices.DeleteDataStream.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete data stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete data stream: %s", resp.String())
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// columnProperty returns the mapping of the column. Strings identifying resources, such as primary keys and the
+// source name, are mapped as keywords, so they can be used in exact matches and aggregations.
+func columnProperty(col schema.Column) types.Property {
+	if (col.PrimaryKey || col.Name == schema.CqSourceNameColumn.Name) &&
+		typeOneOf(col.Type, arrow.BinaryTypes.String, arrow.BinaryTypes.LargeString) {
+		return types.NewKeywordProperty()
+	}
+	return arrowTypeToElasticsearchProperty(col.Type)
+}
+
 func arrowTypeToElasticsearchProperty(dataType arrow.DataType) types.Property {
 	if dataType == nil {
 		return types.NewTextProperty()
@@ -127,14 +186,28 @@ func arrowTypeToElasticsearchProperty(dataType arrow.DataType) types.Property {
 	// handle known extensions
 	case typeOneOf(dataType,
 		cqtypes.ExtensionTypes.UUID,
-		cqtypes.ExtensionTypes.MAC,
+		cqtypes.ExtensionTypes.MAC):
+		return types.NewKeywordProperty()
+	case typeOneOf(dataType,
 		cqtypes.ExtensionTypes.Inet):
-		return types.NewTextProperty()
+		// host addresses are written without their prefix length, see getValueForElasticsearch. Other networks
+		// aren't accepted by the ip type, so they're kept in the source without being indexed.
+		p := types.NewIpProperty()
+		ignoreMalformed := true
+		p.IgnoreMalformed = &ignoreMalformed
+		return p
 	case typeOneOf(dataType,
 		cqtypes.ExtensionTypes.JSON):
 		return types.NewTextProperty()
 
 	// handle nested types
+	case isListOfStructs(dataType):
+		// nested keeps the fields of each struct together, so queries on several fields match the same element
+		p := types.NewNestedProperty()
+		for _, field := range dataType.(arrow.ListLikeType).Elem().(*arrow.StructType).Fields() {
+			p.Properties[field.Name] = arrowTypeToElasticsearchProperty(field.Type)
+		}
+		return p
 	case dataType.ID() == arrow.LIST:
 		return arrowTypeToElasticsearchProperty(dataType.(*arrow.ListType).Elem())
 	case dataType.ID() == arrow.LARGE_LIST:
@@ -186,7 +259,7 @@ func arrowTypeToElasticsearchProperty(dataType arrow.DataType) types.Property {
 	case typeOneOf(dataType,
 		arrow.BinaryTypes.String,
 		arrow.BinaryTypes.LargeString):
-		return types.NewTextProperty()
+		return textWithKeywordProperty()
 	case typeOneOf(dataType,
 		arrow.BinaryTypes.Binary,
 		arrow.BinaryTypes.LargeBinary):
@@ -237,6 +310,26 @@ func arrowTypeToElasticsearchProperty(dataType arrow.DataType) types.Property {
 	return types.NewTextProperty()
 }
 
+// textWithKeywordProperty returns a full-text property with a keyword sub-field,
+// the same mapping Elasticsearch dynamically creates for strings.
+func textWithKeywordProperty() types.Property {
+	ignoreAbove := 256
+	keyword := types.NewKeywordProperty()
+	keyword.IgnoreAbove = &ignoreAbove
+	p := types.NewTextProperty()
+	p.Fields["keyword"] = keyword
+	return p
+}
+
+func isListOfStructs(dataType arrow.DataType) bool {
+	l, ok := dataType.(arrow.ListLikeType)
+	if !ok || dataType.ID() == arrow.MAP {
+		return false
+	}
+	_, ok = l.Elem().(*arrow.StructType)
+	return ok
+}
+
 func typeOneOf(left arrow.DataType, dt ...arrow.DataType) bool {
 	for _, t := range dt {
 		if arrow.TypeEqual(left, t) {
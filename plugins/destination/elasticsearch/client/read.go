@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 
 	"github.com/goccy/go-json"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/apache/arrow/go/v13/arrow/array"
 	"github.com/apache/arrow/go/v13/arrow/memory"
 	"github.com/cloudquery/plugin-sdk/v4/schema"
+	cqtypes "github.com/cloudquery/plugin-sdk/v4/types"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
 )
@@ -110,6 +112,16 @@ func appendValue(builder array.Builder, value any) error {
 		return bldr.AppendValueFromString(fmt.Sprintf("%d", int64(value.(float64))))
 	case *array.Uint8Builder, *array.Uint16Builder, *array.Uint32Builder, *array.Uint64Builder:
 		return bldr.AppendValueFromString(fmt.Sprintf("%d", uint64(value.(float64))))
+	case *cqtypes.InetBuilder:
+		// host addresses are written without their prefix length, see getValueForElasticsearch
+		if ip := net.ParseIP(fmt.Sprintf("%v", value)); ip != nil {
+			bits := net.IPv6len * 8
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, net.IPv4len*8
+			}
+			bldr.Append(&net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			return nil
+		}
 	}
 	return builder.AppendValueFromString(fmt.Sprintf("%v", value))
 }
@@ -1,10 +1,30 @@
 package client
 
-import "runtime"
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+)
 
 const (
 	defaultBatchSize      = 1000
 	defaultBatchSizeBytes = 5 * 1024 * 1024
+
+	varTable = "{{TABLE}}"
+	varYear  = "{{YEAR}}"
+	varMonth = "{{MONTH}}"
+	varDay   = "{{DAY}}"
+
+	// tables with primary keys are upserted into a single index
+	defaultIndexName = varTable
+	// tables without primary keys are appended to daily indices
+	defaultAppendIndexName = varTable + "-" + varYear + "-" + varMonth + "-" + varDay
+)
+
+var (
+	reIndexPrefix   = regexp.MustCompile(`^[a-z0-9][a-z0-9_.+-]*$`)
+	reTableBoundary = regexp.MustCompile(`(^|[-.+]){{TABLE}}([-.+]|$)`)
 )
 
 type Spec struct {
@@ -24,6 +44,13 @@ type Spec struct {
 	Concurrency    int `json:"concurrency"`      // Number of concurrent worker goroutines to use for indexing. (Default: number of CPUs)
 	BatchSize      int `json:"batch_size"`       // Number of documents to batch together per request. (Default: 1000)
 	BatchSizeBytes int `json:"batch_size_bytes"` // Number of bytes to batch together per request. (Default: 1000000)
+
+	IndexPrefix string `json:"index_prefix"` // Prefix for all index, data stream and index template names.
+	// Template for index names, supporting the {{TABLE}}, {{YEAR}}, {{MONTH}} and {{DAY}} variables.
+	// (Default: "{{TABLE}}" for tables with primary keys, "{{TABLE}}-{{YEAR}}-{{MONTH}}-{{DAY}}" for tables without)
+	IndexName   string `json:"index_name"`
+	DataStreams bool   `json:"data_streams"` // Write tables without primary keys to data streams instead of indices.
+	ILMPolicy   string `json:"ilm_policy"`   // Name of an existing ILM policy to set in the generated index templates.
 }
 
 func (s *Spec) SetDefaults() {
@@ -41,6 +68,30 @@ func (s *Spec) SetDefaults() {
 	}
 }
 
-func (*Spec) Validate() error {
+func (s *Spec) Validate() error {
+	if s.IndexPrefix != "" && !reIndexPrefix.MatchString(s.IndexPrefix) {
+		return fmt.Errorf("`index_prefix` %q must be lowercase and may only contain letters, digits, '_', '.', '+' and '-'", s.IndexPrefix)
+	}
+	if s.IndexName == "" {
+		return nil
+	}
+	if !strings.Contains(s.IndexName, varTable) {
+		return fmt.Errorf("`index_name` must contain %s", varTable)
+	}
+	rest := strings.NewReplacer(varTable, "", varYear, "", varMonth, "", varDay, "").Replace(s.IndexName)
+	if strings.Contains(rest, "{{") {
+		return fmt.Errorf("`index_name` only supports the %s, %s, %s and %s variables", varTable, varYear, varMonth, varDay)
+	}
+	if rest != strings.ToLower(rest) || strings.ContainsAny(rest, `\/*?"<>| ,#:`) {
+		return fmt.Errorf("`index_name` must be lowercase and may not contain spaces or any of the characters \\/*?\"<>|,#:")
+	}
+	if hasDateVariables(s.IndexName) && !reTableBoundary.MatchString(s.IndexName) {
+		// otherwise the index pattern of one table could match the indices of another, e.g. "aws_ec2_*" and "aws_ec2_instances_2023"
+		return fmt.Errorf("%s must be separated from the rest of `index_name` by '-', '.' or '+' when it contains date variables", varTable)
+	}
 	return nil
 }
+
+func hasDateVariables(name string) bool {
+	return strings.Contains(name, varYear) || strings.Contains(name, varMonth) || strings.Contains(name, varDay)
+}
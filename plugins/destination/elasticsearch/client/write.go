@@ -17,6 +17,8 @@ import (
 	"github.com/segmentio/fasthash/fnv1a"
 )
 
+const dataStreamTimestampField = "@timestamp"
+
 type bulkResponse struct {
 	Took   int64 `json:"took"`
 	Errors bool  `json:"errors"`
@@ -50,11 +52,19 @@ func (c *Client) writeRecord(ctx context.Context, table *schema.Table, record ar
 	// get the sync time from the first resource in the batch (here we assume that all resources in the batch
 	// have the same sync time. At the moment this assumption holds.)
 	syncTime := time.Now()
+	dataStream := c.isDataStream(table)
 	for r := 0; r < int(record.NumRows()); r++ {
 		doc := map[string]any{}
 		for i, col := range record.Columns() {
 			doc[table.Columns[i].Name] = c.getValueForElasticsearch(col, r)
 		}
+		if dataStream {
+			// data streams require a timestamp
+			doc[dataStreamTimestampField] = doc[schema.CqSyncTimeColumn.Name]
+			if doc[dataStreamTimestampField] == nil {
+				doc[dataStreamTimestampField] = syncTime.UTC().Format(time.RFC3339Nano)
+			}
+		}
 		data, err := json.Marshal(doc)
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %w", err)
@@ -63,10 +73,14 @@ func (c *Client) writeRecord(ctx context.Context, table *schema.Table, record ar
 		var meta []byte
 		hasPrimaryKeys := len(table.PrimaryKeys()) > 0
 
-		if hasPrimaryKeys {
+		switch {
+		case dataStream:
+			// data streams are append-only, and only accept the create operation
+			meta = []byte(`{"create":{}}` + "\n")
+		case hasPrimaryKeys:
 			docID := fmt.Sprint(resourceID(record, r, pks))
 			meta = []byte(fmt.Sprintf(`{"index":{"_id":"%s"}}%s`, docID, "\n"))
-		} else {
+		default:
 			meta = []byte(`{"index":{}}` + "\n")
 		}
 		data = append(data, "\n"...)
@@ -114,6 +128,13 @@ func (c *Client) getValueForElasticsearch(col arrow.Array, i int) any {
 		return m
 	case *cqtypes.JSONArray:
 		return col.ValueStr(i)
+	case *cqtypes.InetArray:
+		// the ip type only accepts addresses, so hosts are written without their prefix length
+		v := col.Value(i)
+		if ones, bits := v.Mask.Size(); ones == bits {
+			return v.IP.String()
+		}
+		return col.ValueStr(i)
 	case array.ListLike:
 		from, to := col.ValueOffsets(i)
 		slc := array.NewSlice(col.ListValues(), from, to)
@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"time"
 
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/delta"
 	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/message"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
 	"github.com/cloudquery/plugin-sdk/v4/writers/streamingbatchwriter"
 	"github.com/rs/zerolog"
@@ -13,14 +17,14 @@ import (
 
 type Client struct {
 	plugin.UnimplementedSource
-	streamingbatchwriter.IgnoreMigrateTable
-	streamingbatchwriter.UnimplementedDeleteStale
 
 	logger zerolog.Logger
 	spec   *Spec
 
 	*filetypes.Client
 	writer *streamingbatchwriter.StreamingBatchWriter
+
+	delta *delta.Tables
 }
 
 func New(_ context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewClientOptions) (plugin.Client, error) {
@@ -46,7 +50,13 @@ func New(_ context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewC
 	if err != nil {
 		return nil, fmt.Errorf("failed to create filetypes client: %w", err)
 	}
+	if c.spec.Format == filetypes.FormatTypeParquet {
+		filetypesClient.FileType = newParquetFile(filetypesClient.FileType, c.spec.Compression)
+	}
 	c.Client = filetypesClient
+	if c.spec.TableFormat == TableFormatDelta {
+		c.delta = delta.NewTables(localDeltaStore{}, c.Client, c.deltaTableRoot, "cloudquery-file-destination")
+	}
 
 	c.writer, err = streamingbatchwriter.New(c,
 		streamingbatchwriter.WithBatchSizeRows(*c.spec.BatchSize),
@@ -60,6 +70,24 @@ func New(_ context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewC
 	return c, nil
 }
 
+func (c *Client) MigrateTable(ctx context.Context, msgs <-chan *message.WriteMigrateTable) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Migrate(ctx, msgs)
+	}
+	return streamingbatchwriter.IgnoreMigrateTable{}.MigrateTable(ctx, msgs)
+}
+
+func (c *Client) DeleteStale(ctx context.Context, msgs <-chan *message.WriteDeleteStale) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.DeleteStale(ctx, msgs)
+	}
+	return streamingbatchwriter.UnimplementedDeleteStale{}.DeleteStale(ctx, msgs)
+}
+
 func (c *Client) Close(ctx context.Context) error {
 	return c.writer.Close(ctx)
 }
+
+func (c *Client) deltaTableRoot(table string) string {
+	return filepath.ToSlash(replacePathVariables(c.spec.Path, table, c.spec.extension(), "", time.Time{}))
+}
@@ -113,6 +113,22 @@ func testSpecs(t *testing.T) []testSpec {
 		}
 	}
 
+	bd := t.TempDir()
+	for _, compression := range []CompressionType{CompressionTypeGZip, CompressionTypeZSTD, CompressionTypeSnappy} {
+		for i := range formats {
+			ret = append(ret, testSpec{
+				testName: "Compression:" + string(compression) + ":" + string(formats[i].Format),
+				baseDir:  bd,
+				Spec: Spec{
+					Path:        filepath.Join(bd, string(compression), "{{TABLE}}.{{FORMAT}}"),
+					NoRotate:    true,
+					Compression: compression,
+					FileSpec:    &formats[i],
+				},
+			})
+		}
+	}
+
 	return ret
 }
 
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+type CompressionType string
+
+const (
+	CompressionTypeNone   CompressionType = ""
+	CompressionTypeGZip   CompressionType = "gzip"
+	CompressionTypeZSTD   CompressionType = "zstd"
+	CompressionTypeSnappy CompressionType = "snappy"
+)
+
+func (c CompressionType) Validate() error {
+	switch c {
+	case CompressionTypeNone, CompressionTypeGZip, CompressionTypeZSTD, CompressionTypeSnappy:
+		return nil
+	default:
+		return fmt.Errorf("unsupported `compression` %q, supported values are %q, %q and %q", c, CompressionTypeGZip, CompressionTypeZSTD, CompressionTypeSnappy)
+	}
+}
+
+// Extension returns the file extension suffix (including the leading dot) for the compression type.
+func (c CompressionType) Extension() string {
+	switch c {
+	case CompressionTypeGZip:
+		return ".gz"
+	case CompressionTypeZSTD:
+		return ".zst"
+	case CompressionTypeSnappy:
+		return ".snappy"
+	default:
+		return ""
+	}
+}
+
+// NewWriter wraps w with a compressing writer. Closing the returned writer flushes it, but doesn't close w.
+func (c CompressionType) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case CompressionTypeGZip:
+		return gzip.NewWriter(w), nil
+	case CompressionTypeZSTD:
+		return zstd.NewWriter(w)
+	case CompressionTypeSnappy:
+		return snappy.NewBufferedWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", c)
+	}
+}
+
+// NewReader wraps r with a decompressing reader.
+func (c CompressionType) NewReader(r io.Reader) (io.ReadCloser, error) {
+	switch c {
+	case CompressionTypeGZip:
+		return gzip.NewReader(r)
+	case CompressionTypeZSTD:
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case CompressionTypeSnappy:
+		return io.NopCloser(snappy.NewReader(r)), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", c)
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/delta"
+	"github.com/google/uuid"
+)
+
+// localDeltaStore stores the Delta Lake tables in the local file system.
+type localDeltaStore struct{}
+
+func (localDeltaStore) Get(_ context.Context, key string) ([]byte, error) {
+	b, err := os.ReadFile(filepath.FromSlash(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, delta.ErrObjectNotFound
+	}
+	return b, err
+}
+
+func (localDeltaStore) Put(_ context.Context, key string, r io.Reader) error {
+	p := filepath.FromSlash(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		_ = os.Remove(p)
+		return err
+	}
+	return f.Close()
+}
+
+func (localDeltaStore) PutIfAbsent(_ context.Context, key string, data []byte) error {
+	p := filepath.FromSlash(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	// linking fails if the file already exists, which gives us the atomic put-if-absent required by the protocol
+	tmp := filepath.Join(filepath.Dir(p), "."+uuid.NewString()+".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	err := os.Link(tmp, p)
+	_ = os.Remove(tmp)
+	if errors.Is(err, fs.ErrExist) {
+		return delta.ErrObjectExists
+	}
+	return err
+}
+
+func (localDeltaStore) Delete(_ context.Context, key string) error {
+	return os.Remove(filepath.FromSlash(key))
+}
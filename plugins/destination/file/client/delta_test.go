@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginDelta(t *testing.T) {
+	ctx := context.Background()
+	p := plugin.NewPlugin("file", "development", New)
+	b, err := json.Marshal(&Spec{
+		Path:        t.TempDir(),
+		FileSpec:    &filetypes.FileSpec{Format: filetypes.FormatTypeParquet},
+		TableFormat: TableFormatDelta,
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.Init(ctx, b, plugin.NewClientOptions{}))
+
+	plugin.TestWriterSuiteRunner(t,
+		p,
+		plugin.WriterTestSuiteTests{
+			SkipUpsert: true,
+			SafeMigrations: plugin.SafeMigrations{
+				AddColumn: true,
+			},
+		},
+	)
+}
+
+func TestDeltaTransactionLog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	b, err := json.Marshal(&Spec{
+		Path:        dir,
+		FileSpec:    &filetypes.FileSpec{Format: filetypes.FormatTypeParquet},
+		TableFormat: TableFormatDelta,
+	})
+	require.NoError(t, err)
+	var client plugin.Client
+	p := plugin.NewPlugin("file", "development", func(ctx context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewClientOptions) (plugin.Client, error) {
+		var err error
+		client, err = New(ctx, logger, spec, opts)
+		return client, err
+	})
+	require.NoError(t, p.Init(ctx, b, plugin.NewClientOptions{}))
+
+	table := &schema.Table{
+		Name: "test_delta",
+		Columns: []schema.Column{
+			{Name: "id", Type: arrow.PrimitiveTypes.Int64, NotNull: true, Description: "The ID"},
+			{Name: "name", Type: arrow.BinaryTypes.String},
+		},
+	}
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+	bldr.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	bldr.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	record := bldr.NewRecord()
+
+	require.NoError(t, p.WriteAll(ctx, []message.WriteMessage{
+		&message.WriteMigrateTable{Table: table},
+		&message.WriteInsert{Record: record},
+	}))
+	// adding a nullable column is a metadata-only change
+	table.Columns = append(table.Columns, schema.Column{Name: "extra", Type: arrow.FixedWidthTypes.Boolean})
+	require.NoError(t, p.WriteAll(ctx, []message.WriteMessage{
+		&message.WriteMigrateTable{Table: table},
+	}))
+	// changing a column type isn't, unless forced
+	changed := table.Copy(nil)
+	changed.Columns[1].Type = arrow.PrimitiveTypes.Int64
+	require.Error(t, p.WriteAll(ctx, []message.WriteMessage{
+		&message.WriteMigrateTable{Table: changed},
+	}))
+
+	logDir := filepath.Join(dir, table.Name, "_delta_log")
+	entries, err := os.ReadDir(logDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"00000000000000000000.json", "00000000000000000001.json"}, dirNames(entries))
+
+	first, err := os.ReadFile(filepath.Join(logDir, entries[0].Name()))
+	require.NoError(t, err)
+	require.Contains(t, string(first), `"protocol":{"minReaderVersion":1,"minWriterVersion":2}`)
+	require.Contains(t, string(first), `{\"numRecords\":2}`)
+	require.Contains(t, string(first), `{\"name\":\"id\",\"type\":\"long\",\"nullable\":false,\"metadata\":{\"comment\":\"The ID\"}}`)
+
+	records, err := readAll(ctx, client, table)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), plugin.TotalRows(records))
+	for _, rec := range records {
+		// files written before the column was added are read with nulls
+		require.Equal(t, int64(3), rec.NumCols())
+		require.Equal(t, int(rec.NumRows()), rec.Column(2).NullN())
+	}
+}
+
+func dirNames(entries []os.DirEntry) []string {
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
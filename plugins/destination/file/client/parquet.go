@@ -0,0 +1,158 @@
+package client
+
+import (
+	"io"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet"
+	"github.com/apache/arrow/go/v13/parquet/compress"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+	ftypes "github.com/cloudquery/filetypes/v4/types"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/types"
+)
+
+// parquetFile writes parquet files compressed with the configured codec.
+// The filetypes parquet writer always uses snappy, so only writing is overridden here:
+// reading is left to filetypes, as the codec is recorded in the file itself.
+// The schema and record conversions match the ones done by filetypes, so the written files read back the same way.
+type parquetFile struct {
+	ftypes.FileType
+	codec compress.Compression
+}
+
+type parquetHandle struct {
+	w *pqarrow.FileWriter
+	s *arrow.Schema
+}
+
+var _ ftypes.Handle = (*parquetHandle)(nil)
+
+func newParquetFile(fileType ftypes.FileType, compression CompressionType) *parquetFile {
+	codec := compress.Codecs.Snappy
+	switch compression {
+	case CompressionTypeGZip:
+		codec = compress.Codecs.Gzip
+	case CompressionTypeZSTD:
+		codec = compress.Codecs.Zstd
+	}
+	return &parquetFile{FileType: fileType, codec: codec}
+}
+
+func (p *parquetFile) WriteHeader(w io.Writer, t *schema.Table) (ftypes.Handle, error) {
+	props := parquet.NewWriterProperties(
+		parquet.WithMaxRowGroupLength(128*1024*1024), // 128M
+		parquet.WithCompression(p.codec),
+	)
+	sc := convertParquetSchema(t.ToArrowSchema())
+	fw, err := pqarrow.NewFileWriter(sc, w, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, err
+	}
+	return &parquetHandle{w: fw, s: sc}, nil
+}
+
+func (h *parquetHandle) WriteContent(records []arrow.Record) error {
+	for _, rec := range records {
+		if err := h.w.WriteBuffered(transformParquetRecord(h.s, rec)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *parquetHandle) WriteFooter() error {
+	err := h.w.Close()
+	h.w = nil
+	return err
+}
+
+func convertParquetSchema(sc *arrow.Schema) *arrow.Schema {
+	md := arrow.MetadataFrom(sc.Metadata().ToMap())
+	return arrow.NewSchema(convertParquetFields(sc.Fields()), &md)
+}
+
+func convertParquetFields(fields []arrow.Field) []arrow.Field {
+	for i := range fields {
+		fields[i].Type = parquetDataType(fields[i].Type)
+	}
+	return fields
+}
+
+// parquetDataType returns the type the Arrow type is written as, with the types unsupported by pqarrow stored as strings.
+func parquetDataType(t arrow.DataType) arrow.DataType {
+	switch dt := t.(type) {
+	case *arrow.DurationType,
+		*arrow.DayTimeIntervalType,
+		*arrow.MonthDayNanoIntervalType,
+		*arrow.MonthIntervalType,
+		*arrow.LargeBinaryType,
+		*arrow.LargeStringType,
+		*types.JSONType,
+		*types.MACType,
+		*types.InetType,
+		*types.UUIDType:
+		return arrow.BinaryTypes.String
+	case *arrow.StructType:
+		return arrow.StructOf(convertParquetFields(dt.Fields())...)
+	case *arrow.MapType:
+		return arrow.MapOf(parquetDataType(dt.KeyType()), parquetDataType(dt.ItemType()))
+	case arrow.ListLikeType:
+		return arrow.ListOf(parquetDataType(dt.Elem()))
+	default:
+		return t
+	}
+}
+
+// transformParquetRecord casts the columns to the types returned by parquetDataType. It does not release the original record.
+func transformParquetRecord(sc *arrow.Schema, rec arrow.Record) arrow.Record {
+	cols := make([]arrow.Array, rec.NumCols())
+	for i := range cols {
+		cols[i] = transformParquetArray(rec.Column(i))
+	}
+	return array.NewRecord(sc, cols, rec.NumRows())
+}
+
+func transformParquetArray(arr arrow.Array) arrow.Array {
+	if arrow.TypeEqual(arrow.BinaryTypes.String, parquetDataType(arr.DataType())) {
+		return parquetStringArray(arr)
+	}
+
+	switch arr := arr.(type) {
+	case *array.Struct:
+		children := make([]arrow.ArrayData, arr.NumField())
+		for i := range children {
+			children[i] = transformParquetArray(arr.Field(i)).Data()
+		}
+		return array.NewStructData(array.NewData(
+			parquetDataType(arr.DataType()), arr.Len(),
+			arr.Data().Buffers(),
+			children,
+			arr.NullN(), arr.Data().Offset(),
+		))
+	case array.ListLike: // this also handles maps
+		return array.MakeFromData(array.NewData(
+			parquetDataType(arr.DataType()), arr.Len(),
+			arr.Data().Buffers(),
+			[]arrow.ArrayData{transformParquetArray(arr.ListValues()).Data()},
+			arr.NullN(), arr.Data().Offset(),
+		))
+	default:
+		return arr
+	}
+}
+
+func parquetStringArray(arr arrow.Array) arrow.Array {
+	builder := array.NewStringBuilder(memory.DefaultAllocator)
+	defer builder.Release()
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			builder.AppendNull()
+			continue
+		}
+		builder.Append(arr.ValueStr(i))
+	}
+	return builder.NewArray()
+}
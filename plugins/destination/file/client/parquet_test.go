@@ -0,0 +1,68 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet/compress"
+	"github.com/apache/arrow/go/v13/parquet/file"
+	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParquetCompression(t *testing.T) {
+	table := &schema.Table{
+		Name: "test_parquet_compression",
+		Columns: schema.ColumnList{
+			{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "uuid", Type: types.ExtensionTypes.UUID},
+		},
+	}
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+	defer bldr.Release()
+	bldr.Field(0).(*array.Int64Builder).Append(1)
+	bldr.Field(1).AppendNull()
+	record := bldr.NewRecord()
+	defer record.Release()
+
+	for compression, want := range map[CompressionType]compress.Compression{
+		CompressionTypeNone:   compress.Codecs.Snappy,
+		CompressionTypeSnappy: compress.Codecs.Snappy,
+		CompressionTypeGZip:   compress.Codecs.Gzip,
+		CompressionTypeZSTD:   compress.Codecs.Zstd,
+	} {
+		t.Run(string(compression), func(t *testing.T) {
+			files, err := filetypes.NewClient(&filetypes.FileSpec{Format: filetypes.FormatTypeParquet})
+			require.NoError(t, err)
+			files.FileType = newParquetFile(files.FileType, compression)
+
+			var buf bytes.Buffer
+			h, err := files.WriteHeader(&buf, table)
+			require.NoError(t, err)
+			require.NoError(t, h.WriteContent([]arrow.Record{record}))
+			require.NoError(t, h.WriteFooter())
+
+			rdr, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			defer rdr.Close()
+			require.EqualValues(t, 1, rdr.NumRows())
+			for i := 0; i < rdr.MetaData().Schema.NumColumns(); i++ {
+				col, err := rdr.MetaData().RowGroup(0).ColumnChunk(i)
+				require.NoError(t, err)
+				require.Equal(t, want, col.Compression())
+			}
+
+			ch := make(chan arrow.Record, 1)
+			require.NoError(t, files.Read(bytes.NewReader(buf.Bytes()), table, ch))
+			close(ch)
+			got := <-ch
+			require.NotNil(t, got)
+			require.EqualValues(t, 1, got.NumRows())
+		})
+	}
+}
@@ -1,30 +1,53 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/partition"
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/google/uuid"
 )
 
-func (c *Client) Read(_ context.Context, table *schema.Table, res chan<- arrow.Record) error {
+func (c *Client) Read(ctx context.Context, table *schema.Table, res chan<- arrow.Record) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Read(ctx, table, res)
+	}
 	if !c.spec.NoRotate {
 		return fmt.Errorf("reading is not supported when `no_rotate` is false. Table: %q", table.Name)
 	}
 	if strings.Contains(c.spec.Path, PathVarUUID) {
 		return fmt.Errorf("reading is not supported when `path` contains UUID variable. Table: %q", table.Name)
 	}
-	name := replacePathVariables(c.spec.Path, table.Name, c.spec.Format, uuid.NewString(), time.Time{})
+	if partition.HasColumnVariables(c.spec.Path) {
+		return fmt.Errorf("reading is not supported when `path` contains {{COLUMN:...}} variables. Table: %q", table.Name)
+	}
+	name := replacePathVariables(c.spec.Path, table.Name, c.spec.extension(), uuid.NewString(), time.Time{})
 	f, err := os.Open(name)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
-	return c.Client.Read(f, table, res)
+	compression := c.spec.streamCompression()
+	if compression == CompressionTypeNone {
+		return c.Client.Read(f, table, res)
+	}
+
+	r, err := compression.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return c.Client.Read(bytes.NewReader(b), table, res)
 }
@@ -21,26 +21,39 @@ const (
 	MinuteVar     = "{{MINUTE}}"
 )
 
+const TableFormatDelta = "delta"
+
 type Spec struct {
 	*filetypes.FileSpec
 	Directory string `json:"directory,omitempty"`
 	NoRotate  bool   `json:"no_rotate,omitempty"`
 	Path      string `json:"path,omitempty"`
 
+	Compression CompressionType `json:"compression,omitempty"`
+	TableFormat string          `json:"table_format,omitempty"`
+
 	BatchSize      *int64               `json:"batch_size"`
 	BatchSizeBytes *int64               `json:"batch_size_bytes"`
 	BatchTimeout   *configtype.Duration `json:"batch_timeout"`
 }
 
 func (s *Spec) SetDefaults() {
-	if s.Directory != "" {
-		s.Path = path.Join(s.Directory, fmt.Sprintf("%s.%s", PathVarTable, s.Format))
-		if !s.NoRotate {
-			s.Path += "." + PathVarUUID
+	switch {
+	case s.TableFormat == TableFormatDelta:
+		// path is the table directory, file names are generated
+		if !strings.Contains(s.Path, PathVarTable) {
+			s.Path = path.Join(s.Path, PathVarTable)
+		}
+	default:
+		if s.Directory != "" {
+			s.Path = path.Join(s.Directory, fmt.Sprintf("%s.%s", PathVarTable, s.extension()))
+			if !s.NoRotate {
+				s.Path += "." + PathVarUUID
+			}
+		}
+		if !strings.Contains(s.Path, PathVarTable) {
+			s.Path = path.Join(s.Path, fmt.Sprintf("%s.%s", PathVarTable, s.extension()))
 		}
-	}
-	if !strings.Contains(s.Path, PathVarTable) {
-		s.Path = path.Join(s.Path, fmt.Sprintf("%s.%s", PathVarTable, s.Format))
 	}
 	if s.BatchSize == nil {
 		if s.NoRotate {
@@ -74,6 +87,13 @@ func (s *Spec) Validate() error {
 	if s.Directory != "" && s.Path != "" {
 		return fmt.Errorf("only one of `directory` or `path` is allowed")
 	}
+	switch s.TableFormat {
+	case "":
+	case TableFormatDelta:
+		return s.validateDelta()
+	default:
+		return fmt.Errorf("unsupported `table_format` %q, only %q is supported", s.TableFormat, TableFormatDelta)
+	}
 	if s.NoRotate && strings.Contains(s.Path, PathVarUUID) {
 		return fmt.Errorf("`path` should not contain %s when `no_rotate` = true", PathVarUUID)
 	}
@@ -83,6 +103,9 @@ func (s *Spec) Validate() error {
 	if s.Format == "" {
 		return fmt.Errorf("`format` is required")
 	}
+	if err := s.Compression.Validate(); err != nil {
+		return err
+	}
 	if s.NoRotate && ((s.BatchSize != nil && *s.BatchSize > 0) || (s.BatchSizeBytes != nil && *s.BatchSizeBytes > 0) || (s.BatchTimeout != nil && s.BatchTimeout.Duration() > 0)) {
 		return fmt.Errorf("`no_rotate` cannot be used with non-zero `batch_size`, `batch_size_bytes` or `batch_timeout_ms`")
 	}
@@ -90,6 +113,33 @@ func (s *Spec) Validate() error {
 	return nil
 }
 
+func (s *Spec) validateDelta() error {
+	if s.Directory != "" {
+		return fmt.Errorf("`directory` is not supported with `table_format` %q, use `path` instead", TableFormatDelta)
+	}
+	if s.Format != filetypes.FormatTypeParquet {
+		return fmt.Errorf("`table_format` %q requires the %q `format`", TableFormatDelta, filetypes.FormatTypeParquet)
+	}
+	if strings.Contains(strings.ReplaceAll(s.Path, PathVarTable, ""), "{{") {
+		return fmt.Errorf("`path` should not contain variables other than %s when `table_format` is %q", PathVarTable, TableFormatDelta)
+	}
+	return nil
+}
+
+// streamCompression returns the compression applied to the whole file.
+// Parquet files are compressed internally, so they are never compressed as a whole.
+func (s *Spec) streamCompression() CompressionType {
+	if s.Format == filetypes.FormatTypeParquet {
+		return CompressionTypeNone
+	}
+	return s.Compression
+}
+
+// extension returns the file extension for the format and compression, e.g. "json.gz".
+func (s *Spec) extension() string {
+	return string(s.Format) + s.streamCompression().Extension()
+}
+
 func (s *Spec) batchingEnabled() bool {
 	switch {
 	case (s.BatchSize != nil && *s.BatchSize > 0) ||
@@ -24,6 +24,10 @@ func TestSpec_SetDefaults(t *testing.T) {
 			Give: Spec{Path: "test/path/{{TABLE}}.json", FileSpec: &filetypes.FileSpec{Format: "json", FormatSpec: map[string]any{"delimiter": ","}}},
 			Want: Spec{Path: "test/path/{{TABLE}}.json", FileSpec: &filetypes.FileSpec{Format: "json", FormatSpec: map[string]any{"delimiter": ","}}, BatchSize: int64Ptr(10000), BatchSizeBytes: int64Ptr(50 * 1024 * 1024), BatchTimeout: &dur30},
 		},
+		{
+			Give: Spec{Path: "test/path", Compression: CompressionTypeGZip, FileSpec: &filetypes.FileSpec{Format: "json"}},
+			Want: Spec{Path: "test/path/{{TABLE}}.json.gz", Compression: CompressionTypeGZip, FileSpec: &filetypes.FileSpec{Format: "json"}, BatchSize: int64Ptr(10000), BatchSizeBytes: int64Ptr(50 * 1024 * 1024), BatchTimeout: &dur30},
+		},
 	}
 	for _, tc := range cases {
 		got := tc.Give
@@ -48,6 +52,10 @@ func TestSpec_Validate(t *testing.T) {
 		{Give: Spec{Path: "test/path/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: true}, WantErr: false},                                                                       // norotate with default batchsize
 		{Give: Spec{Path: "test/path/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: true, BatchSize: &one}, WantErr: true},                                                       // norotate with non zero batchsize
 		{Give: Spec{Path: "test/path/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: false, BatchSize: &one, BatchSizeBytes: &zero, BatchTimeout: &dur0}, WantErr: true},          // can't have nonzero batch size and no {{UUID}}
+		{Give: Spec{Path: "test/path/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: true, Compression: CompressionTypeGZip}, WantErr: false},
+		{Give: Spec{Path: "test/path/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: true, Compression: "lz4"}, WantErr: true}, // unsupported compression
+		{Give: Spec{Path: "test/path/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "parquet"}, NoRotate: true, Compression: CompressionTypeSnappy}, WantErr: false},
+		{Give: Spec{Path: "test/path/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "parquet"}, NoRotate: true, Compression: CompressionTypeZSTD}, WantErr: false},
 	}
 	for i, tc := range cases {
 		tc := tc
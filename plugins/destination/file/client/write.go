@@ -3,63 +3,149 @@ package client
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/apache/arrow/go/v13/arrow"
-	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/partition"
 	"github.com/cloudquery/filetypes/v4/types"
 	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/google/uuid"
 )
 
-func (c *Client) WriteTable(_ context.Context, msgs <-chan *message.WriteInsert) error {
+type fileHandle struct {
+	f *os.File
+	// cw is the compressing writer wrapping f, if compression is enabled
+	cw io.WriteCloser
+	h  types.Handle
+}
+
+func (c *Client) WriteTable(ctx context.Context, msgs <-chan *message.WriteInsert) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Write(ctx, msgs)
+	}
+
 	var (
-		f *os.File
-		h types.Handle
+		handles []*fileHandle
+		byPath  = make(map[string]*fileHandle)
+		now     = time.Now().UTC()
 	)
 	for msg := range msgs {
-		if f == nil {
-			table := msg.GetTable()
-
-			p := replacePathVariables(c.spec.Path, table.Name, c.spec.Format, uuid.NewString(), time.Now().UTC())
+		parts := partition.Split(c.spec.Path, msg.Record)
+		err := c.writePartitions(msg.GetTable(), parts, byPath, &handles, now)
+		partition.Release(parts)
+		if err != nil {
+			closeFileHandles(handles)
+			return err
+		}
+	}
 
-			if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
-				return fmt.Errorf("failed to create directory: %w", err)
-			}
+	for i, fh := range handles {
+		if err := fh.finish(); err != nil {
+			closeFileHandles(handles[i+1:])
+			return err
+		}
+	}
+	return nil
+}
 
+// writePartitions writes the partitions to the files of their paths, opening the files of new paths.
+func (c *Client) writePartitions(table *schema.Table, parts []partition.Partition, byPath map[string]*fileHandle, handles *[]*fileHandle, now time.Time) error {
+	for _, part := range parts {
+		fh := byPath[part.Path]
+		if fh == nil {
 			var err error
-			f, err = os.OpenFile(p, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-			if err != nil {
-				return err
-			}
-
-			h, err = c.Client.WriteHeader(f, table)
+			fh, err = c.openFile(table, part.Path, now)
 			if err != nil {
 				return err
 			}
+			byPath[part.Path] = fh
+			*handles = append(*handles, fh)
 		}
 
-		if err := h.WriteContent([]arrow.Record{msg.Record}); err != nil {
+		if err := fh.h.WriteContent(part.Records); err != nil {
 			return err
 		}
 	}
+	return nil
+}
+
+func (c *Client) openFile(table *schema.Table, partPath string, now time.Time) (*fileHandle, error) {
+	p := replacePathVariables(partPath, table.Name, c.spec.extension(), uuid.NewString(), now)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	f, err := os.OpenFile(p, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	fh := &fileHandle{f: f}
+	var w io.Writer = f
+	if compression := c.spec.streamCompression(); compression != CompressionTypeNone {
+		fh.cw, err = compression.NewWriter(f)
+		if err != nil {
+			fh.close()
+			return nil, err
+		}
+		w = fh.cw
+	}
 
-	if err := h.WriteFooter(); err != nil {
+	fh.h, err = c.Client.WriteHeader(w, table)
+	if err != nil {
+		fh.close()
+		return nil, err
+	}
+	return fh, nil
+}
+
+// finish writes the footer and closes the file.
+func (fh *fileHandle) finish() error {
+	if err := fh.h.WriteFooter(); err != nil {
+		fh.close()
 		return err
 	}
-	return f.Close()
+	if fh.cw != nil {
+		if err := fh.cw.Close(); err != nil {
+			_ = fh.f.Close()
+			return err
+		}
+	}
+	return fh.f.Close()
+}
+
+// close closes the file without writing the footer, when writing to it failed.
+func (fh *fileHandle) close() {
+	if fh.cw != nil {
+		_ = fh.cw.Close()
+	}
+	_ = fh.f.Close()
+}
+
+func closeFileHandles(handles []*fileHandle) {
+	for _, fh := range handles {
+		fh.close()
+	}
 }
 
 func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) error {
-	return c.writer.Write(ctx, msgs)
+	if err := c.writer.Write(ctx, msgs); err != nil {
+		return err
+	}
+	if c.spec.TableFormat == TableFormatDelta {
+		// all batches are flushed by now, so the whole sync is committed at once
+		return c.delta.Commit(ctx)
+	}
+	return nil
 }
 
-func replacePathVariables(specPath, table string, format filetypes.FormatType, fileIdentifier string, t time.Time) string {
+func replacePathVariables(specPath, table, extension, fileIdentifier string, t time.Time) string {
 	name := strings.ReplaceAll(specPath, PathVarTable, table)
-	name = strings.ReplaceAll(name, PathVarFormat, string(format))
+	name = strings.ReplaceAll(name, PathVarFormat, extension)
 	name = strings.ReplaceAll(name, PathVarUUID, fileIdentifier)
 	name = strings.ReplaceAll(name, YearVar, t.Format("2006"))
 	name = strings.ReplaceAll(name, MonthVar, t.Format("01"))
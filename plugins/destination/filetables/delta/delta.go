@@ -0,0 +1,439 @@
+// Package delta writes tables in the Delta Lake table format, see https://github.com/delta-io/delta/blob/master/PROTOCOL.md.
+// Each batch is written as a new parquet file, and all files written to a table during a sync are added to its
+// transaction log in a single commit at the end of the sync.
+package delta
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/google/uuid"
+)
+
+const (
+	logDir = "_delta_log"
+	// commitRetries is the number of times a commit is retried when another writer committed the same version
+	commitRetries = 10
+
+	tagSourceName = "cq_source_name"
+	tagSyncTime   = "cq_sync_time"
+)
+
+// Delta Lake transaction log actions, see https://github.com/delta-io/delta/blob/master/PROTOCOL.md#actions
+type action struct {
+	CommitInfo *commitInfo    `json:"commitInfo,omitempty"`
+	Protocol   *protocol      `json:"protocol,omitempty"`
+	MetaData   *tableMetadata `json:"metaData,omitempty"`
+	Add        *addAction     `json:"add,omitempty"`
+	Remove     *removeAction  `json:"remove,omitempty"`
+}
+
+type commitInfo struct {
+	Timestamp           int64             `json:"timestamp"`
+	Operation           string            `json:"operation"`
+	OperationParameters map[string]string `json:"operationParameters"`
+	EngineInfo          string            `json:"engineInfo,omitempty"`
+}
+
+type protocol struct {
+	MinReaderVersion int `json:"minReaderVersion"`
+	MinWriterVersion int `json:"minWriterVersion"`
+}
+
+type fileFormat struct {
+	Provider string            `json:"provider"`
+	Options  map[string]string `json:"options"`
+}
+
+type tableMetadata struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	Format           fileFormat        `json:"format"`
+	SchemaString     string            `json:"schemaString"`
+	PartitionColumns []string          `json:"partitionColumns"`
+	Configuration    map[string]string `json:"configuration"`
+	CreatedTime      int64             `json:"createdTime"`
+}
+
+type addAction struct {
+	Path             string            `json:"path"`
+	PartitionValues  map[string]string `json:"partitionValues"`
+	Size             int64             `json:"size"`
+	ModificationTime int64             `json:"modificationTime"`
+	DataChange       bool              `json:"dataChange"`
+	Stats            string            `json:"stats,omitempty"`
+	Tags             map[string]string `json:"tags,omitempty"`
+}
+
+type removeAction struct {
+	Path                 string            `json:"path"`
+	DeletionTimestamp    int64             `json:"deletionTimestamp"`
+	DataChange           bool              `json:"dataChange"`
+	ExtendedFileMetadata bool              `json:"extendedFileMetadata"`
+	PartitionValues      map[string]string `json:"partitionValues"`
+	Size                 int64             `json:"size"`
+	Tags                 map[string]string `json:"tags,omitempty"`
+}
+
+// tableLog holds the state of a Delta Lake table, as replayed from its transaction log,
+// along with the changes pending to be committed at the end of the sync.
+type tableLog struct {
+	store      Store
+	root       string
+	engineInfo string
+
+	mu       sync.Mutex
+	version  int64 // last replayed version, -1 if the table doesn't exist yet
+	metadata *tableMetadata
+	files    []*addAction // active files, in the order they were added
+
+	pendingMetadata *tableMetadata
+	pendingAdds     []*addAction
+	pendingRemoves  []*addAction
+	// pendingErr is set when a write to the table failed during the sync, so the sync isn't committed
+	pendingErr error
+}
+
+func newTableLog(store Store, root, engineInfo string) *tableLog {
+	return &tableLog{store: store, root: root, engineInfo: engineInfo, version: -1}
+}
+
+func (t *tableLog) logPath(version int64) string {
+	return path.Join(t.root, logDir, fmt.Sprintf("%020d.json", version))
+}
+
+// refresh replays the commits added to the transaction log since the last refresh. t.mu must be held.
+func (t *tableLog) refresh(ctx context.Context) error {
+	for {
+		b, err := t.store.Get(ctx, t.logPath(t.version+1))
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := t.apply(b); err != nil {
+			return fmt.Errorf("failed to replay version %d of %s: %w", t.version+1, t.root, err)
+		}
+		t.version++
+	}
+}
+
+func (t *tableLog) apply(commit []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(commit))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var a action
+		if err := json.Unmarshal(line, &a); err != nil {
+			return err
+		}
+		switch {
+		case a.MetaData != nil:
+			t.metadata = a.MetaData
+		case a.Add != nil:
+			t.removeFile(a.Add.Path)
+			t.files = append(t.files, a.Add)
+		case a.Remove != nil:
+			t.removeFile(a.Remove.Path)
+		}
+	}
+	return scanner.Err()
+}
+
+func (t *tableLog) removeFile(p string) {
+	for i, f := range t.files {
+		if f.Path == p {
+			t.files = append(t.files[:i], t.files[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *tableLog) hasPending() bool {
+	return t.pendingMetadata != nil || len(t.pendingAdds) > 0 || len(t.pendingRemoves) > 0 || t.pendingErr != nil
+}
+
+func (t *tableLog) resetPending() {
+	t.pendingMetadata = nil
+	t.pendingAdds = nil
+	t.pendingRemoves = nil
+	t.pendingErr = nil
+}
+
+// commit commits the pending changes as a new version of the table.
+// If another writer committed the same version in the meantime, the log is replayed and the commit is retried.
+func (t *tableLog) commit(ctx context.Context, operation string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer t.resetPending()
+
+	if t.pendingErr != nil {
+		t.dropPendingAdds(ctx, func(*addAction) bool { return true })
+		return t.pendingErr
+	}
+	if !t.hasPending() {
+		return nil
+	}
+
+	for i := 0; i < commitRetries; i++ {
+		if err := t.refresh(ctx); err != nil {
+			return err
+		}
+		b, err := t.commitActions(operation)
+		if err != nil {
+			return err
+		}
+		err = t.store.PutIfAbsent(ctx, t.logPath(t.version+1), b)
+		if errors.Is(err, ErrObjectExists) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return t.refresh(ctx)
+	}
+	return fmt.Errorf("failed to commit to %s: too many concurrent commits", t.root)
+}
+
+func (t *tableLog) commitActions(operation string) ([]byte, error) {
+	now := time.Now().UnixMilli()
+	actions := []action{{CommitInfo: &commitInfo{
+		Timestamp:           now,
+		Operation:           operation,
+		OperationParameters: map[string]string{},
+		EngineInfo:          t.engineInfo,
+	}}}
+
+	if t.version < 0 {
+		if t.pendingMetadata == nil {
+			return nil, fmt.Errorf("table %s has no schema", t.root)
+		}
+		actions = append(actions, action{Protocol: &protocol{MinReaderVersion: 1, MinWriterVersion: 2}})
+	}
+	if t.pendingMetadata != nil {
+		actions = append(actions, action{MetaData: t.pendingMetadata})
+	}
+
+	active := make(map[string]bool, len(t.files))
+	for _, f := range t.files {
+		active[f.Path] = true
+	}
+	for _, f := range t.pendingRemoves {
+		if !active[f.Path] {
+			// removed by a concurrent commit
+			continue
+		}
+		actions = append(actions, action{Remove: &removeAction{
+			Path:                 f.Path,
+			DeletionTimestamp:    now,
+			DataChange:           true,
+			ExtendedFileMetadata: true,
+			PartitionValues:      f.PartitionValues,
+			Size:                 f.Size,
+			Tags:                 f.Tags,
+		}})
+	}
+	for _, f := range t.pendingAdds {
+		actions = append(actions, action{Add: f})
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, a := range actions {
+		if err := enc.Encode(a); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// migrate stages the metadata for the table schema. Adding nullable columns is done in place,
+// while any other change requires force migration which removes all the existing data.
+func (t *tableLog) migrate(ctx context.Context, table *schema.Table, force bool) error {
+	schemaString, err := tableSchemaString(table)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.refresh(ctx); err != nil {
+		return err
+	}
+	return t.migrateLocked(ctx, table, schemaString, force)
+}
+
+// ensureMetadata stages the table schema if the table has none yet, e.g. when it's written to without being migrated first.
+func (t *tableLog) ensureMetadata(ctx context.Context, table *schema.Table) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.refresh(ctx); err != nil {
+		return err
+	}
+	if t.metadata != nil || t.pendingMetadata != nil {
+		return nil
+	}
+	schemaString, err := tableSchemaString(table)
+	if err != nil {
+		return err
+	}
+	return t.migrateLocked(ctx, table, schemaString, false)
+}
+
+func (t *tableLog) migrateLocked(ctx context.Context, table *schema.Table, schemaString string, force bool) error {
+	current := t.metadata
+	if t.pendingMetadata != nil {
+		current = t.pendingMetadata
+	}
+	if current != nil && current.SchemaString == schemaString {
+		return nil
+	}
+
+	md := &tableMetadata{
+		ID:               uuid.NewString(),
+		Name:             table.Name,
+		Description:      table.Description,
+		Format:           fileFormat{Provider: "parquet", Options: map[string]string{}},
+		SchemaString:     schemaString,
+		PartitionColumns: []string{},
+		Configuration:    map[string]string{},
+		CreatedTime:      time.Now().UnixMilli(),
+	}
+	if current != nil {
+		md.ID = current.ID
+		md.CreatedTime = current.CreatedTime
+		safe, err := safeMigration(current.SchemaString, schemaString)
+		if err != nil {
+			return err
+		}
+		if !safe {
+			if !force {
+				return fmt.Errorf("table %s requires a forced migration. use 'migrate_mode: forced'", table.Name)
+			}
+			t.pendingRemoves = append(t.pendingRemoves, t.files...)
+			t.dropPendingAdds(ctx, func(*addAction) bool { return true })
+		}
+	}
+	t.pendingMetadata = md
+	return nil
+}
+
+// deleteStale stages the removal of files written by previous syncs of the source.
+func (t *tableLog) deleteStale(ctx context.Context, sourceName string, syncTime time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.refresh(ctx); err != nil {
+		return err
+	}
+
+	stale := func(f *addAction) bool {
+		if f.Tags[tagSourceName] != sourceName {
+			return false
+		}
+		fileSyncTime, err := time.Parse(time.RFC3339Nano, f.Tags[tagSyncTime])
+		return err == nil && fileSyncTime.Before(syncTime)
+	}
+	for _, f := range t.files {
+		if stale(f) {
+			t.pendingRemoves = append(t.pendingRemoves, f)
+		}
+	}
+	t.dropPendingAdds(ctx, stale)
+	return nil
+}
+
+// dropPendingAdds drops matching files written during this sync. As they were never committed, they are deleted right away.
+func (t *tableLog) dropPendingAdds(ctx context.Context, match func(*addAction) bool) {
+	kept := t.pendingAdds[:0]
+	for _, f := range t.pendingAdds {
+		if !match(f) {
+			kept = append(kept, f)
+			continue
+		}
+		_ = t.store.Delete(ctx, path.Join(t.root, f.Path))
+	}
+	t.pendingAdds = kept
+}
+
+func (t *tableLog) addPending(f *addAction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pendingAdds = append(t.pendingAdds, f)
+}
+
+func (t *tableLog) setPendingErr(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pendingErr == nil {
+		t.pendingErr = err
+	}
+}
+
+// activeFiles returns the keys of the files in the latest committed version of the table.
+func (t *tableLog) activeFiles(ctx context.Context) ([]string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.refresh(ctx); err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(t.files))
+	for i, f := range t.files {
+		keys[i] = path.Join(t.root, f.Path)
+	}
+	return keys, nil
+}
+
+// recordSync returns the source name and the latest sync time of the rows in the record,
+// which are used to tag the files so stale ones can be removed.
+func recordSync(record arrow.Record) (sourceName string, syncTime time.Time) {
+	sc := record.Schema()
+	if indices := sc.FieldIndices(schema.CqSourceNameColumn.Name); len(indices) > 0 {
+		if col, ok := record.Column(indices[0]).(*array.String); ok {
+			for i := 0; i < col.Len(); i++ {
+				if col.IsValid(i) {
+					sourceName = col.Value(i)
+					break
+				}
+			}
+		}
+	}
+	if indices := sc.FieldIndices(schema.CqSyncTimeColumn.Name); len(indices) > 0 {
+		if col, ok := record.Column(indices[0]).(*array.Timestamp); ok {
+			unit := col.DataType().(*arrow.TimestampType).Unit
+			for i := 0; i < col.Len(); i++ {
+				if col.IsValid(i) {
+					if t := col.Value(i).ToTime(unit); t.After(syncTime) {
+						syncTime = t
+					}
+				}
+			}
+		}
+	}
+	return sourceName, syncTime
+}
+
+func fileTags(sourceName string, syncTime time.Time) map[string]string {
+	tags := make(map[string]string)
+	if sourceName != "" {
+		tags[tagSourceName] = sourceName
+	}
+	if !syncTime.IsZero() {
+		tags[tagSyncTime] = syncTime.UTC().Format(time.RFC3339Nano)
+	}
+	return tags
+}
@@ -0,0 +1,217 @@
+package delta
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore keeps the objects in memory, so the tables can be tested without a bucket.
+type memStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newMemStore() *memStore {
+	return &memStore{objects: make(map[string][]byte)}
+}
+
+func (s *memStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.objects[key]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+	return b, nil
+}
+
+func (s *memStore) Put(_ context.Context, key string, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = b
+	return nil
+}
+
+func (s *memStore) PutIfAbsent(_ context.Context, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[key]; ok {
+		return ErrObjectExists
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *memStore) keys(prefix string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for k := range s.objects {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func newTestTables(t *testing.T, store Store) *Tables {
+	files, err := filetypes.NewClient(&filetypes.FileSpec{Format: filetypes.FormatTypeParquet})
+	require.NoError(t, err)
+	return NewTables(store, files, func(table string) string { return "delta/" + table }, "cloudquery-test")
+}
+
+func send[T any](msgs ...T) <-chan T {
+	ch := make(chan T, len(msgs))
+	for _, msg := range msgs {
+		ch <- msg
+	}
+	close(ch)
+	return ch
+}
+
+func readAll(ctx context.Context, d *Tables, table *schema.Table) ([]arrow.Record, error) {
+	var records []arrow.Record
+	ch := make(chan arrow.Record)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		errCh <- d.Read(ctx, table, ch)
+	}()
+	for rec := range ch {
+		records = append(records, rec)
+	}
+	return records, <-errCh
+}
+
+func TestTransactionLog(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	d := newTestTables(t, store)
+
+	table := &schema.Table{
+		Name: "test_delta",
+		Columns: []schema.Column{
+			{Name: "id", Type: arrow.PrimitiveTypes.Int64, NotNull: true, Description: "The ID"},
+			{Name: "name", Type: arrow.BinaryTypes.String},
+		},
+	}
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+	bldr.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	bldr.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	record := bldr.NewRecord()
+
+	require.NoError(t, d.Migrate(ctx, send(&message.WriteMigrateTable{Table: table})))
+	require.NoError(t, d.Write(ctx, send(&message.WriteInsert{Record: record})))
+	require.NoError(t, d.Commit(ctx))
+	// adding a nullable column is a metadata-only change
+	table.Columns = append(table.Columns, schema.Column{Name: "extra", Type: arrow.FixedWidthTypes.Boolean})
+	require.NoError(t, d.Migrate(ctx, send(&message.WriteMigrateTable{Table: table})))
+	require.NoError(t, d.Commit(ctx))
+	// changing a column type isn't, unless forced
+	changed := table.Copy(nil)
+	changed.Columns[1].Type = arrow.PrimitiveTypes.Int64
+	require.NoError(t, d.Migrate(ctx, send(&message.WriteMigrateTable{Table: changed})))
+	require.Error(t, d.Commit(ctx))
+
+	logPrefix := "delta/test_delta/" + logDir + "/"
+	require.Equal(t, []string{logPrefix + "00000000000000000000.json", logPrefix + "00000000000000000001.json"}, store.keys(logPrefix))
+
+	first, err := store.Get(ctx, logPrefix+"00000000000000000000.json")
+	require.NoError(t, err)
+	require.Contains(t, string(first), `"protocol":{"minReaderVersion":1,"minWriterVersion":2}`)
+	require.Contains(t, string(first), `"engineInfo":"cloudquery-test"`)
+	require.Contains(t, string(first), `{\"numRecords\":2}`)
+	require.Contains(t, string(first), `{\"name\":\"id\",\"type\":\"long\",\"nullable\":false,\"metadata\":{\"comment\":\"The ID\"}}`)
+
+	var files []string
+	for _, k := range store.keys("delta/test_delta/") {
+		if strings.HasSuffix(k, ".parquet") {
+			files = append(files, k)
+		}
+	}
+	require.Len(t, files, 1)
+	data, err := store.Get(ctx, files[0])
+	require.NoError(t, err)
+	require.Contains(t, string(first), `"size":`+strconv.Itoa(len(data)))
+
+	// new tables replay the transaction log
+	records, err := readAll(ctx, newTestTables(t, store), table)
+	require.NoError(t, err)
+	var rows int64
+	for _, rec := range records {
+		rows += rec.NumRows()
+		// files written before the column was added are read with nulls
+		require.Equal(t, int64(3), rec.NumCols())
+		require.Equal(t, int(rec.NumRows()), rec.Column(2).NullN())
+	}
+	require.Equal(t, int64(2), rows)
+}
+
+func TestCommitConflict(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	table := &schema.Table{Name: "test_delta", Columns: []schema.Column{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}}
+
+	// two writers stage changes from the same version, the second one commits on top of the first
+	first, second := newTableLog(store, "delta/test_delta", "first"), newTableLog(store, "delta/test_delta", "second")
+	require.NoError(t, first.ensureMetadata(ctx, table))
+	require.NoError(t, second.ensureMetadata(ctx, table))
+	first.addPending(&addAction{Path: "part-1.snappy.parquet", PartitionValues: map[string]string{}})
+	second.addPending(&addAction{Path: "part-2.snappy.parquet", PartitionValues: map[string]string{}})
+	require.NoError(t, first.commit(ctx, "WRITE"))
+	require.NoError(t, second.commit(ctx, "WRITE"))
+
+	require.Equal(t, int64(1), second.version)
+	keys, err := newTableLog(store, "delta/test_delta", "").activeFiles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"delta/test_delta/part-1.snappy.parquet", "delta/test_delta/part-2.snappy.parquet"}, keys)
+}
+
+func TestSafeMigration(t *testing.T) {
+	base := &schema.Table{Name: "t", Columns: []schema.Column{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}}
+	cases := []struct {
+		name    string
+		columns []schema.Column
+		safe    bool
+	}{
+		{name: "same", columns: base.Columns, safe: true},
+		{name: "add nullable", columns: append(base.Columns[:1:1], schema.Column{Name: "a", Type: arrow.BinaryTypes.String}), safe: true},
+		{name: "add not null", columns: append(base.Columns[:1:1], schema.Column{Name: "a", Type: arrow.BinaryTypes.String, NotNull: true})},
+		{name: "remove", columns: []schema.Column{{Name: "a", Type: arrow.BinaryTypes.String}}},
+		{name: "change type", columns: []schema.Column{{Name: "id", Type: arrow.BinaryTypes.String}}},
+	}
+	oldSchema, err := tableSchemaString(base)
+	require.NoError(t, err)
+	for _, tc := range cases {
+		newSchema, err := tableSchemaString(&schema.Table{Name: "t", Columns: tc.columns})
+		require.NoError(t, err)
+		safe, err := safeMigration(oldSchema, newSchema)
+		require.NoError(t, err)
+		require.Equal(t, tc.safe, safe, tc.name)
+	}
+}
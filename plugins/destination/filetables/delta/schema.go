@@ -0,0 +1,145 @@
+package delta
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// Delta Lake schema serialization, see https://github.com/delta-io/delta/blob/master/PROTOCOL.md#schema-serialization-format
+type structType struct {
+	Type   string        `json:"type"`
+	Fields []structField `json:"fields"`
+}
+
+type structField struct {
+	Name     string         `json:"name"`
+	Type     any            `json:"type"`
+	Nullable bool           `json:"nullable"`
+	Metadata map[string]any `json:"metadata"`
+}
+
+type arrayType struct {
+	Type         string `json:"type"`
+	ElementType  any    `json:"elementType"`
+	ContainsNull bool   `json:"containsNull"`
+}
+
+type mapType struct {
+	Type              string `json:"type"`
+	KeyType           any    `json:"keyType"`
+	ValueType         any    `json:"valueType"`
+	ValueContainsNull bool   `json:"valueContainsNull"`
+}
+
+func tableSchemaString(table *schema.Table) (string, error) {
+	fields := make([]structField, len(table.Columns))
+	for i, col := range table.Columns {
+		fields[i] = structField{
+			Name:     col.Name,
+			Type:     dataType(col.Type),
+			Nullable: !col.NotNull,
+			Metadata: map[string]any{},
+		}
+		if col.Description != "" {
+			fields[i].Metadata["comment"] = col.Description
+		}
+	}
+	b, err := json.Marshal(structType{Type: "struct", Fields: fields})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal delta schema for table %s: %w", table.Name, err)
+	}
+	return string(b), nil
+}
+
+// dataType returns the Delta Lake type matching the way filetypes writes the Arrow type to parquet.
+func dataType(dt arrow.DataType) any {
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		return "boolean"
+	case *arrow.Int8Type:
+		return "byte"
+	case *arrow.Int16Type, *arrow.Uint8Type:
+		return "short"
+	case *arrow.Int32Type, *arrow.Uint16Type:
+		return "integer"
+	case *arrow.Int64Type, *arrow.Uint32Type, *arrow.Uint64Type:
+		return "long"
+	case *arrow.Float16Type, *arrow.Float32Type:
+		return "float"
+	case *arrow.Float64Type:
+		return "double"
+	case *arrow.Decimal128Type:
+		return fmt.Sprintf("decimal(%d,%d)", dt.Precision, dt.Scale)
+	case *arrow.Decimal256Type:
+		return fmt.Sprintf("decimal(%d,%d)", dt.Precision, dt.Scale)
+	case *arrow.BinaryType, *arrow.FixedSizeBinaryType:
+		return "binary"
+	case *arrow.TimestampType:
+		return "timestamp"
+	case *arrow.Date32Type, *arrow.Date64Type:
+		return "date"
+	case *arrow.StructType:
+		fields := make([]structField, len(dt.Fields()))
+		for i, f := range dt.Fields() {
+			fields[i] = structField{Name: f.Name, Type: dataType(f.Type), Nullable: f.Nullable, Metadata: map[string]any{}}
+		}
+		return structType{Type: "struct", Fields: fields}
+	case *arrow.MapType:
+		return mapType{Type: "map", KeyType: dataType(dt.KeyType()), ValueType: dataType(dt.ItemType()), ValueContainsNull: true}
+	case arrow.ListLikeType:
+		return arrayType{Type: "array", ElementType: dataType(dt.Elem()), ContainsNull: true}
+	default:
+		// strings, and extension (JSON, UUID, inet, MAC) & interval types which are written as strings
+		return "string"
+	}
+}
+
+type schemaField struct {
+	Name     string          `json:"name"`
+	Type     json.RawMessage `json:"type"`
+	Nullable bool            `json:"nullable"`
+}
+
+// safeMigration reports whether the table schema can be changed without rewriting the data,
+// i.e. the only changes are added nullable columns.
+func safeMigration(oldSchema, newSchema string) (bool, error) {
+	var o, n struct {
+		Fields []schemaField `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(oldSchema), &o); err != nil {
+		return false, fmt.Errorf("failed to parse delta schema: %w", err)
+	}
+	if err := json.Unmarshal([]byte(newSchema), &n); err != nil {
+		return false, fmt.Errorf("failed to parse delta schema: %w", err)
+	}
+
+	newFields := make(map[string]schemaField, len(n.Fields))
+	for _, f := range n.Fields {
+		newFields[f.Name] = f
+	}
+	for _, f := range o.Fields {
+		nf, ok := newFields[f.Name]
+		if !ok || nf.Nullable != f.Nullable || !jsonEqual(nf.Type, f.Type) {
+			return false, nil
+		}
+		delete(newFields, f.Name)
+	}
+	for _, f := range newFields {
+		if !f.Nullable {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return false
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
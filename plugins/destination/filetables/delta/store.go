@@ -0,0 +1,24 @@
+package delta
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrObjectNotFound = errors.New("object not found")
+	ErrObjectExists   = errors.New("object already exists")
+)
+
+// Store is the storage of the data files and transaction logs of the Delta Lake tables,
+// e.g. a local directory or a bucket. Keys are slash-separated paths.
+type Store interface {
+	// Get returns the contents of the object, or ErrObjectNotFound if it doesn't exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, r io.Reader) error
+	// PutIfAbsent atomically writes the object, or returns ErrObjectExists if it exists already.
+	// Commits rely on it so that concurrent writers never overwrite each other's versions of a table.
+	PutIfAbsent(ctx context.Context, key string, data []byte) error
+	Delete(ctx context.Context, key string) error
+}
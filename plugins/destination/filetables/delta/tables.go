@@ -0,0 +1,271 @@
+package delta
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet/file"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/google/uuid"
+)
+
+// Tables writes the Delta Lake tables of a destination to the store.
+type Tables struct {
+	store      Store
+	files      *filetypes.Client
+	tableRoot  func(table string) string
+	engineInfo string
+
+	mu     sync.Mutex
+	tables map[string]*tableLog
+}
+
+// NewTables returns the Delta Lake tables kept in the store.
+// files must write parquet, tableRoot returns the key prefix of the table, and engineInfo identifies the writer in the commits.
+func NewTables(store Store, files *filetypes.Client, tableRoot func(table string) string, engineInfo string) *Tables {
+	return &Tables{
+		store:      store,
+		files:      files,
+		tableRoot:  tableRoot,
+		engineInfo: engineInfo,
+		tables:     make(map[string]*tableLog),
+	}
+}
+
+func (d *Tables) table(name string) *tableLog {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if t, ok := d.tables[name]; ok {
+		return t
+	}
+	t := newTableLog(d.store, d.tableRoot(name), d.engineInfo)
+	d.tables[name] = t
+	return t
+}
+
+// The handlers below don't return errors: the batch writer only logs them, and panics when a handler fails
+// after the first sync of the client. Instead, errors are recorded on the table and returned when the sync is committed.
+
+// Write writes the batch to a new parquet file in the table directory.
+// The file is added to the transaction log when the sync is committed.
+func (d *Tables) Write(ctx context.Context, msgs <-chan *message.WriteInsert) error {
+	var (
+		t        *tableLog
+		s        *filetypes.Stream
+		name     string
+		size     countingReader
+		rows     int64
+		source   string
+		syncTime time.Time
+	)
+	fail := func(err error) error {
+		t.setPendingErr(err)
+		if s != nil {
+			_ = s.FinishWithError(err)
+			_ = d.store.Delete(ctx, path.Join(t.root, name))
+		}
+		// drain the channel, so the batch writer isn't blocked
+		// nolint:revive
+		for range msgs {
+		}
+		return nil
+	}
+
+	for msg := range msgs {
+		if s == nil {
+			table := msg.GetTable()
+			t = d.table(table.Name)
+			if err := t.ensureMetadata(ctx, table); err != nil {
+				return fail(err)
+			}
+
+			name = fmt.Sprintf("part-%s.snappy.parquet", uuid.NewString())
+			key := path.Join(t.root, name)
+			var err error
+			s, err = d.files.StartStream(table, func(r io.Reader) error {
+				size.r = r
+				return d.store.Put(ctx, key, &size)
+			})
+			if err != nil {
+				return fail(err)
+			}
+		}
+
+		if err := s.Write([]arrow.Record{msg.Record}); err != nil {
+			return fail(err)
+		}
+		rows += msg.Record.NumRows()
+		recordSource, recordSyncTime := recordSync(msg.Record)
+		if recordSource != "" {
+			source = recordSource
+		}
+		if recordSyncTime.After(syncTime) {
+			syncTime = recordSyncTime
+		}
+	}
+	if s == nil {
+		return nil
+	}
+
+	if err := s.Finish(); err != nil {
+		s = nil
+		_ = d.store.Delete(ctx, path.Join(t.root, name))
+		return fail(err)
+	}
+
+	t.addPending(&addAction{
+		Path:             name,
+		PartitionValues:  map[string]string{},
+		Size:             size.n,
+		ModificationTime: time.Now().UnixMilli(),
+		DataChange:       true,
+		Stats:            fmt.Sprintf(`{"numRecords":%d}`, rows),
+		Tags:             fileTags(source, syncTime),
+	})
+	return nil
+}
+
+// countingReader counts the bytes of the written file, which are needed for its entry in the transaction log.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// Migrate stages the schemas of the tables, to be committed at the end of the sync.
+func (d *Tables) Migrate(ctx context.Context, msgs <-chan *message.WriteMigrateTable) error {
+	for msg := range msgs {
+		t := d.table(msg.Table.Name)
+		if err := t.migrate(ctx, msg.Table, msg.MigrateForce); err != nil {
+			t.setPendingErr(err)
+		}
+	}
+	return nil
+}
+
+// DeleteStale stages the removal of the files written by previous syncs of the source.
+func (d *Tables) DeleteStale(ctx context.Context, msgs <-chan *message.WriteDeleteStale) error {
+	for msg := range msgs {
+		t := d.table(msg.TableName)
+		if err := t.deleteStale(ctx, msg.SourceName, msg.SyncTime); err != nil {
+			t.setPendingErr(err)
+		}
+	}
+	return nil
+}
+
+// Commit commits the changes made to every table during the sync, one commit per table.
+func (d *Tables) Commit(ctx context.Context) error {
+	d.mu.Lock()
+	names := make([]string, 0, len(d.tables))
+	for name := range d.tables {
+		names = append(names, name)
+	}
+	d.mu.Unlock()
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if err := d.table(name).commit(ctx, "WRITE"); err != nil {
+			errs = append(errs, fmt.Errorf("failed to commit table %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Read reads the files of the latest committed version of the table.
+// Files written before nullable columns were added are read with nulls in those columns.
+func (d *Tables) Read(ctx context.Context, table *schema.Table, res chan<- arrow.Record) error {
+	keys, err := d.table(table.Name).activeFiles(ctx)
+	if err != nil {
+		return err
+	}
+	sc := table.ToArrowSchema()
+	for _, key := range keys {
+		if err := d.readFile(ctx, key, table, sc, res); err != nil {
+			return fmt.Errorf("failed to read %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func (d *Tables) readFile(ctx context.Context, key string, table *schema.Table, sc *arrow.Schema, res chan<- arrow.Record) error {
+	b, err := d.store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	fileTable, err := tableOfFile(b, table)
+	if err != nil {
+		return err
+	}
+
+	ch := make(chan arrow.Record)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		errCh <- d.files.Read(bytes.NewReader(b), fileTable, ch)
+	}()
+	for rec := range ch {
+		res <- padRecord(sc, rec)
+	}
+	return <-errCh
+}
+
+// tableOfFile returns the table as it was when the file was written, i.e. only with the columns present in the file.
+func tableOfFile(b []byte, table *schema.Table) (*schema.Table, error) {
+	rdr, err := file.NewParquetReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		return nil, err
+	}
+	fileSchema, err := fr.Schema()
+	if err != nil {
+		return nil, err
+	}
+
+	fileTable := *table
+	fileTable.Columns = make(schema.ColumnList, 0, len(fileSchema.Fields()))
+	for _, field := range fileSchema.Fields() {
+		col := table.Columns.Get(field.Name)
+		if col == nil {
+			return nil, fmt.Errorf("column %q not found in table %s", field.Name, table.Name)
+		}
+		fileTable.Columns = append(fileTable.Columns, *col)
+	}
+	return &fileTable, nil
+}
+
+// padRecord returns the record with the given schema, with nulls in the columns missing from the record.
+func padRecord(sc *arrow.Schema, rec arrow.Record) arrow.Record {
+	cols := make([]arrow.Array, len(sc.Fields()))
+	for i, field := range sc.Fields() {
+		if indices := rec.Schema().FieldIndices(field.Name); len(indices) > 0 {
+			cols[i] = rec.Column(indices[0])
+			continue
+		}
+		cols[i] = array.MakeArrayOfNull(memory.DefaultAllocator, field.Type, int(rec.NumRows()))
+	}
+	return array.NewRecord(sc, cols, rec.NumRows())
+}
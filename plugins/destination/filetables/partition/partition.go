@@ -0,0 +1,122 @@
+// Package partition splits records by the values of the columns referenced by {{COLUMN:name}} path variables.
+package partition
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow"
+)
+
+// hiveDefaultPartition is the partition value used for null (or missing) column values,
+// matching the convention used by Hive, Athena and Spark.
+const hiveDefaultPartition = "__HIVE_DEFAULT_PARTITION__"
+
+var reColumnVar = regexp.MustCompile(`{{COLUMN:([^{}]+)}}`)
+
+// Partition is a part of a record whose rows share the same values for all columns
+// referenced by {{COLUMN:name}} path variables.
+type Partition struct {
+	Path    string
+	Records []arrow.Record
+}
+
+// HasColumnVariables reports whether the path template contains {{COLUMN:name}} variables.
+func HasColumnVariables(specPath string) bool {
+	return reColumnVar.MatchString(specPath)
+}
+
+// ColumnName returns the name of the column referenced by the first {{COLUMN:name}} variable in s.
+func ColumnName(s string) (string, bool) {
+	m := reColumnVar.FindStringSubmatch(s)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// ReplaceColumnVariables replaces all {{COLUMN:name}} variables in the path template with value.
+func ReplaceColumnVariables(specPath, value string) string {
+	return reColumnVar.ReplaceAllLiteralString(specPath, value)
+}
+
+// Split splits the record by the values of the columns referenced in the path template.
+// Each returned partition holds the path template with the column variables replaced.
+// Rows are not copied: partitions reference slices of the original record, which must be released with Release.
+func Split(specPath string, record arrow.Record) []Partition {
+	matches := reColumnVar.FindAllStringSubmatch(specPath, -1)
+	if len(matches) == 0 {
+		record.Retain()
+		return []Partition{{Path: specPath, Records: []arrow.Record{record}}}
+	}
+
+	cols := make([]arrow.Array, len(matches))
+	for i, m := range matches {
+		if indices := record.Schema().FieldIndices(m[1]); len(indices) > 0 {
+			cols[i] = record.Column(indices[0])
+		}
+	}
+
+	var parts []Partition
+	index := make(map[string]int)
+	add := func(p string, start, end int64) {
+		slice := record.NewSlice(start, end)
+		if i, ok := index[p]; ok {
+			parts[i].Records = append(parts[i].Records, slice)
+			return
+		}
+		index[p] = len(parts)
+		parts = append(parts, Partition{Path: p, Records: []arrow.Record{slice}})
+	}
+
+	var (
+		start   int64
+		current string
+	)
+	for row := int64(0); row < record.NumRows(); row++ {
+		p := replaceColumnValues(specPath, matches, cols, int(row))
+		if row > start && p != current {
+			add(current, start, row)
+			start = row
+		}
+		current = p
+	}
+	if record.NumRows() > start {
+		add(current, start, record.NumRows())
+	}
+	return parts
+}
+
+// Release releases the records of the partitions.
+func Release(parts []Partition) {
+	for _, p := range parts {
+		for _, r := range p.Records {
+			r.Release()
+		}
+	}
+}
+
+func replaceColumnValues(specPath string, matches [][]string, cols []arrow.Array, row int) string {
+	name := specPath
+	for i, m := range matches {
+		name = strings.Replace(name, m[0], columnPathValue(cols[i], row), 1)
+	}
+	return name
+}
+
+// columnPathValue returns the value of the column at the given row, escaped so it can be safely used as a path segment.
+func columnPathValue(col arrow.Array, row int) string {
+	if col == nil || col.IsNull(row) {
+		return hiveDefaultPartition
+	}
+	switch v := col.ValueStr(row); v {
+	case "":
+		return hiveDefaultPartition
+	case ".", "..":
+		// don't let values traverse the directory structure
+		return strings.ReplaceAll(v, ".", "%2E")
+	default:
+		return url.PathEscape(v)
+	}
+}
@@ -0,0 +1,92 @@
+package partition
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplit(t *testing.T) {
+	sc := arrow.NewSchema([]arrow.Field{
+		{Name: "account_id", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "region", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	bldr := array.NewRecordBuilder(mem, sc)
+	defer bldr.Release()
+	accounts := bldr.Field(0).(*array.StringBuilder)
+	regions := bldr.Field(1).(*array.StringBuilder)
+	accounts.AppendValues([]string{"1", "1", "2", "1", "", "3"}, []bool{true, true, true, true, false, true})
+	regions.AppendValues([]string{"us-east-1", "us-east-1", "us-east-1", "eu-west-1", "us-east-1", "a/.."}, nil)
+	record := bldr.NewRecord()
+	defer record.Release()
+
+	cases := []struct {
+		path string
+		want map[string]int64
+	}{
+		{
+			path: "{{TABLE}}/{{UUID}}.json",
+			want: map[string]int64{"{{TABLE}}/{{UUID}}.json": 6},
+		},
+		{
+			path: "{{TABLE}}/account_id={{COLUMN:account_id}}/{{UUID}}.json",
+			want: map[string]int64{
+				"{{TABLE}}/account_id=1/{{UUID}}.json":                          3,
+				"{{TABLE}}/account_id=2/{{UUID}}.json":                          1,
+				"{{TABLE}}/account_id=__HIVE_DEFAULT_PARTITION__/{{UUID}}.json": 1,
+				"{{TABLE}}/account_id=3/{{UUID}}.json":                          1,
+			},
+		},
+		{
+			path: "{{TABLE}}/{{COLUMN:account_id}}/{{COLUMN:region}}/{{COLUMN:missing}}.json",
+			want: map[string]int64{
+				"{{TABLE}}/1/us-east-1/__HIVE_DEFAULT_PARTITION__.json":                          2,
+				"{{TABLE}}/2/us-east-1/__HIVE_DEFAULT_PARTITION__.json":                          1,
+				"{{TABLE}}/1/eu-west-1/__HIVE_DEFAULT_PARTITION__.json":                          1,
+				"{{TABLE}}/__HIVE_DEFAULT_PARTITION__/us-east-1/__HIVE_DEFAULT_PARTITION__.json": 1,
+				"{{TABLE}}/3/a%2F../__HIVE_DEFAULT_PARTITION__.json":                             1,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		got := make(map[string]int64)
+		parts := Split(tc.path, record)
+		for _, p := range parts {
+			if _, ok := got[p.Path]; ok {
+				t.Errorf("duplicate partition %q for path %q", p.Path, tc.path)
+			}
+			for _, r := range p.Records {
+				got[p.Path] += r.NumRows()
+			}
+		}
+		Release(parts)
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("Split(%q) mismatch (-want +got):\n%s", tc.path, diff)
+		}
+	}
+}
+
+func TestColumnVariables(t *testing.T) {
+	const path = "{{TABLE}}/account_id={{COLUMN:account_id}}/{{COLUMN:region}}/{{UUID}}.json"
+	if !HasColumnVariables(path) {
+		t.Errorf("HasColumnVariables(%q) = false, want true", path)
+	}
+	if HasColumnVariables("{{TABLE}}/{{UUID}}.json") {
+		t.Error("HasColumnVariables without column variables = true, want false")
+	}
+	if name, ok := ColumnName("account_id={{COLUMN:account_id}}"); !ok || name != "account_id" {
+		t.Errorf("ColumnName = %q, %v, want \"account_id\", true", name, ok)
+	}
+	if _, ok := ColumnName("{{YEAR}}"); ok {
+		t.Error("ColumnName({{YEAR}}) ok = true, want false")
+	}
+	if got, want := ReplaceColumnVariables(path, "TEST_COLUMN"), "{{TABLE}}/account_id=TEST_COLUMN/TEST_COLUMN/{{UUID}}.json"; got != want {
+		t.Errorf("ReplaceColumnVariables = %q, want %q", got, want)
+	}
+}
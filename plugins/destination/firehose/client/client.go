@@ -5,9 +5,9 @@ import (
 	"fmt"
 
 	"github.com/apache/arrow/go/v13/arrow"
-	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	"github.com/cloudquery/plugin-sdk/v4/glob"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/goccy/go-json"
@@ -15,9 +15,21 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// firehoseAPI is the part of the Firehose API used by the client, so that it can be mocked in tests.
+type firehoseAPI interface {
+	PutRecordBatch(ctx context.Context, params *firehose.PutRecordBatchInput, optFns ...func(*firehose.Options)) (*firehose.PutRecordBatchOutput, error)
+}
+
+type stream struct {
+	arn    string
+	name   string
+	client firehoseAPI
+}
+
 type Client struct {
-	firehoseClient *firehose.Client
-	spec           Spec
+	// streams by ARN
+	streams map[string]*stream
+	spec    Spec
 
 	logger zerolog.Logger
 	plugin.UnimplementedSource
@@ -35,20 +47,48 @@ func New(ctx context.Context, logger zerolog.Logger, specBytes []byte, _ plugin.
 		return nil, err
 	}
 
-	parsedARN, err := arn.Parse(spec.StreamARN)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse firehose stream ARN: %w", err)
+	c := &Client{
+		logger:  logger.With().Str("module", "firehose").Logger(),
+		spec:    spec,
+		streams: make(map[string]*stream),
 	}
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(parsedARN.Region))
-	if err != nil {
-		return nil, fmt.Errorf("unable to load AWS SDK config: %w", err)
+
+	// streams may be in different regions, so there's a client per region
+	clients := make(map[string]firehoseAPI)
+	for _, streamARN := range spec.streamARNs() {
+		parsedARN, err := parseStreamARN(streamARN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse firehose stream ARN: %w", err)
+		}
+		client, ok := clients[parsedARN.Region]
+		if !ok {
+			cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(parsedARN.Region))
+			if err != nil {
+				return nil, fmt.Errorf("unable to load AWS SDK config: %w", err)
+			}
+			client = firehose.NewFromConfig(cfg)
+			clients[parsedARN.Region] = client
+		}
+		c.streams[streamARN] = &stream{arn: streamARN, name: streamName(parsedARN), client: client}
 	}
 
-	return &Client{
-		logger:         logger.With().Str("module", "firehose").Logger(),
-		spec:           spec,
-		firehoseClient: firehose.NewFromConfig(cfg),
-	}, nil
+	return c, nil
+}
+
+// streamFor returns the delivery stream of the table: the stream of the first `streams` entry matching it, or `stream_arn`.
+// The clients of all of them are created in New, so only tables without any stream are an error here.
+func (c *Client) streamFor(table string) (*stream, error) {
+	for _, s := range c.spec.Streams {
+		for _, pattern := range s.Tables {
+			if glob.Glob(pattern, table) {
+				return c.streams[s.StreamARN], nil
+			}
+		}
+	}
+	if c.spec.StreamARN == "" {
+		return nil, fmt.Errorf("no stream configured for table %s", table)
+	}
+	return c.streams[c.spec.StreamARN], nil
 }
 
 func (*Client) Close(context.Context) error { return nil }
@@ -4,7 +4,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	"github.com/aws/aws-sdk-go-v2/service/firehose/types"
+	"github.com/cloudquery/plugin-sdk/v4/message"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/goccy/go-json"
 	"github.com/stretchr/testify/require"
 )
@@ -28,3 +36,91 @@ func TestPluginJSON(t *testing.T) {
 		},
 	)
 }
+
+type mockFirehose struct {
+	inputs []*firehose.PutRecordBatchInput
+}
+
+func (m *mockFirehose) PutRecordBatch(_ context.Context, params *firehose.PutRecordBatchInput, _ ...func(*firehose.Options)) (*firehose.PutRecordBatchOutput, error) {
+	// the records are reused by the client after the call, so they're copied
+	m.inputs = append(m.inputs, &firehose.PutRecordBatchInput{
+		DeliveryStreamName: params.DeliveryStreamName,
+		Records:            append([]types.Record(nil), params.Records...),
+	})
+	out := &firehose.PutRecordBatchOutput{}
+	for range params.Records {
+		out.RequestResponses = append(out.RequestResponses, types.PutRecordBatchResponseEntry{RecordId: aws.String("id")})
+	}
+	return out, nil
+}
+
+func TestWriteRouting(t *testing.T) {
+	const (
+		defaultARN = "arn:aws:firehose:us-east-1:123456789012:deliverystream/default"
+		ec2ARN     = "arn:aws:firehose:us-east-1:123456789012:deliverystream/ec2"
+	)
+	spec := Spec{
+		StreamARN:     defaultARN,
+		Streams:       []StreamSpec{{Tables: []string{"aws_ec2_*"}, StreamARN: ec2ARN}},
+		PartitionKeys: []string{"_cq_table", "account_id"},
+	}
+	spec.SetDefaults()
+	require.NoError(t, spec.Validate())
+
+	mock := &mockFirehose{}
+	c := &Client{
+		spec: spec,
+		streams: map[string]*stream{
+			defaultARN: {arn: defaultARN, name: "default", client: mock},
+			ec2ARN:     {arn: ec2ARN, name: "ec2", client: mock},
+		},
+	}
+
+	msgs := make(chan message.WriteMessage, 2)
+	msgs <- &message.WriteInsert{Record: testRecord("aws_ec2_instances", "123")}
+	msgs <- &message.WriteInsert{Record: testRecord("aws_s3_buckets", "")}
+	close(msgs)
+	require.NoError(t, c.Write(context.Background(), msgs))
+
+	require.Len(t, mock.inputs, 2)
+	require.Equal(t, "default", *mock.inputs[0].DeliveryStreamName)
+	require.JSONEq(t,
+		`{"account_id":null,"_cq_table":"aws_s3_buckets","_cq_table_name":"aws_s3_buckets","_cq_partition_keys":{"_cq_table":"aws_s3_buckets","account_id":"null"}}`,
+		string(mock.inputs[0].Records[0].Data),
+	)
+	require.Equal(t, "ec2", *mock.inputs[1].DeliveryStreamName)
+	require.JSONEq(t,
+		`{"account_id":"123","_cq_table":"aws_ec2_instances","_cq_table_name":"aws_ec2_instances","_cq_partition_keys":{"_cq_table":"aws_ec2_instances","account_id":"123"}}`,
+		string(mock.inputs[1].Records[0].Data),
+	)
+}
+
+func TestStreamFor(t *testing.T) {
+	c := &Client{
+		spec: Spec{Streams: []StreamSpec{{Tables: []string{"aws_*"}, StreamARN: "aws"}}},
+		streams: map[string]*stream{
+			"aws": {arn: "aws"},
+		},
+	}
+	s, err := c.streamFor("aws_ec2_instances")
+	require.NoError(t, err)
+	require.Equal(t, "aws", s.arn)
+
+	_, err = c.streamFor("gcp_projects")
+	require.Error(t, err)
+}
+
+func testRecord(tableName, accountID string) arrow.Record {
+	table := &schema.Table{
+		Name:    tableName,
+		Columns: schema.ColumnList{{Name: "account_id", Type: arrow.BinaryTypes.String}},
+	}
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+	defer bldr.Release()
+	if accountID == "" {
+		bldr.Field(0).AppendNull()
+	} else {
+		bldr.Field(0).(*array.StringBuilder).Append(accountID)
+	}
+	return bldr.NewRecord()
+}
@@ -2,14 +2,21 @@ package client
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 )
 
 type Spec struct {
-	StreamARN  string `json:"stream_arn"`
-	NoRotate   bool   `json:"no_rotate,omitempty"`
-	MaxRetries *int   `json:"max_retries,omitempty"`
+	// StreamARN is the default stream, for the tables not matching any of Streams
+	StreamARN  string       `json:"stream_arn"`
+	Streams    []StreamSpec `json:"streams,omitempty"`
+	NoRotate   bool         `json:"no_rotate,omitempty"`
+	MaxRetries *int         `json:"max_retries,omitempty"`
+
+	// PartitionKeys are the columns copied to the _cq_partition_keys object of each record as strings,
+	// to be used as Firehose dynamic partitioning keys
+	PartitionKeys []string `json:"partition_keys,omitempty"`
 
 	MaxRecordSizeBytes int `json:"max_record_size_bytes,omitempty"`
 	MaxBatchRecords    int `json:"max_batch_records,omitempty"`
@@ -38,16 +45,70 @@ func (s *Spec) SetDefaults() {
 	}
 }
 
+// StreamSpec routes the tables matching any of the patterns to the stream.
+type StreamSpec struct {
+	Tables    []string `json:"tables"`
+	StreamARN string   `json:"stream_arn"`
+}
+
 func (s *Spec) Validate() error {
-	if s.StreamARN == "" {
+	if s.StreamARN == "" && len(s.Streams) == 0 {
 		return fmt.Errorf("kinesis firehose Stream ARN is required")
 	}
-	parsedARN, err := arn.Parse(s.StreamARN)
-	if err != nil {
-		return fmt.Errorf("kinesis firehose Stream ARN is invalid")
+	if s.StreamARN != "" {
+		if err := validateStreamARN(s.StreamARN); err != nil {
+			return err
+		}
 	}
-	if parsedARN.Service != "firehose" {
+	for i, stream := range s.Streams {
+		if len(stream.Tables) == 0 {
+			return fmt.Errorf("streams[%d]: tables are required", i)
+		}
+		if stream.StreamARN == "" {
+			return fmt.Errorf("streams[%d]: kinesis firehose Stream ARN is required", i)
+		}
+		if err := validateStreamARN(stream.StreamARN); err != nil {
+			return fmt.Errorf("streams[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// streamARNs returns the ARNs of all configured streams.
+func (s *Spec) streamARNs() []string {
+	arns := make([]string, 0, len(s.Streams)+1)
+	if s.StreamARN != "" {
+		arns = append(arns, s.StreamARN)
+	}
+	for _, stream := range s.Streams {
+		arns = append(arns, stream.StreamARN)
+	}
+	return arns
+}
+
+func validateStreamARN(streamARN string) error {
+	if _, err := parseStreamARN(streamARN); err != nil {
 		return fmt.Errorf("kinesis firehose Stream ARN is invalid")
 	}
 	return nil
 }
+
+// parseStreamARN parses the ARN of a delivery stream (arn:aws:firehose:region:account:deliverystream/name).
+func parseStreamARN(streamARN string) (arn.ARN, error) {
+	parsedARN, err := arn.Parse(streamARN)
+	if err != nil {
+		return arn.ARN{}, err
+	}
+	if parsedARN.Service != "firehose" {
+		return arn.ARN{}, fmt.Errorf("invalid service %q", parsedARN.Service)
+	}
+	if parts := strings.Split(parsedARN.Resource, "/"); len(parts) != 2 {
+		return arn.ARN{}, fmt.Errorf("invalid resource %q", parsedARN.Resource)
+	}
+	return parsedARN, nil
+}
+
+// streamName returns the delivery stream name from the parsed ARN.
+func streamName(parsedARN arn.ARN) string {
+	return strings.Split(parsedARN.Resource, "/")[1]
+}
@@ -5,31 +5,36 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
+	"github.com/apache/arrow/go/v13/arrow"
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/firehose"
 	"github.com/aws/aws-sdk-go-v2/service/firehose/types"
 	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
+const (
+	tableField = "_cq_table"
+	// legacyTableField is kept for the existing delivery stream configurations, use tableField instead
+	legacyTableField   = "_cq_table_name"
+	partitionKeysField = "_cq_partition_keys"
+	// nullPartitionKey is the partition key value for null values, as Firehose fails records with missing keys
+	nullPartitionKey = "null"
+)
+
+// batch is the batch of records being accumulated for a stream
+type batch struct {
+	stream *stream
+	input  *firehose.PutRecordBatchInput
+	size   int
+}
+
 func (c *Client) Write(ctx context.Context, messages <-chan message.WriteMessage) error {
-	parsedARN, err := arn.Parse(c.spec.StreamARN)
-	if err != nil {
-		c.logger.Error().Err(err).Msg("invalid firehose stream ARN")
-		return err
-	}
-	arnResource := strings.Split(parsedARN.Resource, "/")
-	if len(arnResource) != 2 {
-		c.logger.Error().Err(err).Msg("invalid firehose stream ARN")
-		return fmt.Errorf("invalid firehose stream ARN")
-	}
-	recordsBatchInput := &firehose.PutRecordBatchInput{
-		DeliveryStreamName: aws.String(arnResource[1]),
-	}
-	batchSize := 0
+	batches := make(map[string]*batch)
 
 	for m := range messages {
 		switch m := m.(type) {
@@ -47,61 +52,114 @@ func (c *Client) Write(ctx context.Context, messages <-chan message.WriteMessage
 		ins := m.(*message.WriteInsert)
 		table, rec := ins.GetTable(), ins.Record
 
-		for row := int64(0); row < rec.NumRows(); row++ {
-			jsonObj := make(map[string]any, rec.NumCols()+1)
-			for i := range rec.Columns() {
-				jsonObj[rec.ColumnName(i)] = rec.Column(i).GetOneForMarshal(int(row))
-			}
-			// Add table name to the json object
-			// TODO: This should be added to the SDK so that it can be used for other plugins as well
-			jsonObj["_cq_table_name"] = table.Name
-			b, err := json.Marshal(jsonObj)
-			if err != nil {
-				return err
+		s, err := c.streamFor(table.Name)
+		if err != nil {
+			return err
+		}
+		b, ok := batches[s.arn]
+		if !ok {
+			b = &batch{
+				stream: s,
+				input:  &firehose.PutRecordBatchInput{DeliveryStreamName: aws.String(s.name)},
 			}
-			dst := &bytes.Buffer{}
-			err = json.Compact(dst, b)
+			batches[s.arn] = b
+		}
+
+		for row := 0; row < int(rec.NumRows()); row++ {
+			data, err := c.recordData(table, rec, row)
 			if err != nil {
 				return err
 			}
-			if len(dst.Bytes()) > c.spec.MaxRecordSizeBytes {
-				c.logger.Warn().Msgf("skipping record because it is too large: %s", string(b))
+			if len(data) > c.spec.MaxRecordSizeBytes {
+				c.logger.Warn().Msgf("skipping record because it is too large: %s", string(data))
 				continue
 			}
 
 			// If adding this record would exceed the batch size, send the batch
-			if len(dst.Bytes())+batchSize > c.spec.MaxBatchSizeBytes {
-				err := c.sendBatch(ctx, recordsBatchInput, 0)
-				if err != nil {
+			if len(data)+b.size > c.spec.MaxBatchSizeBytes {
+				if err := c.flush(ctx, b); err != nil {
 					return err
 				}
-				recordsBatchInput.Records = recordsBatchInput.Records[:0]
-				batchSize = 0
 			}
 
-			recordsBatchInput.Records = append(recordsBatchInput.Records, types.Record{
-				Data: dst.Bytes(),
-			})
+			b.input.Records = append(b.input.Records, types.Record{Data: data})
 			// Store a running total of the batch size
-			batchSize += len(dst.Bytes())
+			b.size += len(data)
 
 			// Send the batch if it is full
-			if len(recordsBatchInput.Records) >= c.spec.MaxBatchRecords {
-				err := c.sendBatch(ctx, recordsBatchInput, 0)
-				if err != nil {
+			if len(b.input.Records) >= c.spec.MaxBatchRecords {
+				if err := c.flush(ctx, b); err != nil {
 					return err
 				}
-				// Reset the batch
-				recordsBatchInput.Records = recordsBatchInput.Records[:0]
-				batchSize = 0
 			}
 		}
 	}
-	// Send the last batch
-	return c.sendBatch(ctx, recordsBatchInput, 0)
+
+	// Send the last batches
+	arns := maps.Keys(batches)
+	slices.Sort(arns)
+	for _, streamARN := range arns {
+		if err := c.flush(ctx, batches[streamARN]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordData returns the JSON object for the row, with the table name and the partition keys added.
+func (c *Client) recordData(table *schema.Table, rec arrow.Record, row int) ([]byte, error) {
+	jsonObj := make(map[string]any, rec.NumCols()+3)
+	for i := range rec.Columns() {
+		jsonObj[rec.ColumnName(i)] = rec.Column(i).GetOneForMarshal(row)
+	}
+	jsonObj[tableField] = table.Name
+	jsonObj[legacyTableField] = table.Name
+	if len(c.spec.PartitionKeys) > 0 {
+		jsonObj[partitionKeysField] = partitionKeys(c.spec.PartitionKeys, table, rec, row)
+	}
+
+	b, err := json.Marshal(jsonObj)
+	if err != nil {
+		return nil, err
+	}
+	dst := &bytes.Buffer{}
+	if err := json.Compact(dst, b); err != nil {
+		return nil, err
+	}
+	return dst.Bytes(), nil
+}
+
+// partitionKeys returns the values of the key columns as strings, so that they can be used in S3 prefixes as they are.
+// _cq_table can be used as a key for the table name.
+func partitionKeys(keys []string, table *schema.Table, rec arrow.Record, row int) map[string]string {
+	sc := rec.Schema()
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if key == tableField {
+			values[key] = table.Name
+			continue
+		}
+		values[key] = nullPartitionKey
+		if idx := sc.FieldIndices(key); len(idx) > 0 {
+			if col := rec.Column(idx[0]); col.IsValid(row) {
+				values[key] = col.ValueStr(row)
+			}
+		}
+	}
+	return values
+}
+
+func (c *Client) flush(ctx context.Context, b *batch) error {
+	if err := c.sendBatch(ctx, b.stream, b.input, 0); err != nil {
+		return err
+	}
+	// Reset the batch
+	b.input.Records = b.input.Records[:0]
+	b.size = 0
+	return nil
 }
 
-func (c *Client) sendBatch(ctx context.Context, recordsBatchInput *firehose.PutRecordBatchInput, count int) error {
+func (c *Client) sendBatch(ctx context.Context, s *stream, recordsBatchInput *firehose.PutRecordBatchInput, count int) error {
 	if count == *c.spec.MaxRetries {
 		return fmt.Errorf("max retries reached")
 	}
@@ -109,13 +167,13 @@ func (c *Client) sendBatch(ctx context.Context, recordsBatchInput *firehose.PutR
 		return nil
 	}
 	time.Sleep(time.Duration(count) * time.Second)
-	resp, err := c.firehoseClient.PutRecordBatch(ctx, recordsBatchInput)
+	resp, err := s.client.PutRecordBatch(ctx, recordsBatchInput)
 	if err != nil {
-		c.logger.Error().Err(err).Msg("failed to write to firehose")
+		c.logger.Error().Err(err).Str("stream", s.arn).Msg("failed to write to firehose")
 		return err
 	}
 	retryRecords := getFailedRecords(recordsBatchInput, resp)
-	return c.sendBatch(ctx, retryRecords, count+1)
+	return c.sendBatch(ctx, s, retryRecords, count+1)
 }
 
 func getFailedRecords(recordsBatchInput *firehose.PutRecordBatchInput, resp *firehose.PutRecordBatchOutput) *firehose.PutRecordBatchInput {
@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 
 	"cloud.google.com/go/storage"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/delta"
 	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/message"
 	"github.com/cloudquery/plugin-sdk/v4/writers/streamingbatchwriter"
 
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
@@ -16,8 +19,6 @@ import (
 
 type Client struct {
 	plugin.UnimplementedSource
-	streamingbatchwriter.IgnoreMigrateTable
-	streamingbatchwriter.UnimplementedDeleteStale
 
 	logger zerolog.Logger
 	spec   *Spec
@@ -27,6 +28,8 @@ type Client struct {
 	*filetypes.Client
 
 	writer *streamingbatchwriter.StreamingBatchWriter
+
+	delta *delta.Tables
 }
 
 func New(ctx context.Context, logger zerolog.Logger, spec []byte, _ plugin.NewClientOptions) (plugin.Client, error) {
@@ -61,6 +64,9 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, _ plugin.NewCl
 	if err := gcpWriter.Close(); err != nil {
 		return nil, fmt.Errorf("failed to close GCS writer: %w", err)
 	}
+	if c.spec.TableFormat == TableFormatDelta {
+		c.delta = delta.NewTables(&gcsDeltaStore{bucket: c.bucket}, c.Client, c.deltaTableRoot, "cloudquery-gcs-destination")
+	}
 
 	c.writer, err = streamingbatchwriter.New(c,
 		streamingbatchwriter.WithBatchSizeRows(*c.spec.BatchSize),
@@ -74,6 +80,24 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, _ plugin.NewCl
 	return c, nil
 }
 
+func (c *Client) MigrateTable(ctx context.Context, msgs <-chan *message.WriteMigrateTable) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Migrate(ctx, msgs)
+	}
+	return streamingbatchwriter.IgnoreMigrateTable{}.MigrateTable(ctx, msgs)
+}
+
+func (c *Client) DeleteStale(ctx context.Context, msgs <-chan *message.WriteDeleteStale) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.DeleteStale(ctx, msgs)
+	}
+	return streamingbatchwriter.UnimplementedDeleteStale{}.DeleteStale(ctx, msgs)
+}
+
 func (c *Client) Close(ctx context.Context) error {
 	return c.writer.Close(ctx)
 }
+
+func (c *Client) deltaTableRoot(table string) string {
+	return path.Join(c.spec.Path, table)
+}
@@ -45,6 +45,32 @@ func TestPlugin(t *testing.T) {
 	}
 }
 
+func TestPluginDelta(t *testing.T) {
+	ctx := context.Background()
+	p := plugin.NewPlugin("gcs", "development", New)
+	b, err := json.Marshal(&Spec{
+		Bucket:      bucket,
+		Path:        t.TempDir(),
+		FileSpec:    &filetypes.FileSpec{Format: filetypes.FormatTypeParquet},
+		TableFormat: TableFormatDelta,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Init(ctx, b, plugin.NewClientOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	plugin.TestWriterSuiteRunner(t,
+		p,
+		plugin.WriterTestSuiteTests{
+			SkipUpsert: true,
+			SafeMigrations: plugin.SafeMigrations{
+				AddColumn: true,
+			},
+		},
+	)
+}
+
 func testPlugin(t *testing.T, spec *Spec) {
 	ctx := context.Background()
 	p := plugin.NewPlugin("gcs", "development", New)
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"cloud.google.com/go/storage"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/delta"
+	"google.golang.org/api/googleapi"
+)
+
+// gcsDeltaStore stores the Delta Lake tables in the bucket.
+// Commits are created with a precondition that the object doesn't exist, so concurrent writers never overwrite each other.
+type gcsDeltaStore struct {
+	bucket *storage.BucketHandle
+}
+
+func (s *gcsDeltaStore) Get(ctx context.Context, key string) ([]byte, error) {
+	r, err := s.bucket.Object(key).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, delta.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func (s *gcsDeltaStore) Put(ctx context.Context, key string, r io.Reader) error {
+	// canceling the context aborts the upload, so no partial object is created on failure
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := s.bucket.Object(key).NewWriter(ctx)
+	if _, err := io.Copy(w, r); err != nil {
+		cancel()
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (s *gcsDeltaStore) PutIfAbsent(ctx context.Context, key string, data []byte) error {
+	w := s.bucket.Object(key).If(storage.Conditions{DoesNotExist: true}).NewWriter(ctx)
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return err
+	}
+	err := w.Close()
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed {
+		return delta.ErrObjectExists
+	}
+	return err
+}
+
+func (s *gcsDeltaStore) Delete(ctx context.Context, key string) error {
+	return s.bucket.Object(key).Delete(ctx)
+}
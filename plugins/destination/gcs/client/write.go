@@ -5,47 +5,103 @@ import (
 	"fmt"
 
 	"cloud.google.com/go/storage"
-	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/partition"
 	"github.com/cloudquery/filetypes/v4/types"
 	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/google/uuid"
 )
 
+type objectHandle struct {
+	w *storage.Writer
+	h types.Handle
+}
+
 func (c *Client) WriteTable(ctx context.Context, msgs <-chan *message.WriteInsert) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Write(ctx, msgs)
+	}
+
+	// canceling the context aborts the uploads, so no partial objects are created when writing fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	var (
-		w *storage.Writer
-		h types.Handle
+		handles []*objectHandle
+		byPath  = make(map[string]*objectHandle)
 	)
 
 	for msg := range msgs {
-		if w == nil {
-			table := msg.GetTable()
+		parts := partition.Split(c.spec.Path, msg.Record)
+		err := c.writePartitions(ctx, msg.GetTable(), parts, byPath, &handles)
+		partition.Release(parts)
+		if err != nil {
+			cancel()
+			closeObjectHandles(handles)
+			return err
+		}
+	}
 
-			name := fmt.Sprintf("%s/%s.%s.%s", c.spec.Path, table.Name, c.spec.Format, uuid.NewString())
+	for i, oh := range handles {
+		if err := oh.finish(); err != nil {
+			cancel()
+			closeObjectHandles(handles[i:])
+			return err
+		}
+	}
+	return nil
+}
+
+// writePartitions writes the partitions to the objects of their paths, opening the objects of new paths.
+func (c *Client) writePartitions(ctx context.Context, table *schema.Table, parts []partition.Partition, byPath map[string]*objectHandle, handles *[]*objectHandle) error {
+	for _, part := range parts {
+		oh := byPath[part.Path]
+		if oh == nil {
+			name := fmt.Sprintf("%s/%s.%s.%s", part.Path, table.Name, c.spec.Format, uuid.NewString())
 			if c.spec.NoRotate {
-				name = fmt.Sprintf("%s/%s.%s", c.spec.Path, table.Name, c.spec.Format)
+				name = fmt.Sprintf("%s/%s.%s", part.Path, table.Name, c.spec.Format)
 			}
 
-			w = c.gcsClient.Bucket(c.spec.Bucket).Object(name).NewWriter(ctx)
-
+			// the handle is kept before the header is written, so the writer is closed if that fails
+			oh = &objectHandle{w: c.gcsClient.Bucket(c.spec.Bucket).Object(name).NewWriter(ctx)}
+			*handles = append(*handles, oh)
 			var err error
-			h, err = c.Client.WriteHeader(w, table)
+			oh.h, err = c.Client.WriteHeader(oh.w, table)
 			if err != nil {
 				return err
 			}
+			byPath[part.Path] = oh
 		}
 
-		if err := h.WriteContent([]arrow.Record{msg.Record}); err != nil {
+		if err := oh.h.WriteContent(part.Records); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	if err := h.WriteFooter(); err != nil {
+// finish writes the footer and closes the writer, which completes the upload.
+func (oh *objectHandle) finish() error {
+	if err := oh.h.WriteFooter(); err != nil {
 		return err
 	}
-	return w.Close()
+	return oh.w.Close()
+}
+
+// closeObjectHandles closes the writers once their context is canceled, which discards the uploads.
+func closeObjectHandles(handles []*objectHandle) {
+	for _, oh := range handles {
+		_ = oh.w.Close()
+	}
 }
 
 func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) error {
-	return c.writer.Write(ctx, msgs)
+	if err := c.writer.Write(ctx, msgs); err != nil {
+		return err
+	}
+	if c.spec.TableFormat == TableFormatDelta {
+		// all batches are flushed by now, so the whole sync is committed at once
+		return c.delta.Commit(ctx)
+	}
+	return nil
 }
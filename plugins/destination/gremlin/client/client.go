@@ -14,6 +14,7 @@ import (
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/cloudquery/plugin-sdk/v4/writers/batchwriter"
 	"github.com/rs/zerolog"
 )
@@ -26,6 +27,9 @@ type Client struct {
 	mu     sync.Mutex // protects client during session creation
 	client *gremlingo.DriverRemoteConnection
 	writer *batchwriter.BatchWriter
+
+	tablesMu sync.RWMutex // protects tables
+	tables   map[string]*schema.Table
 }
 
 var AnonT = gremlingo.T__
@@ -33,6 +37,7 @@ var AnonT = gremlingo.T__
 func New(ctx context.Context, logger zerolog.Logger, spec []byte, _ plugin.NewClientOptions) (plugin.Client, error) {
 	c := &Client{
 		logger: logger.With().Str("module", "gremlin").Logger(),
+		tables: make(map[string]*schema.Table),
 	}
 	if err := json.Unmarshal(spec, &c.spec); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal gremlin spec: %w", err)
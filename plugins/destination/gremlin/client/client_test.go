@@ -7,8 +7,16 @@ import (
 	"runtime"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/cloudquery/plugin-sdk/v4/message"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,3 +67,111 @@ func TestPlugin(t *testing.T) {
 		},
 	)
 }
+
+func TestEdges(t *testing.T) {
+	ctx := context.Background()
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 10)
+	vpcs := &schema.Table{
+		Name: "test_edges_vpcs_" + suffix,
+		Columns: schema.ColumnList{
+			schema.CqSourceNameColumn, schema.CqSyncTimeColumn, schema.CqIDColumn,
+			{Name: "vpc_id", Type: arrow.BinaryTypes.String, PrimaryKey: true},
+		},
+	}
+	instances := &schema.Table{
+		Name: "test_edges_instances_" + suffix,
+		Columns: schema.ColumnList{
+			schema.CqSourceNameColumn, schema.CqSyncTimeColumn, schema.CqIDColumn,
+			{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true},
+			{Name: "vpc_id", Type: arrow.BinaryTypes.String},
+		},
+	}
+	volumes := &schema.Table{
+		Name: "test_edges_volumes_" + suffix,
+		Columns: schema.ColumnList{
+			schema.CqSourceNameColumn, schema.CqSyncTimeColumn, schema.CqIDColumn, schema.CqParentIDColumn,
+			{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true},
+		},
+	}
+
+	var client *Client
+	p := plugin.NewPlugin("gremlin", "development", func(ctx context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewClientOptions) (plugin.Client, error) {
+		c, err := New(ctx, logger, spec, opts)
+		if err != nil {
+			return nil, err
+		}
+		client = c.(*Client)
+		return c, nil
+	})
+	s := &Spec{
+		Endpoint:         getenv("CQ_DEST_GREMLIN_ENDPOINT", defaultGremlinEndpoint),
+		Username:         os.Getenv("CQ_DEST_GREMLIN_USERNAME"),
+		Password:         os.Getenv("CQ_DEST_GREMLIN_PASSWORD"),
+		VertexIDStrategy: vertexIDCQID,
+		Edges:            []EdgeSpec{{From: instances.Name + ".vpc_id", To: vpcs.Name + ".vpc_id", Label: "in_vpc"}},
+	}
+	s.SetDefaults()
+	require.NoError(t, s.Validate())
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.NoError(t, p.Init(ctx, b, plugin.NewClientOptions{}))
+
+	const (
+		source       = "test"
+		instanceCQID = "8f0d6f4e-1b0c-4bd8-9e0e-2a6c1a3d1f01"
+	)
+	syncTime := time.Now().UTC().Truncate(time.Microsecond)
+	insert := func(table *schema.Table, values ...string) *message.WriteInsert {
+		bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+		require.NoError(t, bldr.Field(0).AppendValueFromString(source))
+		bldr.Field(1).(*array.TimestampBuilder).Append(arrow.Timestamp(syncTime.UnixMicro()))
+		for i, v := range values {
+			require.NoError(t, bldr.Field(i+2).AppendValueFromString(v))
+		}
+		return &message.WriteInsert{Record: bldr.NewRecord()}
+	}
+	countEdges := func(table *schema.Table, label string) int64 {
+		session, closer, err := client.newSession()
+		require.NoError(t, err)
+		defer closer()
+		res, err := gremlingo.Traversal_().WithRemote(session).V().HasLabel(table.Name).OutE(label).Count().Next()
+		require.NoError(t, err)
+		n, err := res.GetInt64()
+		require.NoError(t, err)
+		return n
+	}
+
+	require.NoError(t, p.WriteAll(ctx, []message.WriteMessage{
+		&message.WriteMigrateTable{Table: vpcs},
+		&message.WriteMigrateTable{Table: instances},
+		&message.WriteMigrateTable{Table: volumes},
+	}))
+
+	// children and edge sources are written before the vertices they point to
+	require.NoError(t, p.WriteAll(ctx, []message.WriteMessage{
+		insert(volumes, "1e4c8a53-3c1c-4d6b-a3f4-7f0b9f3c7a01", instanceCQID, "vol-1"),
+		insert(volumes, "1e4c8a53-3c1c-4d6b-a3f4-7f0b9f3c7a02", instanceCQID, "vol-2"),
+	}))
+	require.NoError(t, p.WriteAll(ctx, []message.WriteMessage{
+		insert(instances, instanceCQID, "i-1", "vpc-1"),
+	}))
+	require.NoError(t, p.WriteAll(ctx, []message.WriteMessage{
+		insert(vpcs, "4b3f0c1e-9d2a-4f6e-8c7b-5a1d2e3f4a01", "vpc-1"),
+	}))
+	require.Equal(t, int64(2), countEdges(volumes, childOfLabel))
+	require.Equal(t, int64(1), countEdges(instances, "in_vpc"))
+
+	// writing the same rows again doesn't duplicate vertices or edges
+	require.NoError(t, p.WriteAll(ctx, []message.WriteMessage{
+		insert(instances, instanceCQID, "i-1", "vpc-1"),
+	}))
+	require.Equal(t, int64(1), countEdges(instances, "in_vpc"))
+
+	// the instance moved to another VPC, so the old edge is stale
+	syncTime = syncTime.Add(time.Second)
+	require.NoError(t, p.WriteAll(ctx, []message.WriteMessage{
+		insert(instances, instanceCQID, "i-1", "vpc-2"),
+		&message.WriteDeleteStale{TableName: instances.Name, SourceName: source, SyncTime: syncTime},
+	}))
+	require.Equal(t, int64(0), countEdges(instances, "in_vpc"))
+}
@@ -16,6 +16,19 @@ func (c *Client) DeleteStale(_ context.Context, msgs message.WriteDeleteStales)
 	defer closer()
 
 	for _, msg := range msgs {
+		// edges that weren't written in this sync, e.g. because the value they're matched on changed.
+		// Edges of stale vertices are dropped along with the vertices.
+		eg := gremlingo.Traversal_().WithRemote(session).
+			V().
+			HasLabel(msg.GetTable().Name).
+			OutE(c.outEdgeLabels(msg.GetTable().Name)...).
+			Has(schema.CqSourceNameColumn.Name, msg.SourceName).
+			Has(schema.CqSyncTimeColumn.Name, gremlingo.P.Lt(msg.SyncTime)).
+			SideEffect(AnonT.Drop())
+		if err := <-eg.Iterate(); err != nil {
+			return err
+		}
+
 		g := gremlingo.Traversal_().WithRemote(session).
 			V().
 			HasLabel(msg.GetTable().Name).
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// childOfLabel is the label of the edges from child table vertices to their parent vertices
+const childOfLabel = "child_of"
+
+// edgeTraversal returns the traversal adding the edges of the written rows, or nil if there are none.
+// Edges are matched by the shared value on both ends, so they're added whichever of the two tables is written first.
+func (c *Client) edgeTraversal(session *gremlingo.DriverRemoteConnection, table *schema.Table, rows []map[string]any) *gremlingo.GraphTraversal {
+	var edges []*gremlingo.GraphTraversal
+
+	// the other end of a child_of edge can be any migrated table with the matching column
+	if table.Columns.Get(schema.CqParentIDColumn.Name) != nil {
+		if parents := c.labelsWithColumn(schema.CqIDColumn.Name); len(parents) > 0 {
+			for _, v := range distinctValues(rows, schema.CqParentIDColumn.Name) {
+				edges = append(edges, upsertEdge(childOfLabel, []any{table.Name}, schema.CqParentIDColumn.Name, parents, schema.CqIDColumn.Name, v))
+			}
+		}
+	}
+	if table.Columns.Get(schema.CqIDColumn.Name) != nil {
+		if children := c.labelsWithColumn(schema.CqParentIDColumn.Name); len(children) > 0 {
+			for _, v := range distinctValues(rows, schema.CqIDColumn.Name) {
+				edges = append(edges, upsertEdge(childOfLabel, children, schema.CqParentIDColumn.Name, []any{table.Name}, schema.CqIDColumn.Name, v))
+			}
+		}
+	}
+
+	for _, e := range c.spec.Edges {
+		fromTable, fromColumn, toTable, toColumn := e.endpoints()
+		if fromTable == table.Name {
+			for _, v := range distinctValues(rows, fromColumn) {
+				edges = append(edges, upsertEdge(e.Label, []any{fromTable}, fromColumn, []any{toTable}, toColumn, v))
+			}
+		}
+		if toTable == table.Name {
+			for _, v := range distinctValues(rows, toColumn) {
+				edges = append(edges, upsertEdge(e.Label, []any{fromTable}, fromColumn, []any{toTable}, toColumn, v))
+			}
+		}
+	}
+
+	if len(edges) == 0 {
+		return nil
+	}
+	// side effects keep the traversal going when an edge has no vertices to connect yet
+	g := gremlingo.Traversal_().WithRemote(session).Inject(0)
+	for _, e := range edges {
+		g = g.SideEffect(e)
+	}
+	return g
+}
+
+// upsertEdge adds an edge from every vertex with one of the from labels and the value in the from column to every
+// vertex with one of the to labels and the value in the to column, unless it already exists. The edge gets the
+// source name and sync time of the row, so it can be removed by DeleteStale when it isn't written in a later sync.
+func upsertEdge(label string, fromLabels []any, fromColumn string, toLabels []any, toColumn string, v edgeValue) *gremlingo.GraphTraversal {
+	t := AnonT.V().HasLabel(toLabels...).Has(toColumn, v.value).As("to").
+		V().HasLabel(fromLabels...).Has(fromColumn, v.value).Coalesce(
+		AnonT.OutE(label).Where(AnonT.InV().As("to")),
+		AnonT.AddE(label).To("to"),
+	)
+	for _, col := range []string{schema.CqSourceNameColumn.Name, schema.CqSyncTimeColumn.Name} {
+		if val := v.row[col]; val != nil {
+			t = t.Property(col, val)
+		}
+	}
+	return t
+}
+
+// labelsWithColumn returns the names of the migrated tables with the column, which are the labels of their vertices.
+func (c *Client) labelsWithColumn(column string) []any {
+	c.tablesMu.RLock()
+	defer c.tablesMu.RUnlock()
+
+	names := make([]string, 0, len(c.tables))
+	for name, table := range c.tables {
+		if table.Columns.Get(column) != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	labels := make([]any, len(names))
+	for i, name := range names {
+		labels[i] = name
+	}
+	return labels
+}
+
+// outEdgeLabels returns the labels of the edges starting at the vertices of the table.
+func (c *Client) outEdgeLabels(tableName string) []any {
+	labels := []any{childOfLabel}
+	for _, e := range c.spec.Edges {
+		if fromTable, _, _, _ := e.endpoints(); fromTable == tableName {
+			labels = append(labels, e.Label)
+		}
+	}
+	return labels
+}
+
+type edgeValue struct {
+	value any
+	// row is the first row with the value
+	row map[string]any
+}
+
+func distinctValues(rows []map[string]any, column string) []edgeValue {
+	seen := make(map[string]struct{}, len(rows))
+	values := make([]edgeValue, 0, len(rows))
+	for _, row := range rows {
+		v, ok := row[column]
+		if !ok || v == nil {
+			continue
+		}
+		key := fmt.Sprintf("%T:%v", v, v)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		values = append(values, edgeValue{value: v, row: row})
+	}
+	return values
+}
@@ -6,7 +6,14 @@ import (
 	"github.com/cloudquery/plugin-sdk/v4/message"
 )
 
-// Migrate tables. Like neo4j, gremlin does not have a schema, so this is a no-op.
-func (*Client) MigrateTables(context.Context, message.WriteMigrateTables) error {
+// Migrate tables. Like neo4j, gremlin does not have a schema, so the tables are only kept to name the vertex labels
+// the edges are looked up by.
+func (c *Client) MigrateTables(_ context.Context, msgs message.WriteMigrateTables) error {
+	c.tablesMu.Lock()
+	defer c.tablesMu.Unlock()
+
+	for _, msg := range msgs {
+		c.tables[msg.Table.Name] = msg.Table
+	}
 	return nil
 }
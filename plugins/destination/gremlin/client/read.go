@@ -16,11 +16,16 @@ func (c *Client) Read(_ context.Context, table *schema.Table, res chan<- arrow.R
 	}
 	defer closer()
 
+	columns := make([]any, len(table.Columns))
+	for i, col := range table.Columns {
+		columns[i] = col.Name
+	}
+
 	g := gremlingo.Traversal_().WithRemote(session).
 		V().
 		HasLabel(table.Name).
 		Group().By(gremlingo.T.Id).
-		By(AnonT.ValueMap())
+		By(AnonT.ValueMap(columns...))
 
 	rs, err := g.GetResultSet()
 	if err != nil {
@@ -2,10 +2,13 @@ package client
 
 import (
 	"fmt"
+	"regexp"
 	"runtime"
 	"strings"
 )
 
+var reEdgeLabel = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 type Spec struct {
 	Endpoint string `json:"endpoint"`
 	Insecure bool   `json:"insecure"`
@@ -30,8 +33,53 @@ type Spec struct {
 
 	BatchSize      int `json:"batch_size"`
 	BatchSizeBytes int `json:"batch_size_bytes"`
+
+	// How vertex IDs are assigned
+	VertexIDStrategy vertexIDStrategy `json:"vertex_id_strategy"`
+
+	// Edges between vertices of different tables, in addition to the parent/child edges
+	Edges []EdgeSpec `json:"edges"`
+}
+
+// EdgeSpec declares edges between the vertices of two tables that have the same value in the given columns,
+// e.g. `{from: aws_ec2_instances.vpc_id, to: aws_ec2_vpcs.vpc_id, label: in_vpc}`.
+type EdgeSpec struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Label string `json:"label"`
+}
+
+// endpoints returns the tables and columns of the edge. It assumes the spec was validated.
+func (e EdgeSpec) endpoints() (fromTable, fromColumn, toTable, toColumn string) {
+	fromTable, fromColumn, _ = strings.Cut(e.From, ".")
+	toTable, toColumn, _ = strings.Cut(e.To, ".")
+	return fromTable, fromColumn, toTable, toColumn
+}
+
+func (e EdgeSpec) Validate() error {
+	for field, v := range map[string]string{"from": e.From, "to": e.To} {
+		table, column, ok := strings.Cut(v, ".")
+		if !ok || table == "" || column == "" || strings.Contains(column, ".") {
+			return fmt.Errorf("edge %s %q must be in the form table.column", field, v)
+		}
+	}
+	if !reEdgeLabel.MatchString(e.Label) {
+		return fmt.Errorf("edge label %q must only contain letters, digits and underscores", e.Label)
+	}
+	return nil
 }
 
+type vertexIDStrategy string
+
+const (
+	// vertexIDNone lets the server assign vertex IDs, vertices are looked up by their primary key properties
+	vertexIDNone = vertexIDStrategy("none")
+	// vertexIDPKHash uses a hash of the table name and primary key values as the vertex ID
+	vertexIDPKHash = vertexIDStrategy("pk_hash")
+	// vertexIDCQID uses the _cq_id column as the vertex ID
+	vertexIDCQID = vertexIDStrategy("cq_id")
+)
+
 type authMode string
 
 const (
@@ -69,6 +117,10 @@ func (s *Spec) SetDefaults() {
 		s.MaxConcurrentConnections = runtime.NumCPU()
 	}
 
+	if s.VertexIDStrategy == "" {
+		s.VertexIDStrategy = vertexIDNone
+	}
+
 	if s.BatchSize == 0 {
 		s.BatchSize = 200
 	}
@@ -90,6 +142,16 @@ func (s *Spec) Validate() error {
 	if s.AuthMode == authModeNone && (s.Username != "" || s.Password != "") {
 		return fmt.Errorf("username or password specified with auth_mode %q. Set auth mode to %q or remove username and password", authModeNone, authModeBasic)
 	}
+	switch s.VertexIDStrategy {
+	case "", vertexIDNone, vertexIDPKHash, vertexIDCQID:
+	default:
+		return fmt.Errorf("invalid vertex_id_strategy, valid values are %q, %q and %q", vertexIDNone, vertexIDPKHash, vertexIDCQID)
+	}
+	for _, e := range s.Edges {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"time"
@@ -41,6 +43,11 @@ func (c *Client) WriteTableBatch(ctx context.Context, tableName string, msgs mes
 		rows = append(rows, c.transformValues(msgs[i].Record, cqTimeIndex)...)
 	}
 
+	ids, err := c.vertexIDs(table, msgs)
+	if err != nil {
+		return err
+	}
+
 	pks := table.PrimaryKeys()
 	if len(pks) == 0 {
 		// If no primary keys are defined, use all columns
@@ -55,8 +62,21 @@ func (c *Client) WriteTableBatch(ctx context.Context, tableName string, msgs mes
 		}
 	}
 
-	g := gremlingo.Traversal_().WithRemote(session).V().HasLabel(tableName)
+	// every row starts with a mid-traversal V() step, so each one is looked up independently of the previous rows
+	g := gremlingo.Traversal_().WithRemote(session).Inject(0)
 	for i := range rows {
+		if ids != nil {
+			g = g.V(ids[i]).Fold().Coalesce(
+				AnonT.Unfold(),
+				AnonT.AddV(table.Name).Property(gremlingo.T.Id, ids[i]),
+			)
+			for _, col := range table.Columns {
+				g = g.Property(gremlingo.Cardinality.Single, col.Name, rows[i][col.Name])
+			}
+			continue
+		}
+
+		g = g.V().HasLabel(tableName)
 		for _, colName := range pks {
 			g = g.Has(colName, rows[i][colName])
 		}
@@ -76,6 +96,22 @@ func (c *Client) WriteTableBatch(ctx context.Context, tableName string, msgs mes
 		}
 	}
 
+	if err := c.iterate(ctx, g); err != nil {
+		return err
+	}
+
+	// edges are added once all vertices of the batch exist
+	if eg := c.edgeTraversal(session, table, rows); eg != nil {
+		if err := c.iterate(ctx, eg); err != nil {
+			return fmt.Errorf("failed to add edges: %w", err)
+		}
+	}
+	return nil
+}
+
+// iterate runs the traversal, retrying on concurrent modification errors.
+func (c *Client) iterate(ctx context.Context, g *gremlingo.GraphTraversal) error {
+	var err error
 	bo := backoff.NewExponentialBackOff()
 	retryCount := 0
 
@@ -108,6 +144,51 @@ func (c *Client) WriteTableBatch(ctx context.Context, tableName string, msgs mes
 	return fmt.Errorf("Max retries (%d) reached. Iterate: %w", c.spec.MaxRetries, err)
 }
 
+// vertexIDs returns the IDs of the vertices of the rows, or nil if the server assigns them.
+func (c *Client) vertexIDs(table *schema.Table, msgs message.WriteInserts) ([]string, error) {
+	var idx []int
+	switch c.spec.VertexIDStrategy {
+	case vertexIDPKHash:
+		pks := table.PrimaryKeys()
+		if len(pks) == 0 {
+			pks = table.Columns.Names()
+		}
+		for _, pk := range pks {
+			idx = append(idx, table.Columns.Index(pk))
+		}
+	case vertexIDCQID:
+		i := table.Columns.Index(schema.CqIDColumn.Name)
+		if i == -1 {
+			return nil, fmt.Errorf("table %s has no %s column, required by vertex_id_strategy %q", table.Name, schema.CqIDColumn.Name, vertexIDCQID)
+		}
+		idx = []int{i}
+	default:
+		return nil, nil
+	}
+
+	var ids []string
+	for _, msg := range msgs {
+		for r := 0; r < int(msg.Record.NumRows()); r++ {
+			if c.spec.VertexIDStrategy == vertexIDCQID {
+				col := msg.Record.Column(idx[0])
+				if col.IsNull(r) {
+					return nil, fmt.Errorf("table %s has a null %s value", table.Name, schema.CqIDColumn.Name)
+				}
+				ids = append(ids, col.ValueStr(r))
+				continue
+			}
+			h := sha256.New()
+			h.Write([]byte(table.Name))
+			for _, i := range idx {
+				h.Write([]byte{0})
+				h.Write([]byte(msg.Record.Column(i).ValueStr(r)))
+			}
+			ids = append(ids, hex.EncodeToString(h.Sum(nil)[:16]))
+		}
+	}
+	return ids, nil
+}
+
 func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) error {
 	if err := c.writer.Write(ctx, msgs); err != nil {
 		return err
@@ -6,7 +6,6 @@ import (
 
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/meilisearch/meilisearch-go"
-	"golang.org/x/exp/slices"
 )
 
 type indexSchema struct {
@@ -14,6 +13,8 @@ type indexSchema struct {
 	PrimaryKey string
 	Attributes []string
 	Index      int
+	// Settings are the wanted settings of the index
+	Settings TableSettings
 }
 
 func (i *indexSchema) init(index *meilisearch.Index) (*indexSchema, error) {
@@ -35,18 +36,19 @@ func (i *indexSchema) canMigrate(o *indexSchema) bool {
 	return i.UID == o.UID && i.PrimaryKey == o.PrimaryKey
 }
 
-func tableIndexSchema(table *schema.Table) *indexSchema {
+func tableIndexSchema(table *schema.Table, settings TableSettings) *indexSchema {
 	return &indexSchema{
 		UID:        table.Name,
 		PrimaryKey: hashColumnName,
 		Attributes: table.Columns.Names(),
+		Settings:   settings,
 	}
 }
 
-func tablesIndexSchemas(tables schema.Tables) map[string]*indexSchema {
+func (c *Client) tablesIndexSchemas(tables schema.Tables) map[string]*indexSchema {
 	res := make(map[string]*indexSchema)
 	for i, table := range tables {
-		s := tableIndexSchema(table)
+		s := tableIndexSchema(table, c.spec.tableSettings(table.Name))
 		s.Index = i
 		res[s.UID] = s
 	}
@@ -89,45 +91,24 @@ func (c *Client) configureIndex(ctx context.Context, s *indexSchema) error {
 		return err
 	}
 
-	attributes := slices.Clone(s.Attributes)
-
-	attrs, err := index.GetFilterableAttributes()
+	current, err := index.GetSettings()
 	if err != nil {
 		return err
 	}
 
-	var current []string
-	if attrs != nil {
-		current = *attrs
-	}
-
-	if len(current) > 0 {
-		attributes = append(attributes, current...)
-		slices.Sort(attributes)
-		attributes = slices.Compact(attributes)
-		if len(attributes) == len(s.Attributes) {
-			// already the same filtered attributes, skip
-			c.logger.Info().Str("index", index.UID).Msg("index is already properly configured, skip")
-			return nil
-		}
-	}
-
-	taskInfo, err := index.UpdateFilterableAttributes(&attributes)
-	if err != nil {
-		return err
-	}
-
-	if err := c.waitTask(ctx, taskInfo); err != nil {
-		return fmt.Errorf("failed to update filterable attributes for index %q: %w", index.UID, err)
+	update := s.settingsUpdate(current)
+	if update == nil {
+		c.logger.Info().Str("index", index.UID).Msg("index is already properly configured, skip")
+		return nil
 	}
 
-	taskInfo, err = index.UpdateSortableAttributes(&attributes)
+	taskInfo, err := index.UpdateSettings(update)
 	if err != nil {
 		return err
 	}
 
 	if err := c.waitTask(ctx, taskInfo); err != nil {
-		return fmt.Errorf("failed to update sortable attributes for index %q: %w", index.UID, err)
+		return fmt.Errorf("failed to update settings for index %q: %w", index.UID, err)
 	}
 
 	return nil
@@ -20,7 +20,7 @@ func (c *Client) MigrateTables(ctx context.Context, messages message.WriteMigrat
 		tables[i] = msg.Table
 	}
 
-	want := tablesIndexSchemas(tables)
+	want := c.tablesIndexSchemas(tables)
 
 	var recreate, create, update []*indexSchema
 	for uid, need := range want {
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"github.com/meilisearch/meilisearch-go"
+	"golang.org/x/exp/slices"
+)
+
+// defaultFilterableAttributes are always filterable if the table has them, so that the resources can be searched by them.
+var defaultFilterableAttributes = []string{"account_id", "region", "_cq_source_name"}
+
+// settingsUpdate returns the settings of the index that differ from the current ones, or nil if none do.
+func (i *indexSchema) settingsUpdate(current *meilisearch.Settings) *meilisearch.Settings {
+	if current == nil {
+		current = new(meilisearch.Settings)
+	}
+	settings := i.Settings
+	update := new(meilisearch.Settings)
+	changed := false
+
+	filterable := settings.FilterableAttributes
+	if len(filterable) == 0 {
+		// keep the current attributes, so that the columns removed from the table can still be filtered by
+		filterable = append(slices.Clone(i.Attributes), current.FilterableAttributes...)
+	} else {
+		filterable = slices.Clone(filterable)
+		for _, attr := range defaultFilterableAttributes {
+			if slices.Contains(i.Attributes, attr) {
+				filterable = append(filterable, attr)
+			}
+		}
+	}
+	if filterable = sortedSet(filterable); !slices.Equal(filterable, sortedSet(current.FilterableAttributes)) {
+		update.FilterableAttributes = filterable
+		changed = true
+	}
+
+	sortable := settings.SortableAttributes
+	if len(sortable) == 0 {
+		sortable = append(slices.Clone(i.Attributes), current.SortableAttributes...)
+	}
+	if sortable = sortedSet(sortable); !slices.Equal(sortable, sortedSet(current.SortableAttributes)) {
+		update.SortableAttributes = sortable
+		changed = true
+	}
+
+	// the order of the searchable attributes and the ranking rules sets their priority
+	if len(settings.SearchableAttributes) > 0 && !slices.Equal(settings.SearchableAttributes, current.SearchableAttributes) {
+		update.SearchableAttributes = settings.SearchableAttributes
+		changed = true
+	}
+	if len(settings.RankingRules) > 0 && !slices.Equal(settings.RankingRules, current.RankingRules) {
+		update.RankingRules = settings.RankingRules
+		changed = true
+	}
+
+	if settings.TypoTolerance != nil {
+		if typo := typoToleranceUpdate(current.TypoTolerance, settings.TypoTolerance); typo != nil {
+			update.TypoTolerance = typo
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return update
+}
+
+// defaultTypoTolerance is the typo tolerance of new Meilisearch indexes.
+var defaultTypoTolerance = meilisearch.TypoTolerance{
+	Enabled:             true,
+	MinWordSizeForTypos: meilisearch.MinWordSizeForTypos{OneTypo: 5, TwoTypos: 9},
+}
+
+// typoToleranceUpdate returns the current typo tolerance with the set options applied, or nil if it's unchanged.
+// Without the current typo tolerance, the options are applied to Meilisearch's defaults, so the update doesn't disable it.
+func typoToleranceUpdate(current *meilisearch.TypoTolerance, want *TypoTolerance) *meilisearch.TypoTolerance {
+	have := defaultTypoTolerance
+	if current != nil {
+		have = *current
+	}
+	update := have
+	if want.MinWordSizeForOneTypo > 0 {
+		update.MinWordSizeForTypos.OneTypo = want.MinWordSizeForOneTypo
+	}
+	if want.MinWordSizeForTwoTypos > 0 {
+		update.MinWordSizeForTypos.TwoTypos = want.MinWordSizeForTwoTypos
+	}
+	if want.DisableOnWords != nil {
+		update.DisableOnWords = want.DisableOnWords
+	}
+	if want.DisableOnAttributes != nil {
+		update.DisableOnAttributes = want.DisableOnAttributes
+	}
+
+	if update.MinWordSizeForTypos == have.MinWordSizeForTypos &&
+		slices.Equal(sortedSet(update.DisableOnWords), sortedSet(have.DisableOnWords)) &&
+		slices.Equal(sortedSet(update.DisableOnAttributes), sortedSet(have.DisableOnAttributes)) {
+		return nil
+	}
+	return &update
+}
+
+// sortedSet returns the sorted unique values.
+func sortedSet(values []string) []string {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return slices.Compact(values)
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/meilisearch/meilisearch-go"
+)
+
+func TestSettingsUpdate(t *testing.T) {
+	attributes := []string{"_cq_id", "_cq_source_name", "account_id", "name", "region"}
+	cases := []struct {
+		name     string
+		settings TableSettings
+		current  *meilisearch.Settings
+		want     *meilisearch.Settings
+	}{
+		{
+			name:    "new index defaults to all columns",
+			current: &meilisearch.Settings{},
+			want: &meilisearch.Settings{
+				FilterableAttributes: attributes,
+				SortableAttributes:   attributes,
+			},
+		},
+		{
+			name: "up to date",
+			current: &meilisearch.Settings{
+				FilterableAttributes: []string{"region", "name", "account_id", "_cq_source_name", "_cq_id"},
+				SortableAttributes:   attributes,
+			},
+		},
+		{
+			name: "removed columns are kept",
+			current: &meilisearch.Settings{
+				FilterableAttributes: append([]string{"removed"}, attributes...),
+				SortableAttributes:   append([]string{"removed"}, attributes...),
+			},
+		},
+		{
+			name: "configured attributes include the default filterable ones",
+			settings: TableSettings{
+				SearchableAttributes: []string{"name", "region"},
+				FilterableAttributes: []string{"name"},
+				SortableAttributes:   []string{"name"},
+				RankingRules:         []string{"words", "sort"},
+			},
+			current: &meilisearch.Settings{
+				SearchableAttributes: []string{"*"},
+				FilterableAttributes: attributes,
+				SortableAttributes:   attributes,
+				RankingRules:         []string{"words", "typo", "proximity", "attribute", "sort", "exactness"},
+			},
+			want: &meilisearch.Settings{
+				SearchableAttributes: []string{"name", "region"},
+				FilterableAttributes: []string{"_cq_source_name", "account_id", "name", "region"},
+				SortableAttributes:   []string{"name"},
+				RankingRules:         []string{"words", "sort"},
+			},
+		},
+		{
+			name: "searchable attributes order is kept",
+			settings: TableSettings{
+				SearchableAttributes: []string{"region", "name"},
+			},
+			current: &meilisearch.Settings{
+				SearchableAttributes: []string{"name", "region"},
+				FilterableAttributes: attributes,
+				SortableAttributes:   attributes,
+			},
+			want: &meilisearch.Settings{
+				SearchableAttributes: []string{"region", "name"},
+			},
+		},
+		{
+			name: "typo tolerance",
+			settings: TableSettings{
+				TypoTolerance: &TypoTolerance{MinWordSizeForOneTypo: 4, DisableOnAttributes: []string{"account_id"}},
+			},
+			current: &meilisearch.Settings{
+				FilterableAttributes: attributes,
+				SortableAttributes:   attributes,
+				TypoTolerance: &meilisearch.TypoTolerance{
+					Enabled:             true,
+					MinWordSizeForTypos: meilisearch.MinWordSizeForTypos{OneTypo: 5, TwoTypos: 9},
+				},
+			},
+			want: &meilisearch.Settings{
+				TypoTolerance: &meilisearch.TypoTolerance{
+					Enabled:             true,
+					MinWordSizeForTypos: meilisearch.MinWordSizeForTypos{OneTypo: 4, TwoTypos: 9},
+					DisableOnAttributes: []string{"account_id"},
+				},
+			},
+		},
+		{
+			name: "typo tolerance up to date",
+			settings: TableSettings{
+				TypoTolerance: &TypoTolerance{MinWordSizeForTwoTypos: 9},
+			},
+			current: &meilisearch.Settings{
+				FilterableAttributes: attributes,
+				SortableAttributes:   attributes,
+				TypoTolerance: &meilisearch.TypoTolerance{
+					Enabled:             true,
+					MinWordSizeForTypos: meilisearch.MinWordSizeForTypos{OneTypo: 5, TwoTypos: 9},
+				},
+			},
+		},
+		{
+			name: "typo tolerance of a new index",
+			settings: TableSettings{
+				TypoTolerance: &TypoTolerance{DisableOnWords: []string{"aws"}},
+			},
+			current: &meilisearch.Settings{
+				FilterableAttributes: attributes,
+				SortableAttributes:   attributes,
+			},
+			want: &meilisearch.Settings{
+				TypoTolerance: &meilisearch.TypoTolerance{
+					Enabled:             true,
+					MinWordSizeForTypos: meilisearch.MinWordSizeForTypos{OneTypo: 5, TwoTypos: 9},
+					DisableOnWords:      []string{"aws"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &indexSchema{UID: "test", PrimaryKey: hashColumnName, Attributes: attributes, Settings: tc.settings}
+			got := s.settingsUpdate(tc.current)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/cloudquery/plugin-sdk/v4/configtype"
+	"github.com/cloudquery/plugin-sdk/v4/glob"
 	"github.com/meilisearch/meilisearch-go"
 	"github.com/valyala/fasthttp"
 )
@@ -23,6 +24,29 @@ type Spec struct {
 	BatchSize      int                  `json:"batch_size,omitempty"`
 	BatchSizeBytes int                  `json:"batch_size_bytes,omitempty"`
 	BatchTimeout   *configtype.Duration `json:"batch_timeout,omitempty"`
+
+	// Tables are the index settings of the tables
+	Tables []TableSettings `json:"tables,omitempty"`
+}
+
+// TableSettings sets the index settings of the tables matching any of the patterns.
+// Unset settings are left as they are, except for the filterable and sortable attributes, which default to all columns.
+type TableSettings struct {
+	Tables []string `json:"tables,omitempty"`
+
+	SearchableAttributes []string `json:"searchable_attributes,omitempty"`
+	// FilterableAttributes always include the defaultFilterableAttributes the table has
+	FilterableAttributes []string       `json:"filterable_attributes,omitempty"`
+	SortableAttributes   []string       `json:"sortable_attributes,omitempty"`
+	RankingRules         []string       `json:"ranking_rules,omitempty"`
+	TypoTolerance        *TypoTolerance `json:"typo_tolerance,omitempty"`
+}
+
+type TypoTolerance struct {
+	MinWordSizeForOneTypo  int64    `json:"min_word_size_for_one_typo,omitempty"`
+	MinWordSizeForTwoTypos int64    `json:"min_word_size_for_two_typos,omitempty"`
+	DisableOnWords         []string `json:"disable_on_words,omitempty"`
+	DisableOnAttributes    []string `json:"disable_on_attributes,omitempty"`
 }
 
 func (s *Spec) validate() error {
@@ -31,9 +55,31 @@ func (s *Spec) validate() error {
 		return fmt.Errorf("empty \"host\" value")
 	case len(s.APIKey) == 0:
 		return fmt.Errorf("empty \"api_key\" value")
-	default:
-		return nil
 	}
+
+	for i, t := range s.Tables {
+		if len(t.Tables) == 0 {
+			return fmt.Errorf("empty \"tables[%d].tables\" value", i)
+		}
+		if typo := t.TypoTolerance; typo != nil && typo.MinWordSizeForOneTypo > 0 && typo.MinWordSizeForTwoTypos > 0 &&
+			typo.MinWordSizeForOneTypo > typo.MinWordSizeForTwoTypos {
+			return fmt.Errorf("\"tables[%d].typo_tolerance.min_word_size_for_one_typo\" must not be greater than \"min_word_size_for_two_typos\"", i)
+		}
+	}
+	return nil
+}
+
+// tableSettings returns the settings of the first `tables` entry matching the table.
+// The indexes of the tables matching no entry only get all their columns as filterable and sortable attributes.
+func (s *Spec) tableSettings(table string) TableSettings {
+	for _, t := range s.Tables {
+		for _, pattern := range t.Tables {
+			if glob.Glob(pattern, table) {
+				return t
+			}
+		}
+	}
+	return TableSettings{}
 }
 
 func (s *Spec) setDefaults() {
@@ -31,6 +31,7 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, _ plugin.NewCl
 	if err := c.spec.Validate(); err != nil {
 		return nil, err
 	}
+	c.spec.setDocumentDefaults()
 	c.client, err = mongo.NewClient(options.Client().ApplyURI(c.spec.ConnectionString).SetRegistry(getRegistry()))
 	if err != nil {
 		return nil, err
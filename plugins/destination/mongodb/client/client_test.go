@@ -7,8 +7,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
 	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/types"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func getTestConnection() string {
@@ -20,12 +26,24 @@ func getTestConnection() string {
 }
 
 func TestPlugin(t *testing.T) {
-	ctx := context.Background()
-	p := plugin.NewPlugin("mongodb", "development", New)
-	s := &Spec{
+	testPlugin(t, &Spec{
 		ConnectionString: getTestConnection(),
 		Database:         "destination_mongodb_test",
-	}
+	})
+}
+
+func TestPluginNative(t *testing.T) {
+	testPlugin(t, &Spec{
+		ConnectionString: getTestConnection(),
+		Database:         "destination_mongodb_native_test",
+		DocumentMode:     documentModeNative,
+		Validation:       validationStrict,
+	})
+}
+
+func testPlugin(t *testing.T, s *Spec) {
+	ctx := context.Background()
+	p := plugin.NewPlugin("mongodb", "development", New)
 	b, err := json.Marshal(s)
 	if err != nil {
 		t.Fatal(err)
@@ -43,3 +61,54 @@ func TestPlugin(t *testing.T) {
 		}),
 	)
 }
+
+func TestTransformArrNative(t *testing.T) {
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, arrow.NewSchema([]arrow.Field{
+		{Name: "json", Type: types.ExtensionTypes.JSON, Nullable: true},
+		{Name: "struct", Type: arrow.StructOf(arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int64, Nullable: true}), Nullable: true},
+		{Name: "map", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32), Nullable: true},
+		{Name: "list", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+	}, nil))
+	defer bldr.Release()
+	bldr.Field(0).(*types.JSONBuilder).Append(map[string]any{"a": 1, "b": []any{1.5, "c"}})
+	sb := bldr.Field(1).(*array.StructBuilder)
+	sb.Append(true)
+	sb.FieldBuilder(0).(*array.Int64Builder).Append(2)
+	mb := bldr.Field(2).(*array.MapBuilder)
+	mb.Append(true)
+	mb.KeyBuilder().(*array.StringBuilder).Append("k")
+	mb.ItemBuilder().(*array.Int32Builder).Append(3)
+	lb := bldr.Field(3).(*array.ListBuilder)
+	lb.Append(true)
+	lb.ValueBuilder().(*array.StringBuilder).Append("v")
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	require.Equal(t, []any{bson.M{"a": int64(1), "b": bson.A{1.5, "c"}}}, transformArrNative(rec.Column(0)))
+	require.Equal(t, []any{bson.D{{Key: "a", Value: int64(2)}}}, transformArrNative(rec.Column(1)))
+	require.Equal(t, []any{bson.D{{Key: "k", Value: int32(3)}}}, transformArrNative(rec.Column(2)))
+	require.Equal(t, []any{bson.A{"v"}}, transformArrNative(rec.Column(3)))
+	require.Equal(t, `{"a":`, nativeJSON(`{"a":`))
+}
+
+func TestJSONSchema(t *testing.T) {
+	table := &schema.Table{
+		Name: "test",
+		Columns: schema.ColumnList{
+			{Name: "id", Type: arrow.PrimitiveTypes.Int64, NotNull: true},
+			{Name: "tags", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)},
+		},
+	}
+	c := &Client{spec: &Spec{DocumentMode: documentModeNative}}
+	require.Equal(t, bson.M{
+		"bsonType": "object",
+		"properties": bson.M{
+			"id":   bson.M{"bsonType": "long"},
+			"tags": bson.M{"bsonType": bson.A{"object", "null"}, "additionalProperties": bson.M{"bsonType": bson.A{"string", "null"}}},
+		},
+		"required": bson.A{"id"},
+	}, c.jsonSchema(table))
+
+	c.spec.DocumentMode = documentModeJSON
+	require.Equal(t, bson.M{"bsonType": bson.A{"array", "null"}}, c.jsonSchema(table)["properties"].(bson.M)["tags"])
+}
@@ -22,6 +22,9 @@ func (c *Client) MigrateTables(ctx context.Context, msgs message.WriteMigrateTab
 
 func (c *Client) migrateTable(ctx context.Context, force bool, table *schema.Table) error {
 	tableName := table.Name
+	if err := c.ensureCollection(ctx, force, table); err != nil {
+		return err
+	}
 	for _, mdl := range c.getIndexTemplates(table) {
 		res, err := c.client.Database(c.spec.Database).Collection(tableName).Indexes().CreateOne(ctx, mdl)
 		switch {
@@ -42,6 +45,75 @@ func (c *Client) migrateTable(ctx context.Context, force bool, table *schema.Tab
 	return nil
 }
 
+// ensureCollection creates the collection of the table, as a time-series collection if configured,
+// and applies the validator to existing collections.
+func (c *Client) ensureCollection(ctx context.Context, force bool, table *schema.Table) error {
+	tableName := table.Name
+	db := c.client.Database(c.spec.Database)
+	specs, err := db.ListCollectionSpecifications(ctx, bson.M{"name": tableName})
+	if err != nil {
+		return fmt.Errorf("list collections: %w", err)
+	}
+
+	timeSeries := c.isTimeSeries(table)
+	if len(specs) > 0 {
+		if !timeSeries || specs[0].Type == "timeseries" {
+			return c.updateValidator(ctx, table)
+		}
+		if !force {
+			return fmt.Errorf("collection %s requires forced migration to become a time-series collection. Migrate manually or consider using 'migrate_mode: forced'", tableName)
+		}
+		if err := db.Collection(tableName).Drop(ctx); err != nil {
+			return fmt.Errorf("drop collection %s: %w", tableName, err)
+		}
+		c.logger.Debug().Str("table", tableName).Msg("dropped collection to recreate it as a time-series collection")
+	}
+
+	opts := options.CreateCollection()
+	if timeSeries {
+		tsOpts := options.TimeSeries().SetTimeField(schema.CqSyncTimeColumn.Name)
+		if c.spec.TimeSeries.Granularity != "" {
+			tsOpts.SetGranularity(c.spec.TimeSeries.Granularity)
+		}
+		opts.SetTimeSeriesOptions(tsOpts)
+		if c.spec.TimeSeries.ExpireAfterSeconds > 0 {
+			opts.SetExpireAfterSeconds(c.spec.TimeSeries.ExpireAfterSeconds)
+		}
+	} else if c.spec.Validation != validationOff {
+		opts.SetValidator(bson.M{"$jsonSchema": c.jsonSchema(table)}).SetValidationLevel(string(c.spec.Validation))
+	}
+	if err := db.CreateCollection(ctx, tableName, opts); err != nil {
+		return fmt.Errorf("create collection %s: %w", tableName, err)
+	}
+	c.logger.Debug().Str("table", tableName).Bool("time_series", timeSeries).Msg("created collection")
+	return nil
+}
+
+// updateValidator replaces the validator of an existing collection with the one derived from the table,
+// so added columns are validated too.
+func (c *Client) updateValidator(ctx context.Context, table *schema.Table) error {
+	if c.spec.Validation == validationOff || c.isTimeSeries(table) {
+		return nil
+	}
+	cmd := bson.D{
+		{Key: "collMod", Value: table.Name},
+		{Key: "validator", Value: bson.M{"$jsonSchema": c.jsonSchema(table)}},
+		{Key: "validationLevel", Value: string(c.spec.Validation)},
+	}
+	if err := c.client.Database(c.spec.Database).RunCommand(ctx, cmd).Err(); err != nil {
+		return fmt.Errorf("update validator of %s: %w", table.Name, err)
+	}
+	return nil
+}
+
+// isTimeSeries returns whether the table is stored in a time-series collection.
+// Only append-only tables, without primary keys, can be, as time-series collections don't support unique indexes.
+func (c *Client) isTimeSeries(table *schema.Table) bool {
+	return c.spec.TimeSeries != nil &&
+		len(table.PrimaryKeys()) == 0 &&
+		table.Columns.Get(schema.CqSyncTimeColumn.Name) != nil
+}
+
 func (c *Client) migrateTableOnConflict(ctx context.Context, force bool, table *schema.Table, mdl mongo.IndexModel) error {
 	tableName := table.Name
 	if !force {
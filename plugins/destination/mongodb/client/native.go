@@ -0,0 +1,107 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/cloudquery/plugin-sdk/v4/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// transformArrNative is like transformArr, but converts structs, maps and JSON values to BSON sub-documents
+// and arrays directly, instead of through JSON, so nested values keep their types.
+func transformArrNative(arr arrow.Array) []any {
+	dbArr := make([]any, arr.Len())
+	switch a := arr.(type) {
+	case *types.JSONArray:
+		for i := 0; i < a.Len(); i++ {
+			if a.IsNull(i) {
+				continue
+			}
+			dbArr[i] = nativeJSON(a.ValueStr(i))
+		}
+	case *array.Struct:
+		fields := a.DataType().(*arrow.StructType).Fields()
+		values := make([][]any, len(fields))
+		for f := range fields {
+			values[f] = transformArrNative(a.Field(f))
+		}
+		for i := 0; i < a.Len(); i++ {
+			if a.IsNull(i) {
+				continue
+			}
+			doc := make(bson.D, len(fields))
+			for f, field := range fields {
+				doc[f] = bson.E{Key: field.Name, Value: values[f][i]}
+			}
+			dbArr[i] = doc
+		}
+	case *array.Map:
+		keys := a.Keys()
+		items := transformArrNative(a.Items())
+		for i := 0; i < a.Len(); i++ {
+			if a.IsNull(i) {
+				continue
+			}
+			start, end := a.ValueOffsets(i)
+			doc := make(bson.D, 0, end-start)
+			for j := int(start); j < int(end); j++ {
+				doc = append(doc, bson.E{Key: keys.ValueStr(j), Value: items[j]})
+			}
+			dbArr[i] = doc
+		}
+	case array.ListLike:
+		for i := 0; i < a.Len(); i++ {
+			if a.IsNull(i) {
+				continue
+			}
+			start, end := a.ValueOffsets(i)
+			nested := array.NewSlice(a.ListValues(), start, end)
+			dbArr[i] = bson.A(transformArrNative(nested))
+			nested.Release()
+		}
+	default:
+		return transformArr(arr)
+	}
+	return dbArr
+}
+
+// nativeJSON parses the JSON value, keeping integers as integers instead of converting all numbers to floats.
+// Values that can't be parsed are stored as the raw string.
+func nativeJSON(s string) any {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var val any
+	if err := dec.Decode(&val); err != nil {
+		return s
+	}
+	return jsonToBSON(val)
+}
+
+func jsonToBSON(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		doc := make(bson.M, len(v))
+		for k, e := range v {
+			doc[k] = jsonToBSON(e)
+		}
+		return doc
+	case []any:
+		arr := make(bson.A, len(v))
+		for i, e := range v {
+			arr[i] = jsonToBSON(e)
+		}
+		return arr
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
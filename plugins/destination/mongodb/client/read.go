@@ -14,6 +14,8 @@ import (
 	"github.com/cloudquery/plugin-sdk/v4/types"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 func (c *Client) reverseTransform(f arrow.Field, bldr array.Builder, val any) error {
@@ -74,14 +76,25 @@ func (c *Client) reverseTransform(f arrow.Field, bldr array.Builder, val any) er
 		if err := b.UnmarshalOne(dec); err != nil {
 			return err
 		}
-	case array.ListLikeBuilder:
+	case *array.MapBuilder:
+		// maps are sub-documents in native document mode, and lists of key/value documents otherwise
+		doc, ok := val.(primitive.M)
+		if !ok {
+			return c.reverseTransformList(f, b, val)
+		}
+		keys := maps.Keys(doc)
+		slices.Sort(keys)
 		b.Append(true)
-		valBuilder := b.ValueBuilder()
-		for _, v := range val.(primitive.A) {
-			if err := c.reverseTransform(f, valBuilder, v); err != nil {
+		for _, k := range keys {
+			if err := b.KeyBuilder().AppendValueFromString(k); err != nil {
+				return err
+			}
+			if err := c.reverseTransform(f, b.ItemBuilder(), doc[k]); err != nil {
 				return err
 			}
 		}
+	case array.ListLikeBuilder:
+		return c.reverseTransformList(f, b, val)
 	default:
 		v, ok := val.(string)
 		if !ok {
@@ -94,6 +107,17 @@ func (c *Client) reverseTransform(f arrow.Field, bldr array.Builder, val any) er
 	return nil
 }
 
+func (c *Client) reverseTransformList(f arrow.Field, b array.ListLikeBuilder, val any) error {
+	b.Append(true)
+	valBuilder := b.ValueBuilder()
+	for _, v := range val.(primitive.A) {
+		if err := c.reverseTransform(f, valBuilder, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *Client) reverseTransformer(table *schema.Table, values primitive.M) (arrow.Record, error) {
 	sc := table.ToArrowSchema()
 	bldr := array.NewRecordBuilder(memory.DefaultAllocator, sc)
@@ -14,6 +14,45 @@ type Spec struct {
 	Database         string `json:"database"`
 	BatchSize        int    `json:"batch_size"`
 	BatchSizeBytes   int    `json:"batch_size_bytes"`
+
+	DocumentMode documentMode    `json:"document_mode"`
+	TimeSeries   *TimeSeriesSpec `json:"time_series"`
+	Validation   validationLevel `json:"validation"`
+}
+
+type documentMode string
+
+const (
+	// documentModeJSON converts nested values through JSON, so structs become sub-documents with JSON types only
+	documentModeJSON documentMode = "json"
+	// documentModeNative converts nested values to BSON directly, keeping their types
+	documentModeNative documentMode = "native"
+)
+
+type validationLevel string
+
+const (
+	validationOff      validationLevel = "off"
+	validationModerate validationLevel = "moderate"
+	validationStrict   validationLevel = "strict"
+)
+
+// TimeSeriesSpec enables time-series collections, keyed on _cq_sync_time, for tables without primary keys.
+type TimeSeriesSpec struct {
+	Granularity        string `json:"granularity"`
+	ExpireAfterSeconds int64  `json:"expire_after_seconds"`
+}
+
+func (s *TimeSeriesSpec) Validate() error {
+	switch s.Granularity {
+	case "", "seconds", "minutes", "hours":
+	default:
+		return fmt.Errorf("invalid time_series.granularity %q, valid values are \"seconds\", \"minutes\" and \"hours\"", s.Granularity)
+	}
+	if s.ExpireAfterSeconds < 0 {
+		return fmt.Errorf("time_series.expire_after_seconds must not be negative")
+	}
+	return nil
 }
 
 func (s *Spec) SetDefaults() {
@@ -25,6 +64,16 @@ func (s *Spec) SetDefaults() {
 	}
 }
 
+// setDocumentDefaults sets the defaults of the document options only, the batch options are left to the batch writer.
+func (s *Spec) setDocumentDefaults() {
+	if s.DocumentMode == "" {
+		s.DocumentMode = documentModeJSON
+	}
+	if s.Validation == "" {
+		s.Validation = validationOff
+	}
+}
+
 func (s *Spec) Validate() error {
 	if s.ConnectionString == "" {
 		return fmt.Errorf("connection_string is required")
@@ -32,5 +81,20 @@ func (s *Spec) Validate() error {
 	if s.Database == "" {
 		return fmt.Errorf("database is required")
 	}
+	switch s.DocumentMode {
+	case "", documentModeJSON, documentModeNative:
+	default:
+		return fmt.Errorf("invalid document_mode %q, valid values are %q and %q", s.DocumentMode, documentModeJSON, documentModeNative)
+	}
+	switch s.Validation {
+	case "", validationOff, validationModerate, validationStrict:
+	default:
+		return fmt.Errorf("invalid validation %q, valid values are %q, %q and %q", s.Validation, validationOff, validationModerate, validationStrict)
+	}
+	if s.TimeSeries != nil {
+		if err := s.TimeSeries.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// jsonSchema returns the $jsonSchema validator of the table's documents.
+// Additional properties are allowed, as every document also has an _id.
+func (c *Client) jsonSchema(table *schema.Table) bson.M {
+	properties := make(bson.M, len(table.Columns))
+	required := bson.A{}
+	for _, col := range table.Columns {
+		properties[col.Name] = c.fieldSchema(col.Type, !col.NotNull)
+		if col.NotNull {
+			required = append(required, col.Name)
+		}
+	}
+	s := bson.M{
+		"bsonType":   "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (c *Client) fieldSchema(dt arrow.DataType, nullable bool) bson.M {
+	s := c.typeSchema(dt)
+	if t, ok := s["bsonType"]; ok && nullable {
+		s["bsonType"] = bson.A{t, "null"}
+	}
+	return s
+}
+
+// typeSchema returns the schema of the BSON values the data type is written as by transformArr and transformArrNative.
+func (c *Client) typeSchema(dt arrow.DataType) bson.M {
+	native := c.spec.DocumentMode == documentModeNative
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		return bson.M{"bsonType": "bool"}
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Uint8Type, *arrow.Uint16Type:
+		return bson.M{"bsonType": "int"}
+	case *arrow.Int64Type, *arrow.Uint32Type, *arrow.Uint64Type:
+		return bson.M{"bsonType": "long"}
+	case *arrow.Float32Type, *arrow.Float64Type:
+		return bson.M{"bsonType": "double"}
+	case *arrow.StringType, *arrow.LargeStringType:
+		return bson.M{"bsonType": "string"}
+	case *arrow.BinaryType, *arrow.LargeBinaryType:
+		return bson.M{"bsonType": "binData"}
+	case *arrow.TimestampType:
+		return bson.M{"bsonType": "date"}
+	case *types.JSONType:
+		// any JSON value, including scalars
+		return bson.M{}
+	case *arrow.StructType:
+		if !native {
+			return bson.M{"bsonType": "object"}
+		}
+		properties := make(bson.M, len(dt.Fields()))
+		for _, f := range dt.Fields() {
+			properties[f.Name] = c.fieldSchema(f.Type, true)
+		}
+		return bson.M{"bsonType": "object", "properties": properties}
+	case *arrow.MapType:
+		if !native {
+			return bson.M{"bsonType": "array"}
+		}
+		return bson.M{"bsonType": "object", "additionalProperties": c.fieldSchema(dt.ItemType(), true)}
+	case arrow.ListLikeType:
+		return bson.M{"bsonType": "array", "items": c.fieldSchema(dt.Elem(), true)}
+	default:
+		return bson.M{"bsonType": "string"}
+	}
+}
@@ -80,7 +80,7 @@ func transformArr(arr arrow.Array) []any {
 	return dbArr
 }
 
-func (*Client) transformRecord(table *schema.Table, record arrow.Record) []any {
+func (c *Client) transformRecord(table *schema.Table, record arrow.Record) []any {
 	nc := int(record.NumCols())
 	nr := int(record.NumRows())
 	documents := make([]any, nr)
@@ -90,7 +90,12 @@ func (*Client) transformRecord(table *schema.Table, record arrow.Record) []any {
 
 	for i := 0; i < nc; i++ {
 		col := record.Column(i)
-		transformed := transformArr(col)
+		var transformed []any
+		if c.spec.DocumentMode == documentModeNative {
+			transformed = transformArrNative(col)
+		} else {
+			transformed = transformArr(col)
+		}
 		for l := 0; l < nr; l++ {
 			documents[l].(bson.M)[table.Columns[i].Name] = transformed[l]
 		}
@@ -3,6 +3,7 @@ package client
 import (
 	"strings"
 
+	"github.com/apache/arrow/go/v13/arrow"
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"golang.org/x/exp/slices"
 )
@@ -30,11 +31,14 @@ func unsafeChanges(changes []schema.TableColumnChange) []schema.TableColumnChang
 func needsTableDrop(change schema.TableColumnChange) bool {
 	switch change.Type {
 	case schema.TableColumnChangeTypeAdd:
+		// existing rows have no values for the column
 		return change.Current.NotNull || change.Current.PrimaryKey
 	case schema.TableColumnChangeTypeRemove:
-		return change.Previous.NotNull || change.Previous.PrimaryKey
+		// the column is kept, made nullable & removed from the primary key, if needed
+		return false
 	case schema.TableColumnChangeTypeUpdate:
-		return true
+		// only the nullability & primary key changes can be done in place
+		return !arrow.TypeEqual(change.Current.Type, change.Previous.Type)
 	default:
 		return true
 	}
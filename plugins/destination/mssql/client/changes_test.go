@@ -0,0 +1,43 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsafeChanges(t *testing.T) {
+	have := &schema.Table{
+		Name: "table_name",
+		Columns: schema.ColumnList{
+			{Name: "_cq_id", Type: arrow.PrimitiveTypes.Int64, NotNull: true},
+			{Name: "id", Type: arrow.PrimitiveTypes.Int64, PrimaryKey: true, NotNull: true},
+			{Name: "removed", Type: arrow.PrimitiveTypes.Int64, NotNull: true},
+		},
+	}
+
+	// moving the primary key to _cq_id can be done in place
+	want := &schema.Table{
+		Name: "table_name",
+		Columns: schema.ColumnList{
+			{Name: "_cq_id", Type: arrow.PrimitiveTypes.Int64, PrimaryKey: true, NotNull: true},
+			{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		},
+	}
+	require.Empty(t, unsafeChanges(want.GetChanges(have)))
+	require.False(t, samePKs(have.PrimaryKeys(), want.PrimaryKeys()))
+
+	// type changes and not null columns without values require dropping the table
+	want = &schema.Table{
+		Name: "table_name",
+		Columns: schema.ColumnList{
+			{Name: "_cq_id", Type: arrow.PrimitiveTypes.Int64, NotNull: true},
+			{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true, NotNull: true},
+			{Name: "removed", Type: arrow.PrimitiveTypes.Int64, NotNull: true},
+			{Name: "added", Type: arrow.PrimitiveTypes.Int64, NotNull: true},
+		},
+	}
+	require.Len(t, unsafeChanges(want.GetChanges(have)), 2)
+}
@@ -33,8 +33,9 @@ func TestPlugin(t *testing.T) {
 		p,
 		plugin.WriterTestSuiteTests{
 			SafeMigrations: plugin.SafeMigrations{
-				AddColumn:    true,
-				RemoveColumn: true,
+				AddColumn:           true,
+				RemoveColumn:        true,
+				RemoveColumnNotNull: true,
 			},
 		},
 	)
@@ -81,3 +81,15 @@ func (c *Client) getTablePK(ctx context.Context, tableName string) ([]string, er
 
 	return result, nil
 }
+
+func (c *Client) getTablePKConstraint(ctx context.Context, tableName string) (string, error) {
+	query, params := queries.GetTablePKConstraint(c.spec.Schema, tableName)
+
+	var name string
+	if err := c.db.QueryRowContext(ctx, query, params...).Scan(&name); err != nil {
+		c.logErr(err)
+		return "", err
+	}
+
+	return name, nil
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"strings"
+	"fmt"
 
 	"github.com/cloudquery/cloudquery/plugins/destination/mssql/queries"
 	"github.com/cloudquery/plugin-sdk/v4/message"
 	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"golang.org/x/exp/slices"
 )
 
 // MigrateTables relies on the CLI/client to lock before running migration.
@@ -24,6 +25,11 @@ func (c *Client) MigrateTables(ctx context.Context, messages message.WriteMigrat
 		return err
 	}
 
+	forced := make(map[string]bool, len(messages))
+	for _, m := range messages {
+		forced[m.Table.Name] = m.MigrateForce
+	}
+
 	for _, want := range want {
 		c.logger.Info().Str("table", want.Name).Msg("Migrating table")
 		if len(want.Columns) == 0 {
@@ -41,7 +47,7 @@ func (c *Client) MigrateTables(ctx context.Context, messages message.WriteMigrat
 		}
 
 		c.logger.Info().Str("table", want.Name).Msg("Table exists, auto-migrating")
-		if err := c.autoMigrateTable(ctx, have, want); err != nil {
+		if err := c.autoMigrateTable(ctx, have, want, forced[want.Name]); err != nil {
 			return err
 		}
 	}
@@ -77,7 +83,7 @@ func (c *Client) checkForced(have, want schema.Tables, messages message.WriteMig
 	return nil
 }
 
-func (c *Client) autoMigrateTable(ctx context.Context, have, want *schema.Table) error {
+func (c *Client) autoMigrateTable(ctx context.Context, have, want *schema.Table, force bool) error {
 	changes := want.GetChanges(have)
 	if len(changes) == 0 {
 		c.logger.Info().Str("table", want.Name).Msg("Table schema is up-to-date, skip")
@@ -90,19 +96,73 @@ func (c *Client) autoMigrateTable(ctx context.Context, have, want *schema.Table)
 		return c.recreateTable(ctx, want)
 	}
 
-	statements := make([]string, 0, len(changes))
+	statements, err := c.migrationStatements(ctx, have, want, changes)
+	if err != nil {
+		return err
+	}
+
+	if err := c.execStatements(ctx, want.Name, statements); err != nil {
+		// the existing rows may not fit the new primary key or nullability
+		if !force {
+			return fmt.Errorf("failed to migrate table %s, migrate manually or consider using 'migrate_mode: forced': %w", want.Name, err)
+		}
+		c.logger.Warn().Err(err).Str("table", want.Name).Msg("In-place migration failed, recreating table")
+		return c.recreateTable(ctx, want)
+	}
+
+	return c.ensureTVP(ctx, want)
+}
+
+// migrationStatements returns the statements migrating the table in place.
+// The primary key constraint is dropped first, so that its columns can be altered, and added back last.
+func (c *Client) migrationStatements(ctx context.Context, have, want *schema.Table, changes []schema.TableColumnChange) ([]string, error) {
+	statements := make([]string, 0, len(changes)+2)
+
+	havePKs, wantPKs := have.PrimaryKeys(), want.PrimaryKeys()
+	pkChanged := !samePKs(havePKs, wantPKs)
+	if pkChanged && len(havePKs) > 0 {
+		name, err := c.getTablePKConstraint(ctx, want.Name)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, queries.DropPK(c.spec.Schema, want, name))
+	}
+
 	for _, change := range changes {
-		if change.Type == schema.TableColumnChangeTypeAdd {
+		switch change.Type {
+		case schema.TableColumnChangeTypeAdd:
 			statements = append(statements, queries.AddColumn(c.spec.Schema, want, &change.Current))
+		case schema.TableColumnChangeTypeRemove:
+			if change.Previous.NotNull {
+				// the column is kept, so it has to accept nulls for the new rows
+				col := change.Previous
+				col.NotNull = false
+				statements = append(statements, queries.AlterColumn(c.spec.Schema, want, &col))
+			}
+		case schema.TableColumnChangeTypeUpdate:
+			if change.Current.NotNull != change.Previous.NotNull {
+				statements = append(statements, queries.AlterColumn(c.spec.Schema, want, &change.Current))
+			}
 		}
 	}
 
-	err := c.execStatements(ctx, want.Name, statements)
-	if err != nil {
-		return err
+	if pkChanged && len(wantPKs) > 0 {
+		statements = append(statements, queries.AddPK(c.spec.Schema, want))
 	}
 
-	return c.ensureTVP(ctx, want)
+	return statements, nil
+}
+
+func samePKs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, pk := range a {
+		if !slices.Contains(b, pk) {
+			return false
+		}
+	}
+	return true
 }
 
 func (c *Client) execStatements(ctx context.Context, tableName string, statements []string) error {
@@ -111,9 +171,12 @@ func (c *Client) execStatements(ctx context.Context, tableName string, statement
 	}
 
 	return c.doInTx(ctx, func(tx *sql.Tx) error {
-		query := strings.Join(statements, "\n")
-		c.logger.Debug().Str("table", tableName).Str("query", query).Msg("exec migration statement")
-		_, err := c.db.ExecContext(ctx, query)
-		return err
+		for _, query := range statements {
+			c.logger.Debug().Str("table", tableName).Str("query", query).Msg("exec migration statement")
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 }
@@ -21,6 +21,9 @@ type Spec struct {
 	AuthMode         AuthMode `json:"auth_mode,omitempty"`
 	Schema           string   `json:"schema,omitempty"`
 
+	// Columnstore creates tables without primary keys as clustered columnstore indexes
+	Columnstore bool `json:"columnstore,omitempty"`
+
 	BatchSize      int                  `json:"batch_size,omitempty"`
 	BatchSizeBytes int                  `json:"batch_size_bytes,omitempty"`
 	BatchTimeout   *configtype.Duration `json:"batch_timeout,omitempty"`
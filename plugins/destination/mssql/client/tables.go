@@ -21,6 +21,13 @@ func (c *Client) createTable(ctx context.Context, table *schema.Table) (err erro
 		return fmt.Errorf("failed to create table %s: %w", table.Name, err)
 	}
 
+	if c.spec.Columnstore && len(table.PrimaryKeys()) == 0 {
+		_, err = c.db.ExecContext(ctx, queries.CreateColumnstoreIndex(c.spec.Schema, table))
+		if err != nil {
+			return fmt.Errorf("failed to create columnstore index for table %s: %w", table.Name, err)
+		}
+	}
+
 	return c.ensureTVP(ctx, table)
 }
 
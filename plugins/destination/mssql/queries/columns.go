@@ -19,6 +19,15 @@ func AddColumn(schemaName string, table *schema.Table, column *schema.Column) st
 	})
 }
 
+// AlterColumn changes the nullability of the column, keeping its type.
+func AlterColumn(schemaName string, table *schema.Table, column *schema.Column) string {
+	return execTemplate("col_alter.sql.tpl", &colQueryBuilder{
+		Schema: schemaName,
+		Table:  table.Name,
+		Column: column,
+	})
+}
+
 func GetValueColumns(table *schema.Table) []string {
 	columns := make([]string, 0, len(table.Columns))
 	for _, col := range table.Columns {
@@ -22,3 +22,17 @@ func TestAddColumn(t *testing.T) {
 
 	require.Equal(t, expected, query)
 }
+
+func TestAlterColumn(t *testing.T) {
+	const (
+		schemaName = "cq"
+		expected   = `ALTER TABLE [cq].[table_name] ALTER COLUMN [my_col] bigint NULL;`
+	)
+
+	query := AlterColumn(schemaName, &schema.Table{Name: "table_name"}, &schema.Column{
+		Name: "my_col",
+		Type: arrow.PrimitiveTypes.Int64,
+	})
+
+	require.Equal(t, expected, query)
+}
@@ -19,3 +19,22 @@ func pkConstraint(table *schema.Table) string {
 	const pkSuffix = "_cqpk"
 	return sanitizeID(table.Name + pkSuffix)
 }
+
+// DropPK drops the primary key constraint with the given name.
+func DropPK(schemaName string, table *schema.Table, constraintName string) string {
+	return execTemplate("pk_drop.sql.tpl", &pkQueryBuilder{
+		Schema: schemaName,
+		Table:  table.Name,
+		Name:   sanitizeID(constraintName),
+	})
+}
+
+// AddPK adds the primary key constraint on the primary key columns of the table.
+func AddPK(schemaName string, table *schema.Table) string {
+	return execTemplate("pk_add.sql.tpl", &pkQueryBuilder{
+		Schema:  schemaName,
+		Table:   table.Name,
+		Name:    pkConstraint(table),
+		Columns: table.PrimaryKeys(),
+	})
+}
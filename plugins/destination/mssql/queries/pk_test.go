@@ -0,0 +1,41 @@
+package queries
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropPK(t *testing.T) {
+	const (
+		schemaName = "cq"
+		expected   = `ALTER TABLE [cq].[table_name] DROP CONSTRAINT [PK__table_na__3213E83F];`
+	)
+
+	query := DropPK(schemaName, &schema.Table{Name: "table_name"}, "PK__table_na__3213E83F")
+
+	require.Equal(t, expected, query)
+}
+
+func TestAddPK(t *testing.T) {
+	const (
+		schemaName = "cq"
+		expected   = `ALTER TABLE [cq].[table_name] ADD CONSTRAINT [table_name_cqpk] PRIMARY KEY (
+  [extra_col_pk1],
+  [extra_col_pk2]
+);`
+	)
+
+	query := AddPK(schemaName, &schema.Table{
+		Name: "table_name",
+		Columns: schema.ColumnList{
+			schema.CqIDColumn,
+			schema.Column{Name: "extra_col_pk1", Type: arrow.PrimitiveTypes.Float64, PrimaryKey: true, NotNull: true},
+			schema.Column{Name: "extra_col_pk2", Type: arrow.FixedWidthTypes.Boolean, PrimaryKey: true, NotNull: true},
+		},
+	})
+
+	require.Equal(t, expected, query)
+}
@@ -23,3 +23,12 @@ func GetTablePK(schemaName string, tableName string) (query string, params []any
 
 	return pks, []any{sql.Named("tableName", tableName), sql.Named("schemaName", schemaName)}
 }
+
+func GetTablePKConstraint(schemaName string, tableName string) (query string, params []any) {
+	pkConstraint := `SELECT CONSTRAINT_NAME FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS
+			WHERE CONSTRAINT_TYPE = 'PRIMARY KEY'
+			AND TABLE_NAME = @tableName
+			AND TABLE_SCHEMA = @schemaName;`
+
+	return pkConstraint, []any{sql.Named("tableName", tableName), sql.Named("schemaName", schemaName)}
+}
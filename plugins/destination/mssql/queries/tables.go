@@ -39,3 +39,14 @@ func DropTable(schemaName string, table *schema.Table) string {
 		Table:  table.Name,
 	})
 }
+
+// CreateColumnstoreIndex stores the table as a clustered columnstore index.
+// The table can't have a clustered primary key, so this is only used for tables without primary keys.
+func CreateColumnstoreIndex(schemaName string, table *schema.Table) string {
+	const columnstoreSuffix = "_cqcci"
+	return execTemplate("columnstore_add.sql.tpl", &pkQueryBuilder{
+		Schema: schemaName,
+		Table:  table.Name,
+		Name:   sanitizeID(table.Name + columnstoreSuffix),
+	})
+}
@@ -99,3 +99,14 @@ func TestCreateTableCustomPKConstraintName(t *testing.T) {
 
 	require.Equal(t, expected, query)
 }
+
+func TestCreateColumnstoreIndex(t *testing.T) {
+	const (
+		schemaName = "cq"
+		expected   = `CREATE CLUSTERED COLUMNSTORE INDEX [table_name_cqcci] ON [cq].[table_name];`
+	)
+
+	query := CreateColumnstoreIndex(schemaName, &schema.Table{Name: "table_name"})
+
+	require.Equal(t, expected, query)
+}
@@ -0,0 +1 @@
+ALTER TABLE {{sanitizeID .Schema .Table}} ALTER COLUMN {{sanitizeID .Column.Name}} {{.Column.Type | sql}} {{- if .Column.NotNull }} NOT NULL {{- else }} NULL {{- end}};
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE CLUSTERED COLUMNSTORE INDEX {{.Name}} ON {{sanitizeID .Schema .Table}};
\ No newline at end of file
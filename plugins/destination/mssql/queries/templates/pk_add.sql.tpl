@@ -0,0 +1,3 @@
+ALTER TABLE {{sanitizeID .Schema .Table}} ADD CONSTRAINT {{.Name}} PRIMARY KEY (
+{{template "col_names.sql.tpl" .Columns}}
+);
\ No newline at end of file
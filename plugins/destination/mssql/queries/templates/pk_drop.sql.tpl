@@ -0,0 +1 @@
+ALTER TABLE {{sanitizeID .Schema .Table}} DROP CONSTRAINT {{.Name}};
\ No newline at end of file
@@ -2,25 +2,18 @@ CREATE PROCEDURE {{sanitizeID .Schema .Name}} @TVP {{sanitizeID .Schema .Type}}
 AS
 BEGIN
  SET NOCOUNT ON;
+ MERGE {{sanitizeID .Schema .Table.Name}} WITH (HOLDLOCK) AS [tgt]
+ USING @TVP AS [src]
+ ON (
+{{with .Table.PrimaryKeys}}{{template "tvp_cmp.sql.tpl" .}}{{end}}
+ )
  {{- if .Values }}
- UPDATE [tgt] WITH (UPDLOCK)
- SET
+ WHEN MATCHED THEN UPDATE SET
 {{with .Values}}{{template "tvp_assign.sql.tpl" .}}{{end}}
- FROM {{sanitizeID .Schema .Table.Name}} AS [tgt]
- INNER JOIN @TVP AS [src]
- ON
-{{with .Table.PrimaryKeys}}{{template "tvp_cmp.sql.tpl" .}}{{end}}
-;
-{{- end }}
-
- INSERT {{sanitizeID .Schema .Table.Name}} (
+ {{- end }}
+ WHEN NOT MATCHED BY TARGET THEN INSERT (
 {{template "col_names.sql.tpl" .Table.Columns.Names}}
- ) SELECT
+ ) VALUES (
 {{template "tvp_col_names.sql.tpl" .Table.Columns.Names}}
- FROM @TVP AS [src]
- LEFT JOIN {{sanitizeID .Schema .Table.Name}} AS [tgt] ON (
-{{with .Table.PrimaryKeys}}{{template "tvp_cmp.sql.tpl" .}}{{end}}
- ) WHERE (
-{{with .Table.PrimaryKeys}}{{template "tvp_null.sql.tpl" .}}{{end}}
-);
+ );
 END;
\ No newline at end of file
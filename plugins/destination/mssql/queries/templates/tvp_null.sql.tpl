@@ -1,7 +0,0 @@
-{{- $first := true -}}
-{{- range .}}
-  {{- if $first}}{{$first = false}}  {{else}}
-  AND
-  {{end -}}
-  {{sanitizeID "tgt" .}} IS NULL
-{{- end -}}
\ No newline at end of file
@@ -2,7 +2,9 @@ package queries
 
 import (
 	"database/sql"
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/apache/arrow/go/v13/arrow"
 	"github.com/apache/arrow/go/v13/arrow/array"
@@ -73,6 +75,7 @@ func TVPQuery(schemaName string, table *schema.Table, records []arrow.Record) (q
 	if err != nil {
 		return "", nil, err
 	}
+	rows = dedupRows(table, rows)
 
 	return "exec " + sanitizeID(schemaName, tvpProcName(table)) + " @TVP;",
 		[]any{
@@ -84,6 +87,42 @@ func TVPQuery(schemaName string, table *schema.Table, records []arrow.Record) (q
 		nil
 }
 
+// dedupRows keeps only the last row for each primary key,
+// as MERGE fails when several source rows match the same target row.
+func dedupRows(table *schema.Table, rows [][]any) [][]any {
+	pkIndices := make([]int, 0, len(table.Columns))
+	for i, col := range table.Columns {
+		if col.PrimaryKey {
+			pkIndices = append(pkIndices, i)
+		}
+	}
+
+	last := make(map[string]int, len(rows))
+	keys := make([]string, len(rows))
+	for i, row := range rows {
+		key := new(strings.Builder)
+		for _, idx := range pkIndices {
+			if row[idx] != nil {
+				fmt.Fprintf(key, "%v", reflect.Indirect(reflect.ValueOf(row[idx])).Interface())
+			}
+			key.WriteByte(0)
+		}
+		keys[i] = key.String()
+		last[keys[i]] = i
+	}
+	if len(last) == len(rows) {
+		return rows
+	}
+
+	result := make([][]any, 0, len(last))
+	for i, row := range rows {
+		if last[keys[i]] == i {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
 type transformer func([][]any) any
 
 func tableTransformer(table *schema.Table) transformer {
@@ -102,23 +102,21 @@ func TestTVPAddProc(t *testing.T) {
 AS
 BEGIN
  SET NOCOUNT ON;
- UPDATE [tgt] WITH (UPDLOCK)
- SET
+ MERGE [cq].[table_name] WITH (HOLDLOCK) AS [tgt]
+ USING @TVP AS [src]
+ ON (
+  [tgt].[extra_col_pk1] = [src].[extra_col_pk1]
+  AND
+  [tgt].[extra_col_pk2] = [src].[extra_col_pk2]
+ )
+ WHEN MATCHED THEN UPDATE SET
   [tgt].[_cq_id] = [src].[_cq_id],
   [tgt].[_cq_parent_id] = [src].[_cq_parent_id],
   [tgt].[_cq_source_name] = [src].[_cq_source_name],
   [tgt].[_cq_sync_time] = [src].[_cq_sync_time],
   [tgt].[extra_col_not_pk1] = [src].[extra_col_not_pk1],
   [tgt].[extra_col_not_pk2] = [src].[extra_col_not_pk2]
- FROM [cq].[table_name] AS [tgt]
- INNER JOIN @TVP AS [src]
- ON
-  [tgt].[extra_col_pk1] = [src].[extra_col_pk1]
-  AND
-  [tgt].[extra_col_pk2] = [src].[extra_col_pk2]
-;
-
- INSERT [cq].[table_name] (
+ WHEN NOT MATCHED BY TARGET THEN INSERT (
   [_cq_id],
   [_cq_parent_id],
   [_cq_source_name],
@@ -127,7 +125,7 @@ BEGIN
   [extra_col_pk2],
   [extra_col_not_pk1],
   [extra_col_not_pk2]
- ) SELECT
+ ) VALUES (
   [src].[_cq_id],
   [src].[_cq_parent_id],
   [src].[_cq_source_name],
@@ -136,16 +134,7 @@ BEGIN
   [src].[extra_col_pk2],
   [src].[extra_col_not_pk1],
   [src].[extra_col_not_pk2]
- FROM @TVP AS [src]
- LEFT JOIN [cq].[table_name] AS [tgt] ON (
-  [tgt].[extra_col_pk1] = [src].[extra_col_pk1]
-  AND
-  [tgt].[extra_col_pk2] = [src].[extra_col_pk2]
- ) WHERE (
-  [tgt].[extra_col_pk1] IS NULL
-  AND
-  [tgt].[extra_col_pk2] IS NULL
-);
+ );
 END;`
 	)
 
@@ -173,23 +162,20 @@ func TestTVPAddProcInsertOnly(t *testing.T) {
 AS
 BEGIN
  SET NOCOUNT ON;
-
- INSERT [cq].[table_name] (
+ MERGE [cq].[table_name] WITH (HOLDLOCK) AS [tgt]
+ USING @TVP AS [src]
+ ON (
+  [tgt].[extra_col_pk1] = [src].[extra_col_pk1]
+  AND
+  [tgt].[extra_col_pk2] = [src].[extra_col_pk2]
+ )
+ WHEN NOT MATCHED BY TARGET THEN INSERT (
   [extra_col_pk1],
   [extra_col_pk2]
- ) SELECT
+ ) VALUES (
   [src].[extra_col_pk1],
   [src].[extra_col_pk2]
- FROM @TVP AS [src]
- LEFT JOIN [cq].[table_name] AS [tgt] ON (
-  [tgt].[extra_col_pk1] = [src].[extra_col_pk1]
-  AND
-  [tgt].[extra_col_pk2] = [src].[extra_col_pk2]
- ) WHERE (
-  [tgt].[extra_col_pk1] IS NULL
-  AND
-  [tgt].[extra_col_pk2] IS NULL
-);
+ );
 END;`
 	)
 
@@ -203,3 +189,21 @@ END;`
 
 	require.Equal(t, expected, query)
 }
+
+func TestDedupRows(t *testing.T) {
+	table := &schema.Table{
+		Name: "table_name",
+		Columns: schema.ColumnList{
+			schema.Column{Name: "pk", Type: arrow.BinaryTypes.String, PrimaryKey: true, NotNull: true},
+			schema.Column{Name: "value", Type: arrow.PrimitiveTypes.Int64},
+		},
+	}
+	str := func(s string) *string { return &s }
+	num := func(n int64) *int64 { return &n }
+	rows := dedupRows(table, [][]any{
+		{str("a"), num(1)},
+		{str("b"), num(2)},
+		{str("a"), num(3)},
+	})
+	require.Equal(t, [][]any{{str("b"), num(2)}, {str("a"), num(3)}}, rows)
+}
@@ -16,7 +16,7 @@ func GetRows(table arrow.Table) ([][]any, error) {
 		row := 0
 		for _, chunk := range col.Data().Chunks() {
 			for i := 0; i < chunk.Len(); i++ {
-				rows[row][n], err = getColValue(chunk, row)
+				rows[row][n], err = getColValue(chunk, i)
 				if err != nil {
 					return nil, err
 				}
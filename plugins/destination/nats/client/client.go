@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/writers/batchwriter"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+)
+
+type Client struct {
+	plugin.UnimplementedSource
+
+	logger zerolog.Logger
+	spec   *Spec
+
+	conn *nats.Conn
+	js   nats.JetStreamContext
+	// kv is the bucket of the rows in ModeKV
+	kv nats.KeyValue
+
+	// encoder writes and reads the rows as JSON
+	encoder *filetypes.Client
+	writer  *batchwriter.BatchWriter
+}
+
+func New(_ context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewClientOptions) (plugin.Client, error) {
+	c := &Client{
+		logger: logger.With().Str("module", "dest-nats").Logger(),
+	}
+	if opts.NoConnection {
+		return c, nil
+	}
+
+	if err := json.Unmarshal(spec, &c.spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal NATS spec: %w", err)
+	}
+	if err := c.spec.Validate(); err != nil {
+		return nil, err
+	}
+	c.spec.SetDefaults()
+
+	var err error
+	c.encoder, err = filetypes.NewClient(&filetypes.FileSpec{Format: filetypes.FormatTypeJSON})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create filetypes client: %w", err)
+	}
+
+	c.conn, err = nats.Connect(c.spec.URL, c.connectOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	c.js, err = c.conn.JetStream()
+	if err != nil {
+		c.conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+	if err := c.ensureStore(); err != nil {
+		c.conn.Close()
+		return nil, err
+	}
+
+	writerOpts := []batchwriter.Option{batchwriter.WithLogger(c.logger), batchwriter.WithBatchSize(c.spec.BatchSize)}
+	if c.spec.BatchSizeBytes > 0 {
+		// otherwise the batch writer's default applies
+		writerOpts = append(writerOpts, batchwriter.WithBatchSizeBytes(c.spec.BatchSizeBytes))
+	}
+	c.writer, err = batchwriter.New(c, writerOpts...)
+	if err != nil {
+		c.conn.Close()
+		return nil, fmt.Errorf("failed to create batch writer: %w", err)
+	}
+	return c, nil
+}
+
+func (c *Client) connectOptions() []nats.Option {
+	opts := []nats.Option{nats.Name("cloudquery")}
+	switch {
+	case c.spec.CredentialsFile != "":
+		opts = append(opts, nats.UserCredentials(c.spec.CredentialsFile))
+	case c.spec.Token != "":
+		opts = append(opts, nats.Token(c.spec.Token))
+	case c.spec.Username != "":
+		opts = append(opts, nats.UserInfo(c.spec.Username, c.spec.Password))
+	}
+	return opts
+}
+
+// ensureStore creates the stream, or the bucket in ModeKV, if it doesn't exist yet.
+// Existing ones are used as they are, so that their limits and replicas can be configured outside of CloudQuery.
+func (c *Client) ensureStore() error {
+	if c.spec.Mode == ModeKV {
+		kv, err := c.js.KeyValue(c.spec.Bucket)
+		if errors.Is(err, nats.ErrBucketNotFound) {
+			c.logger.Info().Str("bucket", c.spec.Bucket).Msg("creating bucket")
+			kv, err = c.js.CreateKeyValue(&nats.KeyValueConfig{Bucket: c.spec.Bucket})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get bucket %s: %w", c.spec.Bucket, err)
+		}
+		c.kv = kv
+		return nil
+	}
+
+	_, err := c.js.StreamInfo(c.spec.Stream)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		c.logger.Info().Str("stream", c.spec.Stream).Msg("creating stream")
+		_, err = c.js.AddStream(&nats.StreamConfig{
+			Name:     c.spec.Stream,
+			Subjects: []string{c.spec.SubjectPrefix + ".>"},
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get stream %s: %w", c.spec.Stream, err)
+	}
+	return nil
+}
+
+func (*Client) MigrateTables(context.Context, message.WriteMigrateTables) error {
+	// rows are schemaless JSON, and all tables share the stream or bucket created on start
+	return nil
+}
+
+func (c *Client) Close(ctx context.Context) error {
+	if c.conn == nil {
+		return nil
+	}
+	defer c.conn.Close()
+	if err := c.writer.Close(ctx); err != nil {
+		return fmt.Errorf("failed to close batch writer: %w", err)
+	}
+	return nil
+}
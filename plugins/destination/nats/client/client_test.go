@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/nats-io/nats-server/v2/server"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// runServer starts an embedded NATS server with JetStream enabled.
+func runServer(t *testing.T) *server.Server {
+	t.Helper()
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	s := natsserver.RunServer(&opts)
+	t.Cleanup(s.Shutdown)
+	return s
+}
+
+// runClient returns a client connected to a new server.
+func runClient(t *testing.T, spec Spec) *Client {
+	t.Helper()
+	spec.URL = runServer(t).ClientURL()
+	b, err := json.Marshal(spec)
+	require.NoError(t, err)
+	c, err := New(context.Background(), zerolog.Nop(), b, plugin.NewClientOptions{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, c.Close(context.Background())) })
+	return c.(*Client)
+}
+
+func TestPlugin(t *testing.T) {
+	cases := []struct {
+		mode  string
+		tests plugin.WriterTestSuiteTests
+	}{
+		{mode: ModeStream, tests: plugin.WriterTestSuiteTests{SkipUpsert: true, SkipMigrate: true, SkipDeleteStale: true}},
+		// rows are stored under their keys, so inserting a row again replaces it
+		{mode: ModeKV, tests: plugin.WriterTestSuiteTests{SkipInsert: true, SkipMigrate: true}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.mode, func(t *testing.T) {
+			ctx := context.Background()
+			p := plugin.NewPlugin("nats", "development", New)
+			b, err := json.Marshal(&Spec{URL: runServer(t).ClientURL(), Mode: tc.mode})
+			require.NoError(t, err)
+			require.NoError(t, p.Init(ctx, b, plugin.NewClientOptions{}))
+			t.Cleanup(func() { require.NoError(t, p.Close(ctx)) })
+			plugin.TestWriterSuiteRunner(t, p, tc.tests)
+		})
+	}
+}
+
+// idsTable is keyed by the id, so rows with the same id are the same row.
+var idsTable = &schema.Table{
+	Name: "test_table",
+	Columns: schema.ColumnList{
+		schema.CqSourceNameColumn,
+		schema.CqSyncTimeColumn,
+		{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true},
+	},
+}
+
+// idsRecord returns a record of idsTable with a row per id, synced at the time.
+func idsRecord(syncTime time.Time, ids ...string) arrow.Record {
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, idsTable.ToArrowSchema())
+	defer bldr.Release()
+	for _, id := range ids {
+		bldr.Field(0).(*array.StringBuilder).Append("source")
+		bldr.Field(1).(*array.TimestampBuilder).AppendTime(syncTime)
+		bldr.Field(2).(*array.StringBuilder).Append(id)
+	}
+	return bldr.NewRecord()
+}
+
+func TestDedup(t *testing.T) {
+	c := runClient(t, Spec{Dedup: true})
+	ctx := context.Background()
+
+	syncTime := time.Now().UTC().Truncate(time.Microsecond)
+	// the same rows are published again, as on a retry, then in the next sync
+	batches := []message.WriteInserts{
+		{{Record: idsRecord(syncTime, "a", "b")}},
+		{{Record: idsRecord(syncTime, "a", "b")}},
+		{{Record: idsRecord(syncTime.Add(time.Second), "a")}},
+	}
+	for _, batch := range batches {
+		require.NoError(t, c.WriteTableBatch(ctx, idsTable.Name, batch))
+	}
+
+	info, err := c.js.StreamInfo(c.spec.Stream)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, info.State.Msgs)
+}
+
+func TestDeleteStaleKV(t *testing.T) {
+	c := runClient(t, Spec{Mode: ModeKV})
+	ctx := context.Background()
+
+	syncTime := time.Now().UTC().Truncate(time.Microsecond)
+	require.NoError(t, c.WriteTableBatch(ctx, idsTable.Name, message.WriteInserts{{Record: idsRecord(syncTime, "a", "b.c")}}))
+	next := syncTime.Add(time.Second)
+	require.NoError(t, c.WriteTableBatch(ctx, idsTable.Name, message.WriteInserts{{Record: idsRecord(next, "a")}}))
+	require.NoError(t, c.DeleteStale(ctx, message.WriteDeleteStales{{TableName: idsTable.Name, SourceName: "source", SyncTime: next}}))
+
+	keys, err := c.kv.Keys()
+	require.NoError(t, err)
+	require.Equal(t, []string{"test_table.a"}, keys)
+}
+
+func TestEscapeToken(t *testing.T) {
+	cases := map[string]string{
+		"":                 "=",
+		"i-0123_abc":       "i-0123_abc",
+		"10.0.0.1":         "10=2E0=2E0=2E1",
+		"a=b":              "a=3Db",
+		"arn:aws:s3:::b/*": "arn=3Aaws=3As3=3A=3A=3Ab=2F=2A",
+	}
+	for give, want := range cases {
+		require.Equal(t, want, escapeToken(give), give)
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/nats-io/nats.go"
+)
+
+// syncTimeLayout is the format timestamps are encoded in by the JSON encoder, always in UTC.
+const syncTimeLayout = "2006-01-02 15:04:05.999999999"
+
+// DeleteStale purges the keys of the rows of the source that weren't updated in the sync.
+// Messages published to a stream can't be retracted, so in ModeStream the stream limits should be used to expire them instead.
+func (c *Client) DeleteStale(ctx context.Context, msgs message.WriteDeleteStales) error {
+	if c.spec.Mode != ModeKV {
+		return nil
+	}
+	for _, msg := range msgs {
+		var purged int
+		err := c.forEachEntry(ctx, msg.TableName, func(entry nats.KeyValueEntry) error {
+			stale, err := isStale(entry.Value(), msg.SourceName, msg.SyncTime)
+			if err != nil {
+				return fmt.Errorf("failed to decode key %s: %w", entry.Key(), err)
+			}
+			if !stale {
+				return nil
+			}
+			purged++
+			return c.kv.Purge(entry.Key())
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete stale rows of table %s: %w", msg.TableName, err)
+		}
+		c.logger.Debug().Str("table", msg.TableName).Int("purged", purged).Msg("deleted stale rows")
+	}
+	return nil
+}
+
+// isStale reports whether the row was written by the source before the sync time.
+func isStale(data []byte, sourceName string, syncTime time.Time) (bool, error) {
+	var row map[string]any
+	if err := json.Unmarshal(data, &row); err != nil {
+		return false, err
+	}
+	rowSource, ok := row[schema.CqSourceNameColumn.Name].(string)
+	if !ok || rowSource != sourceName {
+		return false, nil
+	}
+	rowSyncTime, ok := row[schema.CqSyncTimeColumn.Name].(string)
+	if !ok {
+		return false, nil
+	}
+	t, err := time.Parse(syncTimeLayout, rowSyncTime)
+	if err != nil {
+		return false, err
+	}
+	return t.Before(syncTime.UTC()), nil
+}
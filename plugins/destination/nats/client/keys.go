@@ -0,0 +1,55 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// rowKey returns the key of the row, `<table>.<primary key value>...`, with the values escaped to valid key tokens.
+// Rows of tables without primary keys are keyed by a hash of their JSON.
+func rowKey(table string, record arrow.Record, cols []int, row int, data []byte) string {
+	tokens := make([]string, 0, len(cols)+1)
+	tokens = append(tokens, escapeToken(table))
+	for _, col := range cols {
+		tokens = append(tokens, escapeToken(record.Column(col).ValueStr(row)))
+	}
+	if len(cols) == 0 {
+		sum := sha256.Sum256(data)
+		tokens = append(tokens, hex.EncodeToString(sum[:]))
+	}
+	return strings.Join(tokens, ".")
+}
+
+// escapeToken escapes the characters not allowed in KV keys and subject tokens as `=XX`,
+// so that different values never share a token. The empty string is escaped as a lone `=`.
+func escapeToken(s string) string {
+	if s == "" {
+		return "="
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		switch {
+		case b >= 'a' && b <= 'z', b >= 'A' && b <= 'Z', b >= '0' && b <= '9', b == '-', b == '_':
+			sb.WriteByte(b)
+		default:
+			fmt.Fprintf(&sb, "=%02X", b)
+		}
+	}
+	return sb.String()
+}
+
+// msgID returns the deduplication ID of the row: its key and the sync time, so that only the duplicates
+// published in the same sync, e.g. on retries, are dropped.
+func msgID(table string, record arrow.Record, cols []int, row int) string {
+	id := rowKey(table, record, cols, row, nil)
+	if idx := record.Schema().FieldIndices(schema.CqSyncTimeColumn.Name); len(idx) > 0 {
+		id += "@" + record.Column(idx[0]).ValueStr(row)
+	}
+	return id
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	maxWaitTime = 3 * time.Second
+)
+
+// Read reads the rows of the table, which are collected first, as the JSON decoder is made for reading whole files.
+func (c *Client) Read(ctx context.Context, table *schema.Table, res chan<- arrow.Record) error {
+	var buf bytes.Buffer
+	add := func(data []byte) {
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if c.spec.Mode == ModeKV {
+		err := c.forEachEntry(ctx, table.Name, func(entry nats.KeyValueEntry) error {
+			add(entry.Value())
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	} else if err := c.readSubject(ctx, c.spec.subject(table.Name), add); err != nil {
+		return err
+	}
+	return c.encoder.Read(bytes.NewReader(buf.Bytes()), table, res)
+}
+
+// readSubject calls fn with the data of all the messages on the subject.
+func (c *Client) readSubject(ctx context.Context, subject string, fn func([]byte)) error {
+	sub, err := c.js.SubscribeSync(subject, nats.OrderedConsumer(), nats.DeliverAll())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = sub.Unsubscribe() }()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		msg, err := sub.NextMsg(maxWaitTime)
+		if errors.Is(err, nats.ErrTimeout) {
+			// there are no messages on the subject
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fn(msg.Data)
+		if meta, err := msg.Metadata(); err == nil && meta.NumPending == 0 {
+			return nil
+		}
+	}
+}
+
+// forEachEntry calls fn with the current entries of the table's keys in the bucket.
+func (c *Client) forEachEntry(ctx context.Context, table string, fn func(nats.KeyValueEntry) error) error {
+	w, err := c.kv.Watch(escapeToken(table)+".>", nats.IgnoreDeletes(), nats.Context(ctx))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = w.Stop() }()
+	for entry := range w.Updates() {
+		// a nil entry marks the end of the current entries, the following ones are updates
+		if entry == nil {
+			return nil
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
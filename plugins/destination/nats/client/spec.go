@@ -0,0 +1,104 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	ModeStream = "stream"
+	ModeKV     = "kv"
+
+	defaultStream        = "CLOUDQUERY"
+	defaultSubjectPrefix = "cq"
+	defaultBucket        = "cloudquery"
+
+	// every batch waits for the acks of all of its rows
+	defaultBatchSize = 1000
+)
+
+var (
+	// names of streams and buckets, as accepted by the server
+	nameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	// subject tokens, without wildcards
+	subjectPrefixRegex = regexp.MustCompile(`^[^.*>\s]+(\.[^.*>\s]+)*$`)
+)
+
+type Spec struct {
+	URL             string `json:"url,omitempty"`
+	CredentialsFile string `json:"credentials_file,omitempty"`
+	Token           string `json:"token,omitempty"`
+	Username        string `json:"username,omitempty"`
+	Password        string `json:"password,omitempty"`
+
+	// Mode is either ModeStream, publishing the rows to the `<subject_prefix>.<table>` subjects of Stream,
+	// or ModeKV, storing each row under its primary key in Bucket.
+	Mode string `json:"mode,omitempty"`
+
+	Stream        string `json:"stream,omitempty"`
+	SubjectPrefix string `json:"subject_prefix,omitempty"`
+	// Dedup sets the message IDs of the rows of tables with primary keys, so that JetStream drops the duplicates
+	// published within its duplicate window.
+	Dedup bool `json:"dedup,omitempty"`
+
+	Bucket string `json:"bucket,omitempty"`
+
+	BatchSize      int `json:"batch_size,omitempty"`
+	BatchSizeBytes int `json:"batch_size_bytes,omitempty"`
+}
+
+func (s *Spec) SetDefaults() {
+	if s.URL == "" {
+		s.URL = nats.DefaultURL
+	}
+	if s.Mode == "" {
+		s.Mode = ModeStream
+	}
+	if s.Stream == "" {
+		s.Stream = defaultStream
+	}
+	if s.SubjectPrefix == "" {
+		s.SubjectPrefix = defaultSubjectPrefix
+	}
+	if s.Bucket == "" {
+		s.Bucket = defaultBucket
+	}
+	if s.BatchSize == 0 {
+		s.BatchSize = defaultBatchSize
+	}
+}
+
+func (s *Spec) Validate() error {
+	switch s.Mode {
+	case "", ModeStream, ModeKV:
+	default:
+		return fmt.Errorf("unsupported `mode` %q, only %q and %q are supported", s.Mode, ModeStream, ModeKV)
+	}
+	if s.Stream != "" && !nameRegex.MatchString(s.Stream) {
+		return fmt.Errorf("invalid `stream` %q: only letters, digits, `-` and `_` are allowed", s.Stream)
+	}
+	if s.Bucket != "" && !nameRegex.MatchString(s.Bucket) {
+		return fmt.Errorf("invalid `bucket` %q: only letters, digits, `-` and `_` are allowed", s.Bucket)
+	}
+	if s.SubjectPrefix != "" && !subjectPrefixRegex.MatchString(s.SubjectPrefix) {
+		return fmt.Errorf("invalid `subject_prefix` %q: wildcards, whitespace and empty tokens are not allowed", s.SubjectPrefix)
+	}
+	if s.Mode == ModeKV && s.Dedup {
+		return fmt.Errorf("`dedup` is only supported in %q mode, rows are deduplicated by their keys in %q mode", ModeStream, ModeKV)
+	}
+	if s.CredentialsFile != "" && (s.Token != "" || s.Username != "") {
+		return fmt.Errorf("only one of `credentials_file`, `token` or `username` is allowed")
+	}
+	if s.Token != "" && s.Username != "" {
+		return fmt.Errorf("only one of `token` or `username` is allowed")
+	}
+	return nil
+}
+
+// subject returns the subject the rows of the table are published to.
+func (s *Spec) subject(table string) string {
+	return strings.Join([]string{s.SubjectPrefix, table}, ".")
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpec_Validate(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Give    Spec
+		WantErr bool
+	}{
+		{Name: "defaults", Give: Spec{}},
+		{Name: "kv", Give: Spec{Mode: ModeKV, Bucket: "inventory"}},
+		{Name: "unsupported mode", Give: Spec{Mode: "queue"}, WantErr: true},
+		{Name: "invalid stream", Give: Spec{Stream: "cq.stream"}, WantErr: true},
+		{Name: "invalid bucket", Give: Spec{Mode: ModeKV, Bucket: "my bucket"}, WantErr: true},
+		{Name: "nested subject prefix", Give: Spec{SubjectPrefix: "edge.cq"}},
+		{Name: "wildcard subject prefix", Give: Spec{SubjectPrefix: "cq.*"}, WantErr: true},
+		{Name: "kv dedup", Give: Spec{Mode: ModeKV, Dedup: true}, WantErr: true},
+		{Name: "token and username", Give: Spec{Token: "token", Username: "user"}, WantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Give.Validate()
+			if tc.WantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
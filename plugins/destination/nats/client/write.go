@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/nats-io/nats.go"
+)
+
+func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) error {
+	if err := c.writer.Write(ctx, msgs); err != nil {
+		return fmt.Errorf("failed to write messages: %w", err)
+	}
+	if err := c.writer.Flush(ctx); err != nil {
+		return fmt.Errorf("failed to flush writer: %w", err)
+	}
+	return nil
+}
+
+// WriteTableBatch publishes each row as a JSON message, either to the table subject and waiting for all of them to be
+// acknowledged, or to the row key in the bucket.
+func (c *Client) WriteTableBatch(ctx context.Context, name string, msgs message.WriteInserts) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	table := msgs[0].GetTable()
+	// the records have the columns of the table, so these are the indices of the primary key columns in them too
+	cols := table.PrimaryKeysIndexes()
+	if c.spec.Mode == ModeKV {
+		return c.putRows(ctx, name, msgs, cols)
+	}
+	dedup := c.spec.Dedup && len(cols) > 0
+
+	futures := make([]nats.PubAckFuture, 0, len(msgs))
+	for _, msg := range msgs {
+		rows, err := c.encodeRows(table, msg.Record)
+		if err != nil {
+			return err
+		}
+		for i, data := range rows {
+			m := nats.NewMsg(c.spec.subject(name))
+			if dedup {
+				m.Header.Set(nats.MsgIdHdr, msgID(name, msg.Record, cols, i))
+			}
+			m.Data = data
+			f, err := c.js.PublishMsgAsync(m)
+			if err != nil {
+				return fmt.Errorf("failed to publish row of table %s: %w", name, err)
+			}
+			futures = append(futures, f)
+		}
+	}
+
+	for _, f := range futures {
+		select {
+		case <-f.Ok():
+		case err := <-f.Err():
+			return fmt.Errorf("failed to publish row of table %s: %w", name, err)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// putRows stores each row under its key in the bucket.
+func (c *Client) putRows(ctx context.Context, name string, msgs message.WriteInserts, cols []int) error {
+	table := msgs[0].GetTable()
+	for _, msg := range msgs {
+		rows, err := c.encodeRows(table, msg.Record)
+		if err != nil {
+			return err
+		}
+		for i, data := range rows {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if _, err := c.kv.Put(rowKey(name, msg.Record, cols, i, data), data); err != nil {
+				return fmt.Errorf("failed to put row of table %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// encodeRows returns the JSON of each row of the record.
+func (c *Client) encodeRows(table *schema.Table, record arrow.Record) ([][]byte, error) {
+	var buf bytes.Buffer
+	if err := c.encoder.WriteTableBatchFile(&buf, table, []arrow.Record{record}); err != nil {
+		return nil, fmt.Errorf("failed to encode rows of table %s: %w", table.Name, err)
+	}
+	if buf.Len() == 0 {
+		return nil, nil
+	}
+	return bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n")), nil
+}
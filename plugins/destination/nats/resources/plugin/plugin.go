@@ -0,0 +1,6 @@
+package plugin
+
+var (
+	// Don't move this file to a different package, it's used by Go releaser to embed the version in the binary.
+	Version = "Development"
+)
@@ -2,11 +2,78 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
-// Migrate tables. Like mongo, neo4j does not have a schema, so this is a no-op.
-func (*Client) MigrateTables(context.Context, message.WriteMigrateTables) error {
-	return nil
+const (
+	dropConstraintCypher   = "DROP CONSTRAINT %s IF EXISTS"
+	dropIndexCypher        = "DROP INDEX %s IF EXISTS"
+	uniqueConstraintCypher = "CREATE CONSTRAINT %s IF NOT EXISTS FOR (n:%s) REQUIRE n.%s IS UNIQUE"
+	createIndexCypher      = "CREATE INDEX %s IF NOT EXISTS FOR (n:%s) ON (%s)"
+)
+
+// Migrate tables. Like mongo, neo4j does not have a schema, so only the constraints and indexes
+// used to merge nodes and create relationships are created.
+func (c *Client) MigrateTables(ctx context.Context, msgs message.WriteMigrateTables) error {
+	session := c.LoggedSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	for _, msg := range msgs {
+		for _, stmt := range c.migrateStatements(msg.Table, msg.MigrateForce) {
+			c.logger.Debug().Str("stmt", stmt).Msg("Executing statement")
+			if _, err := session.Run(ctx, stmt, nil); err != nil {
+				return fmt.Errorf("failed to migrate table %s: %w", msg.Table.Name, err)
+			}
+		}
+	}
+	return session.Close(ctx)
+}
+
+func (c *Client) migrateStatements(table *schema.Table, force bool) []string {
+	var stmts []string
+	label := quoteIdentifier(table.Name)
+
+	if table.Columns.Get(schema.CqIDColumn.Name) != nil || table.Columns.Get(schema.CqParentIDColumn.Name) != nil {
+		// not unique, as _cq_id may be duplicated in tables without primary keys
+		for _, col := range []string{schema.CqIDColumn.Name, schema.CqParentIDColumn.Name} {
+			stmts = append(stmts, fmt.Sprintf(createIndexCypher,
+				quoteIdentifier(strings.ToLower(resourceLabel)+col), quoteIdentifier(resourceLabel), "n."+quoteIdentifier(col)))
+		}
+	}
+
+	pks := table.PrimaryKeys()
+	pkName := quoteIdentifier(table.Name + "_cq_pk")
+	if force {
+		// the primary key may have changed. Dropping the constraint drops its index, so only a composite key index is left.
+		stmts = append(stmts, fmt.Sprintf(dropConstraintCypher, pkName), fmt.Sprintf(dropIndexCypher, pkName))
+	}
+	switch len(pks) {
+	case 0:
+	case 1:
+		stmts = append(stmts, fmt.Sprintf(uniqueConstraintCypher, pkName, label, quoteIdentifier(pks[0])))
+	default:
+		// composite uniqueness constraints aren't available in all editions, so composite keys are only indexed
+		stmts = append(stmts, fmt.Sprintf(createIndexCypher, pkName, label, propertyList(pks)))
+	}
+
+	for _, col := range c.relationshipColumns(table.Name) {
+		if len(pks) == 1 && pks[0] == col {
+			continue // already indexed by the constraint
+		}
+		stmts = append(stmts, fmt.Sprintf(createIndexCypher, quoteIdentifier(table.Name+"_"+col), label, propertyList([]string{col})))
+	}
+	return stmts
+}
+
+func propertyList(columns []string) string {
+	props := make([]string, len(columns))
+	for i, col := range columns {
+		props[i] = "n." + quoteIdentifier(col)
+	}
+	return strings.Join(props, ", ")
 }
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+const (
+	// resourceLabel is added to all nodes, so parents can be found by _cq_id without knowing their table
+	resourceLabel = "CQResource"
+	// childOfType is the type of the relationships from child table nodes to their parent nodes
+	childOfType = "CHILD_OF"
+
+	// Both relationship statements match the nodes on either side by a shared value, so the relationships are created
+	// whichever of the two tables is written first.
+	childOfCypher      = "UNWIND $values AS v MATCH (c:%[1]s {_cq_parent_id: v}) MATCH (p:%[1]s {_cq_id: v}) MERGE (c)-[:%[2]s]->(p)"
+	relationshipCypher = "UNWIND $values AS v MATCH (f:%s {%s: v}) MATCH (t:%s {%s: v}) MERGE (f)-[:%s]->(t)"
+	// staleRelationshipCypher removes relationships of nodes whose value changed since the relationship was created
+	staleRelationshipCypher = "UNWIND $values AS v MATCH (f:%s {%s: v})-[r:%s]->(t:%s) WHERE t.%s <> v DELETE r"
+)
+
+type statement struct {
+	cypher string
+	params map[string]any
+}
+
+// relationshipStatements returns the statements creating the relationships of the written rows.
+func (c *Client) relationshipStatements(table *schema.Table, rows []map[string]any) []statement {
+	var stmts []statement
+
+	var ids []any
+	if table.Columns.Get(schema.CqParentIDColumn.Name) != nil {
+		ids = append(ids, distinctValues(rows, schema.CqParentIDColumn.Name)...)
+	}
+	if table.Columns.Get(schema.CqIDColumn.Name) != nil {
+		ids = append(ids, distinctValues(rows, schema.CqIDColumn.Name)...)
+	}
+	if len(ids) > 0 {
+		stmts = append(stmts, statement{
+			cypher: fmt.Sprintf(childOfCypher, quoteIdentifier(resourceLabel), childOfType),
+			params: map[string]any{"values": ids},
+		})
+	}
+
+	for _, r := range c.spec.Relationships {
+		fromTable, fromColumn, toTable, toColumn := r.endpoints()
+		from, to := quoteIdentifier(fromTable), quoteIdentifier(toTable)
+		fromCol, toCol := quoteIdentifier(fromColumn), quoteIdentifier(toColumn)
+		if fromTable == table.Name {
+			values := distinctValues(rows, fromColumn)
+			if len(values) > 0 {
+				stmts = append(stmts,
+					statement{
+						cypher: fmt.Sprintf(staleRelationshipCypher, from, fromCol, r.Type, to, toCol),
+						params: map[string]any{"values": values},
+					},
+					statement{
+						cypher: fmt.Sprintf(relationshipCypher, from, fromCol, to, toCol, r.Type),
+						params: map[string]any{"values": values},
+					},
+				)
+			}
+		}
+		if toTable == table.Name {
+			values := distinctValues(rows, toColumn)
+			if len(values) > 0 {
+				stmts = append(stmts, statement{
+					cypher: fmt.Sprintf(relationshipCypher, from, fromCol, to, toCol, r.Type),
+					params: map[string]any{"values": values},
+				})
+			}
+		}
+	}
+	return stmts
+}
+
+// relationshipColumns returns the columns of the table used in relationships, which should be indexed.
+func (c *Client) relationshipColumns(tableName string) []string {
+	var columns []string
+	for _, r := range c.spec.Relationships {
+		fromTable, fromColumn, toTable, toColumn := r.endpoints()
+		if fromTable == tableName {
+			columns = append(columns, fromColumn)
+		}
+		if toTable == tableName {
+			columns = append(columns, toColumn)
+		}
+	}
+	return columns
+}
+
+func distinctValues(rows []map[string]any, column string) []any {
+	seen := make(map[string]struct{}, len(rows))
+	values := make([]any, 0, len(rows))
+	for _, row := range rows {
+		v, ok := row[column]
+		if !ok || v == nil {
+			continue
+		}
+		key := fmt.Sprintf("%T:%v", v, v)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		values = append(values, v)
+	}
+	return values
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelationshipStatements(t *testing.T) {
+	c := &Client{spec: &Spec{Relationships: []RelationshipSpec{
+		{From: "aws_ec2_instances.vpc_id", To: "aws_ec2_vpcs.vpc_id", Type: "IN_VPC"},
+	}}}
+	instances := &schema.Table{
+		Name: "aws_ec2_instances",
+		Columns: schema.ColumnList{
+			schema.CqIDColumn,
+			schema.CqParentIDColumn,
+			{Name: "vpc_id", Type: arrow.BinaryTypes.String},
+		},
+	}
+	rows := []map[string]any{
+		{"_cq_id": "a", "_cq_parent_id": "p", "vpc_id": "vpc-1"},
+		{"_cq_id": "b", "_cq_parent_id": "p", "vpc_id": "vpc-1"},
+		{"_cq_id": "c", "_cq_parent_id": "p"},
+	}
+
+	stmts := c.relationshipStatements(instances, rows)
+	require.Len(t, stmts, 3)
+	require.Equal(t, "UNWIND $values AS v MATCH (c:`CQResource` {_cq_parent_id: v}) MATCH (p:`CQResource` {_cq_id: v}) MERGE (c)-[:CHILD_OF]->(p)", stmts[0].cypher)
+	require.Equal(t, []any{"p", "a", "b", "c"}, stmts[0].params["values"])
+	require.Equal(t, "UNWIND $values AS v MATCH (f:`aws_ec2_instances` {`vpc_id`: v})-[r:IN_VPC]->(t:`aws_ec2_vpcs`) WHERE t.`vpc_id` <> v DELETE r", stmts[1].cypher)
+	require.Equal(t, "UNWIND $values AS v MATCH (f:`aws_ec2_instances` {`vpc_id`: v}) MATCH (t:`aws_ec2_vpcs` {`vpc_id`: v}) MERGE (f)-[:IN_VPC]->(t)", stmts[2].cypher)
+	require.Equal(t, []any{"vpc-1"}, stmts[2].params["values"])
+
+	vpcs := &schema.Table{
+		Name:    "aws_ec2_vpcs",
+		Columns: schema.ColumnList{{Name: "vpc_id", Type: arrow.BinaryTypes.String, PrimaryKey: true}},
+	}
+	stmts = c.relationshipStatements(vpcs, []map[string]any{{"vpc_id": "vpc-1"}})
+	require.Len(t, stmts, 1)
+	require.Equal(t, stmts[0].cypher, "UNWIND $values AS v MATCH (f:`aws_ec2_instances` {`vpc_id`: v}) MATCH (t:`aws_ec2_vpcs` {`vpc_id`: v}) MERGE (f)-[:IN_VPC]->(t)")
+
+	require.Equal(t, []string{
+		"CREATE CONSTRAINT `aws_ec2_vpcs_cq_pk` IF NOT EXISTS FOR (n:`aws_ec2_vpcs`) REQUIRE n.`vpc_id` IS UNIQUE",
+	}, c.migrateStatements(vpcs, false))
+	require.Equal(t, []string{
+		"CREATE INDEX `cqresource_cq_id` IF NOT EXISTS FOR (n:`CQResource`) ON (n.`_cq_id`)",
+		"CREATE INDEX `cqresource_cq_parent_id` IF NOT EXISTS FOR (n:`CQResource`) ON (n.`_cq_parent_id`)",
+		"DROP CONSTRAINT `aws_ec2_instances_cq_pk` IF EXISTS",
+		"DROP INDEX `aws_ec2_instances_cq_pk` IF EXISTS",
+		"CREATE INDEX `aws_ec2_instances_vpc_id` IF NOT EXISTS FOR (n:`aws_ec2_instances`) ON (n.`vpc_id`)",
+	}, c.migrateStatements(instances, true))
+}
+
+func TestRelationshipSpecValidate(t *testing.T) {
+	require.NoError(t, RelationshipSpec{From: "a.b", To: "c.d", Type: "REL_1"}.Validate())
+	require.Error(t, RelationshipSpec{From: "a", To: "c.d", Type: "REL"}.Validate())
+	require.Error(t, RelationshipSpec{From: "a.b", To: "c.d.e", Type: "REL"}.Validate())
+	require.Error(t, RelationshipSpec{From: "a.b", To: "c.d", Type: "IN VPC"}.Validate())
+}
@@ -2,8 +2,12 @@ package client
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 )
 
+var reIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 type Spec struct {
 	ConnectionString string `json:"connection_string"`
 	Username         string `json:"username"`
@@ -11,6 +15,36 @@ type Spec struct {
 
 	BatchSize      int `json:"batch_size"`
 	BatchSizeBytes int `json:"batch_size_bytes"`
+
+	Relationships []RelationshipSpec `json:"relationships"`
+}
+
+// RelationshipSpec declares a relationship between the nodes of two tables that have the same value in the given columns,
+// e.g. `{from: aws_ec2_instances.vpc_id, to: aws_ec2_vpcs.vpc_id, type: IN_VPC}`.
+type RelationshipSpec struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// endpoints returns the tables and columns of the relationship. It assumes the spec was validated.
+func (r RelationshipSpec) endpoints() (fromTable, fromColumn, toTable, toColumn string) {
+	fromTable, fromColumn, _ = strings.Cut(r.From, ".")
+	toTable, toColumn, _ = strings.Cut(r.To, ".")
+	return fromTable, fromColumn, toTable, toColumn
+}
+
+func (r RelationshipSpec) Validate() error {
+	for field, v := range map[string]string{"from": r.From, "to": r.To} {
+		table, column, ok := strings.Cut(v, ".")
+		if !ok || table == "" || column == "" || strings.Contains(column, ".") {
+			return fmt.Errorf("relationship %s %q must be in the form table.column", field, v)
+		}
+	}
+	if !reIdentifier.MatchString(r.Type) {
+		return fmt.Errorf("relationship type %q must only contain letters, digits and underscores", r.Type)
+	}
+	return nil
 }
 
 func (s *Spec) SetDefaults() {
@@ -26,5 +60,10 @@ func (s *Spec) Validate() error {
 	if s.ConnectionString == "" {
 		return fmt.Errorf("connection_string is required")
 	}
+	for _, r := range s.Relationships {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
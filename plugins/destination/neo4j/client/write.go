@@ -33,6 +33,8 @@ func (c *Client) WriteTableBatch(ctx context.Context, tableName string, msgs mes
 	if len(pks) == 0 {
 		sb.WriteString("UNWIND $rows AS row CREATE (t:")
 		sb.WriteString(tableName)
+		sb.WriteString(":")
+		sb.WriteString(resourceLabel)
 		sb.WriteString(") SET t = row")
 	} else {
 		sb.WriteString("UNWIND $rows AS row MERGE (t:")
@@ -46,13 +48,24 @@ func (c *Client) WriteTableBatch(ctx context.Context, tableName string, msgs mes
 			sb.WriteString(": row.")
 			sb.WriteString(column)
 		}
-		sb.WriteString("}) SET t = row")
+		// the label is set separately, so nodes written before it was introduced are still matched
+		sb.WriteString("}) SET t = row, t:")
+		sb.WriteString(resourceLabel)
 	}
 	stmt := sb.String()
+	relationshipStmts := c.relationshipStatements(table, rows)
 	c.logger.Debug().Str("stmt", stmt).Any("rows", rows).Msg("Executing statement")
 	if _, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-		_, err := tx.Run(ctx, stmt, map[string]any{"rows": rows})
-		return nil, err
+		if _, err := tx.Run(ctx, stmt, map[string]any{"rows": rows}); err != nil {
+			return nil, err
+		}
+		for _, s := range relationshipStmts {
+			c.logger.Debug().Str("stmt", s.cypher).Msg("Executing statement")
+			if _, err := tx.Run(ctx, s.cypher, s.params); err != nil {
+				return nil, fmt.Errorf("failed to create relationships: %w", err)
+			}
+		}
+		return nil, nil
 	}); err != nil {
 		return err
 	}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/writers/batchwriter"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+type Client struct {
+	plugin.UnimplementedSource
+
+	logger zerolog.Logger
+	spec   *Spec
+
+	client *redis.Client
+	// encoder writes and reads the rows as JSON in FormatJSON
+	encoder *filetypes.Client
+	writer  *batchwriter.BatchWriter
+}
+
+func New(ctx context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewClientOptions) (plugin.Client, error) {
+	c := &Client{
+		logger: logger.With().Str("module", "dest-redis").Logger(),
+	}
+	if opts.NoConnection {
+		return c, nil
+	}
+
+	if err := json.Unmarshal(spec, &c.spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Redis spec: %w", err)
+	}
+	if err := c.spec.Validate(); err != nil {
+		return nil, err
+	}
+	c.spec.SetDefaults()
+
+	redisOpts, err := redis.ParseURL(c.spec.ConnectionString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse connection_string: %w", err)
+	}
+	c.client = redis.NewClient(redisOpts)
+	if err := c.client.Ping(ctx).Err(); err != nil {
+		_ = c.client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	if c.spec.Format == FormatJSON {
+		c.encoder, err = filetypes.NewClient(&filetypes.FileSpec{Format: filetypes.FormatTypeJSON})
+		if err != nil {
+			_ = c.client.Close()
+			return nil, fmt.Errorf("failed to create filetypes client: %w", err)
+		}
+	}
+
+	writerOpts := []batchwriter.Option{batchwriter.WithLogger(c.logger), batchwriter.WithBatchSize(c.spec.BatchSize)}
+	if c.spec.BatchSizeBytes > 0 {
+		// otherwise the batch writer's default applies
+		writerOpts = append(writerOpts, batchwriter.WithBatchSizeBytes(c.spec.BatchSizeBytes))
+	}
+	c.writer, err = batchwriter.New(c, writerOpts...)
+	if err != nil {
+		_ = c.client.Close()
+		return nil, fmt.Errorf("failed to create batch writer: %w", err)
+	}
+	return c, nil
+}
+
+func (*Client) MigrateTables(context.Context, message.WriteMigrateTables) error {
+	// rows are stored schemaless, so there's nothing to migrate
+	return nil
+}
+
+func (c *Client) Close(ctx context.Context) error {
+	if c.client == nil {
+		return nil
+	}
+	if err := c.writer.Close(ctx); err != nil {
+		_ = c.client.Close()
+		return fmt.Errorf("failed to close batch writer: %w", err)
+	}
+	return c.client.Close()
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/cloudquery/plugin-sdk/v4/configtype"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// connect returns a client of the miniredis server.
+func connect(t *testing.T, s *miniredis.Miniredis, spec Spec) *Client {
+	t.Helper()
+	spec.ConnectionString = "redis://" + s.Addr()
+	b, err := json.Marshal(spec)
+	require.NoError(t, err)
+	c, err := New(context.Background(), zerolog.Nop(), b, plugin.NewClientOptions{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, c.Close(context.Background())) })
+	return c.(*Client)
+}
+
+func TestPlugin(t *testing.T) {
+	for _, format := range []string{FormatHash, FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			ctx := context.Background()
+			p := plugin.NewPlugin("redis", "development", New)
+			b, err := json.Marshal(&Spec{ConnectionString: "redis://" + miniredis.RunT(t).Addr(), Format: format})
+			require.NoError(t, err)
+			require.NoError(t, p.Init(ctx, b, plugin.NewClientOptions{}))
+			t.Cleanup(func() { require.NoError(t, p.Close(ctx)) })
+			plugin.TestWriterSuiteRunner(t,
+				p,
+				plugin.WriterTestSuiteTests{
+					// rows are stored under their keys, so inserting a row again replaces it
+					SkipInsert:  true,
+					SkipMigrate: true,
+				},
+			)
+		})
+	}
+}
+
+// regionsTable is keyed by the id, and indexed by the region in the tests.
+var regionsTable = &schema.Table{
+	Name: "test_table",
+	Columns: schema.ColumnList{
+		schema.CqSourceNameColumn,
+		schema.CqSyncTimeColumn,
+		{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true},
+		{Name: "region", Type: arrow.BinaryTypes.String},
+	},
+}
+
+// regionsRecord returns a record of regionsTable with a row per id => region pair.
+func regionsRecord(syncTime time.Time, regions map[string]string) arrow.Record {
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, regionsTable.ToArrowSchema())
+	defer bldr.Release()
+	for id, region := range regions {
+		bldr.Field(0).(*array.StringBuilder).Append("source")
+		bldr.Field(1).(*array.TimestampBuilder).AppendTime(syncTime)
+		bldr.Field(2).(*array.StringBuilder).Append(id)
+		bldr.Field(3).(*array.StringBuilder).Append(region)
+	}
+	return bldr.NewRecord()
+}
+
+func members(t *testing.T, s *miniredis.Miniredis, key string) []string {
+	t.Helper()
+	if !s.Exists(key) {
+		return nil
+	}
+	m, err := s.Members(key)
+	require.NoError(t, err)
+	return m
+}
+
+func TestIndexColumns(t *testing.T) {
+	for _, format := range []string{FormatHash, FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			c := connect(t, s, Spec{
+				Format: format,
+				Tables: []TableSpec{{Tables: []string{"test_*"}, IndexColumns: []string{"region"}}},
+			})
+			ctx := context.Background()
+
+			syncTime := time.Now().UTC()
+			record := regionsRecord(syncTime, map[string]string{"a": "us-east-1", "b": "us-east-1"})
+			require.NoError(t, c.WriteTableBatch(ctx, regionsTable.Name, message.WriteInserts{{Record: record}}))
+			require.ElementsMatch(t, []string{"test_table:a", "test_table:b"}, members(t, s, "_cq:index:test_table:region:us-east-1"))
+
+			// the row moving to another region is removed from the set of the previous one
+			record = regionsRecord(syncTime, map[string]string{"b": "eu-west-1"})
+			require.NoError(t, c.WriteTableBatch(ctx, regionsTable.Name, message.WriteInserts{{Record: record}}))
+			require.Equal(t, []string{"test_table:a"}, members(t, s, "_cq:index:test_table:region:us-east-1"))
+			require.Equal(t, []string{"test_table:b"}, members(t, s, "_cq:index:test_table:region:eu-west-1"))
+
+			// a row written twice in a batch is indexed by its last values only
+			first := regionsRecord(syncTime, map[string]string{"a": "ap-south-1"})
+			last := regionsRecord(syncTime, map[string]string{"a": "eu-west-1"})
+			require.NoError(t, c.WriteTableBatch(ctx, regionsTable.Name, message.WriteInserts{{Record: first}, {Record: last}}))
+			require.Empty(t, members(t, s, "_cq:index:test_table:region:us-east-1"))
+			require.Empty(t, members(t, s, "_cq:index:test_table:region:ap-south-1"))
+			require.ElementsMatch(t, []string{"test_table:a", "test_table:b"}, members(t, s, "_cq:index:test_table:region:eu-west-1"))
+		})
+	}
+}
+
+func TestDeleteStale(t *testing.T) {
+	s := miniredis.RunT(t)
+	c := connect(t, s, Spec{
+		Tables: []TableSpec{{Tables: []string{"test_table"}, IndexColumns: []string{"region"}}},
+	})
+	ctx := context.Background()
+
+	syncTime := time.Now().UTC()
+	record := regionsRecord(syncTime, map[string]string{"a": "us-east-1", "b": "us-east-1"})
+	require.NoError(t, c.WriteTableBatch(ctx, regionsTable.Name, message.WriteInserts{{Record: record}}))
+	next := syncTime.Add(time.Second)
+	record = regionsRecord(next, map[string]string{"a": "us-east-1"})
+	require.NoError(t, c.WriteTableBatch(ctx, regionsTable.Name, message.WriteInserts{{Record: record}}))
+	require.NoError(t, c.DeleteStale(ctx, message.WriteDeleteStales{{TableName: regionsTable.Name, SourceName: "source", SyncTime: next}}))
+
+	require.False(t, s.Exists("test_table:b"))
+	require.True(t, s.Exists("test_table:a"))
+	require.Equal(t, []string{"test_table:a"}, members(t, s, "_cq:index:test_table:region:us-east-1"))
+	synced, err := s.ZMembers("_cq:sync:test_table:source")
+	require.NoError(t, err)
+	require.Equal(t, []string{"test_table:a"}, synced)
+}
+
+func TestTTL(t *testing.T) {
+	s := miniredis.RunT(t)
+	c := connect(t, s, Spec{
+		Tables: []TableSpec{
+			{Tables: []string{"test_table"}, IndexColumns: []string{"region"}, TTL: configtype.NewDuration(time.Hour)},
+		},
+	})
+	ctx := context.Background()
+
+	record := regionsRecord(time.Now().UTC(), map[string]string{"a": "us-east-1"})
+	require.NoError(t, c.WriteTableBatch(ctx, regionsTable.Name, message.WriteInserts{{Record: record}}))
+	for _, key := range []string{"test_table:a", "_cq:index:test_table:region:us-east-1", "_cq:sync:test_table:source"} {
+		require.Equal(t, time.Hour, s.TTL(key), key)
+	}
+
+	s.FastForward(time.Hour)
+	require.Empty(t, s.Keys())
+}
+
+func TestRowKey(t *testing.T) {
+	require.Equal(t, "test_table:arn:aws:ec2:us-east-1", rowKey("test_table", []string{"arn:aws:ec2:us-east-1"}))
+	require.Equal(t, `test_table:a\:b:c`, rowKey("test_table", []string{"a:b", "c"}))
+	require.Equal(t, `test_table:a:b\:c`, rowKey("test_table", []string{"a", "b:c"}))
+	require.Equal(t, `test_table:a\\:\:b`, rowKey("test_table", []string{`a\`, ":b"}))
+}
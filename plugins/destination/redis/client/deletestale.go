@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/redis/go-redis/v9"
+)
+
+// DeleteStale deletes the rows written by the source before the sync time, which are tracked in the sync sets.
+func (c *Client) DeleteStale(ctx context.Context, msgs message.WriteDeleteStales) error {
+	for _, msg := range msgs {
+		if err := c.deleteStale(ctx, msg); err != nil {
+			return fmt.Errorf("failed to delete stale rows of table %s: %w", msg.TableName, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) deleteStale(ctx context.Context, msg *message.WriteDeleteStale) error {
+	sync := syncKey(msg.TableName, msg.SourceName)
+	keys, err := c.client.ZRangeByScore(ctx, sync, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: "(" + strconv.FormatInt(msg.SyncTime.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	ts := c.spec.tableSpec(msg.TableName)
+	var indexed []map[string]string
+	if len(ts.IndexColumns) > 0 {
+		if indexed, err = c.indexedValues(ctx, keys, ts.IndexColumns); err != nil {
+			return err
+		}
+	}
+
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			pipe.Del(ctx, key)
+			for _, col := range ts.IndexColumns {
+				if value, ok := indexed[i][col]; ok {
+					pipe.SRem(ctx, indexKey(msg.TableName, col, value), key)
+				}
+			}
+			pipe.ZRem(ctx, sync, key)
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/redis/go-redis/v9"
+)
+
+const scanCount = 1000
+
+// Read reads the rows of the table, ordered by key.
+func (c *Client) Read(ctx context.Context, table *schema.Table, res chan<- arrow.Record) error {
+	keys, err := c.tableKeys(ctx, table.Name)
+	if err != nil {
+		return fmt.Errorf("failed to list keys of table %s: %w", table.Name, err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if c.spec.Format == FormatJSON {
+		return c.readJSON(ctx, table, keys, res)
+	}
+	return c.readHashes(ctx, table, keys, res)
+}
+
+// readJSON reads the rows, which are collected first, as the JSON decoder is made for reading whole files.
+func (c *Client) readJSON(ctx context.Context, table *schema.Table, keys []string, res chan<- arrow.Record) error {
+	cmds := make([]*redis.StringCmd, len(keys))
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, cmd := range cmds {
+		// the key may have expired since it was listed
+		if errors.Is(cmd.Err(), redis.Nil) {
+			continue
+		}
+		buf.WriteString(cmd.Val())
+		buf.WriteByte('\n')
+	}
+	return c.encoder.Read(bytes.NewReader(buf.Bytes()), table, res)
+}
+
+func (c *Client) readHashes(ctx context.Context, table *schema.Table, keys []string, res chan<- arrow.Record) error {
+	cmds := make([]*redis.MapStringStringCmd, len(keys))
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.HGetAll(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sc := table.ToArrowSchema()
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, sc)
+	defer bldr.Release()
+	for _, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			continue
+		}
+		for i, f := range sc.Fields() {
+			value, ok := fields[f.Name]
+			if !ok {
+				bldr.Field(i).AppendNull()
+				continue
+			}
+			if err := bldr.Field(i).AppendValueFromString(value); err != nil {
+				return fmt.Errorf("failed to read column %s of table %s: %w", f.Name, table.Name, err)
+			}
+		}
+	}
+	res <- bldr.NewRecord()
+	return nil
+}
+
+// tableKeys returns the keys of the rows of the table, sorted.
+func (c *Client) tableKeys(ctx context.Context, table string) ([]string, error) {
+	var keys []string
+	iter := c.client.Scan(ctx, 0, escapePattern(table)+":*", scanCount).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// escapePattern escapes the special characters of glob-style patterns.
+func escapePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`).Replace(s)
+}
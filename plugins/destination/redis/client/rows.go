@@ -0,0 +1,149 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// metaPrefix is the prefix of the keys maintained by the plugin besides the rows.
+const metaPrefix = "_cq:"
+
+// row is a row of a record prepared for writing.
+type row struct {
+	key string
+	// fields are the non-null column values as strings: the hash fields in FormatHash, and the values indexed in both formats
+	fields map[string]string
+	// data is the JSON of the row in FormatJSON
+	data []byte
+
+	// sourceName and syncTime are set if the table has the _cq_source_name and _cq_sync_time columns
+	sourceName string
+	syncTime   time.Time
+}
+
+// keyValueEscaper escapes the separator in the values of composite primary keys.
+var keyValueEscaper = strings.NewReplacer(`\`, `\\`, ":", `\:`)
+
+// rowKey returns the key of the row: `<table>:<primary key value>...`, or `<table>:<hash of the row>` for tables without primary keys.
+// Backslashes and colons in the values of composite primary keys are escaped with a backslash, so that the keys of different rows
+// can't collide. Single values are kept as they are.
+func rowKey(table string, values []string) string {
+	if len(values) == 1 {
+		return table + ":" + values[0]
+	}
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = keyValueEscaper.Replace(v)
+	}
+	return table + ":" + strings.Join(escaped, ":")
+}
+
+// indexKey returns the key of the set of the keys of the rows having the value in the column.
+func indexKey(table, column, value string) string {
+	return metaPrefix + "index:" + table + ":" + column + ":" + value
+}
+
+// syncKey returns the key of the sorted set of the keys of the rows written by the source, scored by their sync time.
+func syncKey(table, sourceName string) string {
+	return metaPrefix + "sync:" + table + ":" + sourceName
+}
+
+func (c *Client) rows(table *schema.Table, record arrow.Record) ([]row, error) {
+	var lines [][]byte
+	if c.spec.Format == FormatJSON {
+		var buf bytes.Buffer
+		if err := c.encoder.WriteTableBatchFile(&buf, table, []arrow.Record{record}); err != nil {
+			return nil, fmt.Errorf("failed to encode rows of table %s: %w", table.Name, err)
+		}
+		lines = bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	}
+
+	sc := record.Schema()
+	// the records have the columns of the table, so these are the indices of the primary key columns in them too
+	cols := table.PrimaryKeysIndexes()
+	sourceNames, _ := columnByName(record, schema.CqSourceNameColumn.Name).(*array.String)
+	syncTimes, _ := columnByName(record, schema.CqSyncTimeColumn.Name).(*array.Timestamp)
+
+	rows := make([]row, record.NumRows())
+	for i := range rows {
+		r := &rows[i]
+		if lines != nil {
+			r.data = lines[i]
+			fields, err := jsonFields(r.data)
+			if err != nil {
+				return nil, err
+			}
+			r.fields = fields
+		} else {
+			r.fields = make(map[string]string, len(sc.Fields()))
+			for j, f := range sc.Fields() {
+				if col := record.Column(j); col.IsValid(i) {
+					r.fields[f.Name] = col.ValueStr(i)
+				}
+			}
+		}
+		r.key = rowKey(table.Name, keyValues(record, cols, i))
+
+		if sourceNames != nil && syncTimes != nil && sourceNames.IsValid(i) && syncTimes.IsValid(i) {
+			r.sourceName = sourceNames.Value(i)
+			r.syncTime = syncTimes.Value(i).ToTime(syncTimes.DataType().(*arrow.TimestampType).Unit)
+		}
+	}
+	return rows, nil
+}
+
+func keyValues(record arrow.Record, cols []int, i int) []string {
+	if len(cols) == 0 {
+		h := sha256.New()
+		for _, col := range record.Columns() {
+			h.Write([]byte(col.ValueStr(i)))
+			h.Write([]byte{0})
+		}
+		return []string{hex.EncodeToString(h.Sum(nil))}
+	}
+	values := make([]string, len(cols))
+	for j, col := range cols {
+		values[j] = record.Column(col).ValueStr(i)
+	}
+	return values
+}
+
+func columnByName(record arrow.Record, name string) arrow.Array {
+	indices := record.Schema().FieldIndices(name)
+	if len(indices) == 0 {
+		return nil
+	}
+	return record.Column(indices[0])
+}
+
+// jsonFields returns the non-null values of the JSON row as strings: strings as they are, and other values as JSON.
+func jsonFields(data []byte) (map[string]string, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to decode row: %w", err)
+	}
+	fields := make(map[string]string, len(values))
+	for name, v := range values {
+		switch {
+		case bytes.Equal(v, []byte("null")):
+		case len(v) > 0 && v[0] == '"':
+			var s string
+			if err := json.Unmarshal(v, &s); err != nil {
+				return nil, fmt.Errorf("failed to decode row: %w", err)
+			}
+			fields[name] = s
+		default:
+			fields[name] = string(v)
+		}
+	}
+	return fields, nil
+}
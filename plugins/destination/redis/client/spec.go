@@ -0,0 +1,83 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/cloudquery/plugin-sdk/v4/configtype"
+	"github.com/cloudquery/plugin-sdk/v4/glob"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	FormatHash = "hash"
+	FormatJSON = "json"
+
+	// every batch is written in a single transaction
+	defaultBatchSize = 1000
+)
+
+type Spec struct {
+	ConnectionString string `json:"connection_string"`
+
+	// Format is either FormatHash, storing each row as a hash with a field per non-null column,
+	// or FormatJSON, storing each row as a JSON string.
+	Format string      `json:"format,omitempty"`
+	Tables []TableSpec `json:"tables,omitempty"`
+
+	BatchSize      int `json:"batch_size,omitempty"`
+	BatchSizeBytes int `json:"batch_size_bytes,omitempty"`
+}
+
+// TableSpec sets the index sets and the expiry of the rows of the tables matching any of the patterns.
+type TableSpec struct {
+	Tables []string `json:"tables"`
+	// IndexColumns are the columns with a set of the keys of the rows per value
+	IndexColumns []string `json:"index_columns,omitempty"`
+	// TTL is the time after which the keys of the rows expire, unless they are written again
+	TTL configtype.Duration `json:"ttl,omitempty"`
+}
+
+func (s *Spec) SetDefaults() {
+	if s.Format == "" {
+		s.Format = FormatHash
+	}
+	if s.BatchSize == 0 {
+		s.BatchSize = defaultBatchSize
+	}
+}
+
+func (s *Spec) Validate() error {
+	if s.ConnectionString == "" {
+		return fmt.Errorf("connection_string is required")
+	}
+	if _, err := redis.ParseURL(s.ConnectionString); err != nil {
+		return fmt.Errorf("invalid connection_string: %w", err)
+	}
+	switch s.Format {
+	case "", FormatHash, FormatJSON:
+	default:
+		return fmt.Errorf("unsupported format %q, only %q and %q are supported", s.Format, FormatHash, FormatJSON)
+	}
+	for i, t := range s.Tables {
+		if len(t.Tables) == 0 {
+			return fmt.Errorf("tables[%d]: tables are required", i)
+		}
+		if t.TTL.Duration() < 0 {
+			return fmt.Errorf("tables[%d]: ttl must not be negative", i)
+		}
+	}
+	return nil
+}
+
+// tableSpec returns the first `tables` entry matching the table.
+// The rows of tables matching no entry are neither indexed nor expired.
+func (s *Spec) tableSpec(table string) TableSpec {
+	for _, t := range s.Tables {
+		for _, pattern := range t.Tables {
+			if glob.Glob(pattern, table) {
+				return t
+			}
+		}
+	}
+	return TableSpec{}
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudquery/plugin-sdk/v4/configtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpec_Validate(t *testing.T) {
+	const connectionString = "redis://localhost:6379/0"
+	cases := []struct {
+		Name    string
+		Give    Spec
+		WantErr bool
+	}{
+		{Name: "defaults", Give: Spec{ConnectionString: connectionString}},
+		{Name: "json", Give: Spec{ConnectionString: connectionString, Format: FormatJSON}},
+		{Name: "missing connection string", Give: Spec{}, WantErr: true},
+		{Name: "invalid connection string", Give: Spec{ConnectionString: "localhost:6379"}, WantErr: true},
+		{Name: "unsupported format", Give: Spec{ConnectionString: connectionString, Format: "csv"}, WantErr: true},
+		{
+			Name: "tables",
+			Give: Spec{ConnectionString: connectionString, Tables: []TableSpec{
+				{Tables: []string{"aws_ec2_*"}, IndexColumns: []string{"region"}, TTL: configtype.NewDuration(time.Hour)},
+			}},
+		},
+		{Name: "missing table patterns", Give: Spec{ConnectionString: connectionString, Tables: []TableSpec{{}}}, WantErr: true},
+		{
+			Name: "negative ttl",
+			Give: Spec{ConnectionString: connectionString, Tables: []TableSpec{
+				{Tables: []string{"*"}, TTL: configtype.NewDuration(-time.Hour)},
+			}},
+			WantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Give.Validate()
+			if tc.WantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSpec_TableSpec(t *testing.T) {
+	s := Spec{Tables: []TableSpec{
+		{Tables: []string{"aws_ec2_instances"}, IndexColumns: []string{"instance_type"}},
+		{Tables: []string{"aws_*"}, IndexColumns: []string{"region"}},
+	}}
+	require.Equal(t, []string{"instance_type"}, s.tableSpec("aws_ec2_instances").IndexColumns)
+	require.Equal(t, []string{"region"}, s.tableSpec("aws_s3_buckets").IndexColumns)
+	require.Empty(t, s.tableSpec("gcp_projects").IndexColumns)
+}
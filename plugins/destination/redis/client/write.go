@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/redis/go-redis/v9"
+)
+
+func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) error {
+	if err := c.writer.Write(ctx, msgs); err != nil {
+		return err
+	}
+	return c.writer.Flush(ctx)
+}
+
+// WriteTableBatch writes the rows under their keys, replacing the previous ones, and updates the index and sync sets.
+func (c *Client) WriteTableBatch(ctx context.Context, name string, msgs message.WriteInserts) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	table := msgs[0].GetTable()
+	var rows []row
+	for _, msg := range msgs {
+		r, err := c.rows(table, msg.Record)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, r...)
+	}
+	rows = lastRows(rows)
+
+	ts := c.spec.tableSpec(name)
+	var previous []map[string]string
+	if len(ts.IndexColumns) > 0 {
+		keys := make([]string, len(rows))
+		for i, r := range rows {
+			keys[i] = r.key
+		}
+		var err error
+		if previous, err = c.indexedValues(ctx, keys, ts.IndexColumns); err != nil {
+			return fmt.Errorf("failed to get indexed values of table %s: %w", name, err)
+		}
+	}
+
+	ttl := ts.TTL.Duration()
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		expire := func(key string) {
+			if ttl > 0 {
+				pipe.Expire(ctx, key, ttl)
+			}
+		}
+		for i, r := range rows {
+			if c.spec.Format == FormatJSON {
+				pipe.Set(ctx, r.key, r.data, ttl)
+			} else {
+				pipe.Del(ctx, r.key)
+				if len(r.fields) > 0 {
+					pipe.HSet(ctx, r.key, r.fields)
+					expire(r.key)
+				}
+			}
+
+			for _, col := range ts.IndexColumns {
+				old, hadOld := previous[i][col]
+				value, ok := r.fields[col]
+				if hadOld && (!ok || old != value) {
+					pipe.SRem(ctx, indexKey(name, col, old), r.key)
+				}
+				if ok {
+					key := indexKey(name, col, value)
+					pipe.SAdd(ctx, key, r.key)
+					expire(key)
+				}
+			}
+
+			if !r.syncTime.IsZero() {
+				key := syncKey(name, r.sourceName)
+				pipe.ZAdd(ctx, key, redis.Z{Score: float64(r.syncTime.UnixMilli()), Member: r.key})
+				expire(key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write rows of table %s: %w", name, err)
+	}
+	return nil
+}
+
+// lastRows returns the rows with distinct keys, keeping the last row of each key.
+// Index removals are computed from the values stored before the batch, so every key is written once per batch.
+func lastRows(rows []row) []row {
+	last := make(map[string]int, len(rows))
+	for i, r := range rows {
+		last[r.key] = i
+	}
+	if len(last) == len(rows) {
+		return rows
+	}
+	res := make([]row, 0, len(last))
+	for i, r := range rows {
+		if last[r.key] == i {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// indexedValues returns the current values of the columns of the rows stored under the keys.
+// The values of missing rows are empty.
+func (c *Client) indexedValues(ctx context.Context, keys []string, columns []string) ([]map[string]string, error) {
+	cmds := make([]redis.Cmder, len(keys))
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			if c.spec.Format == FormatJSON {
+				cmds[i] = pipe.Get(ctx, key)
+			} else {
+				cmds[i] = pipe.HMGet(ctx, key, columns...)
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	values := make([]map[string]string, len(keys))
+	for i, cmd := range cmds {
+		values[i] = make(map[string]string, len(columns))
+		switch cmd := cmd.(type) {
+		case *redis.SliceCmd:
+			for j, v := range cmd.Val() {
+				if s, ok := v.(string); ok {
+					values[i][columns[j]] = s
+				}
+			}
+		case *redis.StringCmd:
+			if errors.Is(cmd.Err(), redis.Nil) {
+				continue
+			}
+			fields, err := jsonFields([]byte(cmd.Val()))
+			if err != nil {
+				return nil, err
+			}
+			for _, col := range columns {
+				if v, ok := fields[col]; ok {
+					values[i][col] = v
+				}
+			}
+		}
+	}
+	return values, nil
+}
@@ -5,23 +5,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/cloudquery/plugin-sdk/v4/message"
 	"github.com/cloudquery/plugin-sdk/v4/plugin"
 	"github.com/cloudquery/plugin-sdk/v4/writers/streamingbatchwriter"
 
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/delta"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/partition"
 	"github.com/cloudquery/filetypes/v4"
 	"github.com/rs/zerolog"
 )
 
 type Client struct {
 	plugin.UnimplementedSource
-	streamingbatchwriter.IgnoreMigrateTable
-	streamingbatchwriter.UnimplementedDeleteStale
 
 	logger zerolog.Logger
 	spec   *Spec
@@ -31,6 +34,12 @@ type Client struct {
 	s3Client   *s3.Client
 	uploader   *manager.Uploader
 	downloader *manager.Downloader
+
+	glueClient glueAPI
+	// glueTables holds the names of the tables already registered in Glue during this sync
+	glueTables sync.Map
+
+	delta *delta.Tables
 }
 
 func New(ctx context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewClientOptions) (plugin.Client, error) {
@@ -66,13 +75,19 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, opts plugin.Ne
 	c.s3Client = s3.NewFromConfig(cfg)
 	c.uploader = manager.NewUploader(c.s3Client)
 	c.downloader = manager.NewDownloader(c.s3Client)
+	if c.spec.Glue != nil {
+		c.glueClient = glue.NewFromConfig(cfg)
+	}
+	if c.spec.TableFormat == TableFormatDelta {
+		c.delta = delta.NewTables(&s3DeltaStore{client: c.s3Client, uploader: c.uploader, bucket: c.spec.Bucket}, c.Client, c.deltaTableRoot, "cloudquery-s3-destination")
+	}
 
 	if *c.spec.TestWrite {
 		// we want to run this test because we want it to fail early if the bucket is not accessible
 		timeNow := time.Now().UTC()
 		if _, err := c.uploader.Upload(ctx, &s3.PutObjectInput{
 			Bucket: aws.String(c.spec.Bucket),
-			Key:    aws.String(replacePathVariables(c.spec.Path, "TEST_TABLE", "TEST_UUID", c.spec.Format, timeNow)),
+			Key:    aws.String(replacePathVariables(partition.ReplaceColumnVariables(c.spec.Path, "TEST_COLUMN"), "TEST_TABLE", "TEST_UUID", c.spec.Format, timeNow)),
 			Body:   bytes.NewReader([]byte("")),
 		}); err != nil {
 			return nil, fmt.Errorf("failed to write test file to S3: %w", err)
@@ -91,6 +106,20 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, opts plugin.Ne
 	return c, nil
 }
 
+func (c *Client) MigrateTable(ctx context.Context, msgs <-chan *message.WriteMigrateTable) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Migrate(ctx, msgs)
+	}
+	return streamingbatchwriter.IgnoreMigrateTable{}.MigrateTable(ctx, msgs)
+}
+
+func (c *Client) DeleteStale(ctx context.Context, msgs <-chan *message.WriteDeleteStale) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.DeleteStale(ctx, msgs)
+	}
+	return streamingbatchwriter.UnimplementedDeleteStale{}.DeleteStale(ctx, msgs)
+}
+
 func (c *Client) Close(ctx context.Context) error {
 	return c.writer.Close(ctx)
 }
@@ -107,3 +136,7 @@ func (c *Client) ResolveEndpoint(service, region string, options ...any) (aws.En
 		Source:        aws.EndpointSourceCustom,
 	}, nil
 }
+
+func (c *Client) deltaTableRoot(table string) string {
+	return replacePathVariables(c.spec.Path, table, "", c.spec.Format, time.Time{})
+}
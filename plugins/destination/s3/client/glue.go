@@ -0,0 +1,321 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	gluetypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/partition"
+	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/filetypes/v4/csv"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// glueAPI is the subset of the Glue API used to register tables, so it can be mocked in tests.
+type glueAPI interface {
+	CreateTable(ctx context.Context, params *glue.CreateTableInput, optFns ...func(*glue.Options)) (*glue.CreateTableOutput, error)
+	UpdateTable(ctx context.Context, params *glue.UpdateTableInput, optFns ...func(*glue.Options)) (*glue.UpdateTableOutput, error)
+}
+
+// glueLayout describes how the `path` template maps to a Glue table location and its projected partitions.
+type glueLayout struct {
+	// location is the path template of the table root, e.g. "data/{{TABLE}}"
+	location string
+	// locationTemplate is the path template of the partition directories relative to location, with partition
+	// variables replaced by Athena's ${name} projection placeholders, e.g. "year=${year}/account_id=${account_id}"
+	locationTemplate string
+	partitions       []gluePartition
+}
+
+type gluePartition struct {
+	name string
+	// column is set for {{COLUMN:name}} partitions
+	column string
+	// variable is set for time partitions, e.g. "{{YEAR}}"
+	variable string
+}
+
+var reAnyVar = regexp.MustCompile(`{{[^{}]+}}`)
+
+// timeProjections maps time path variables to Athena integer partition projection ranges and digits.
+var timeProjections = map[string]struct {
+	rng    string
+	digits int
+}{
+	YearVar:   {rng: "2000,2100", digits: 4},
+	MonthVar:  {rng: "1,12", digits: 2},
+	DayVar:    {rng: "1,31", digits: 2},
+	HourVar:   {rng: "0,23", digits: 2},
+	MinuteVar: {rng: "0,59", digits: 2},
+}
+
+// parseGlueLayout splits the directory part of the path template into the (per-table) table location and
+// the partition directories below it. Every directory below the table location may contain at most one variable,
+// which becomes a projected partition.
+func parseGlueLayout(specPath string) (*glueLayout, error) {
+	dir := path.Dir(specPath)
+	if dir == "." {
+		return nil, fmt.Errorf("`path` should contain %s as a directory when `glue` is set", PathVarTable)
+	}
+	dirs := strings.Split(dir, "/")
+
+	l := &glueLayout{}
+	i := 0
+	for ; i < len(dirs); i++ {
+		if reAnyVar.MatchString(strings.ReplaceAll(dirs[i], PathVarTable, "")) {
+			break
+		}
+	}
+	l.location = strings.Join(dirs[:i], "/")
+	if !strings.Contains(l.location, PathVarTable) {
+		return nil, fmt.Errorf("`path` should contain %s in a directory before any other variables when `glue` is set", PathVarTable)
+	}
+
+	seen := make(map[string]bool)
+	templates := make([]string, 0, len(dirs)-i)
+	for _, d := range dirs[i:] {
+		vars := reAnyVar.FindAllString(strings.ReplaceAll(d, PathVarTable, ""), -1)
+		switch len(vars) {
+		case 0:
+			templates = append(templates, d)
+			continue
+		case 1:
+		default:
+			return nil, fmt.Errorf("`path` directory %q should contain at most one variable when `glue` is set", d)
+		}
+
+		v := vars[0]
+		var p gluePartition
+		column, isColumn := partition.ColumnName(v)
+		switch {
+		case isColumn:
+			p.column = column
+			p.name = p.column
+		case timeProjections[v].digits > 0:
+			p.variable = v
+			p.name = strings.ToLower(strings.Trim(v, "{}"))
+		default:
+			return nil, fmt.Errorf("`path` variable %s can't be used in a directory when `glue` is set", v)
+		}
+		if key, ok := strings.CutSuffix(d, "="+v); ok && key != "" && !reAnyVar.MatchString(key) {
+			// Hive-style "key={{VAR}}" directory, name the partition after the key
+			p.name = key
+		}
+		if seen[p.name] {
+			return nil, fmt.Errorf("duplicate partition %q in `path`", p.name)
+		}
+		seen[p.name] = true
+
+		l.partitions = append(l.partitions, p)
+		templates = append(templates, strings.Replace(d, v, "${"+p.name+"}", 1))
+	}
+	l.locationTemplate = strings.Join(templates, "/")
+	return l, nil
+}
+
+func (c *Client) registerGlueTable(ctx context.Context, table *schema.Table) error {
+	if c.glueClient == nil {
+		return nil
+	}
+	if _, loaded := c.glueTables.LoadOrStore(table.Name, struct{}{}); loaded {
+		return nil
+	}
+
+	input, err := c.glueTableInput(table)
+	if err != nil {
+		c.glueTables.Delete(table.Name)
+		return err
+	}
+
+	var catalogID *string
+	if c.spec.Glue.CatalogID != "" {
+		catalogID = aws.String(c.spec.Glue.CatalogID)
+	}
+	_, err = c.glueClient.CreateTable(ctx, &glue.CreateTableInput{
+		CatalogId:    catalogID,
+		DatabaseName: aws.String(c.spec.Glue.Database),
+		TableInput:   input,
+	})
+	var alreadyExists *gluetypes.AlreadyExistsException
+	if errors.As(err, &alreadyExists) {
+		c.logger.Debug().Str("table", table.Name).Msg("updating existing Glue table")
+		_, err = c.glueClient.UpdateTable(ctx, &glue.UpdateTableInput{
+			CatalogId:    catalogID,
+			DatabaseName: aws.String(c.spec.Glue.Database),
+			TableInput:   input,
+		})
+	}
+	if err != nil {
+		c.glueTables.Delete(table.Name)
+		return fmt.Errorf("failed to register table %q in Glue: %w", table.Name, err)
+	}
+	return nil
+}
+
+func (c *Client) glueTableInput(table *schema.Table) (*gluetypes.TableInput, error) {
+	l, err := parseGlueLayout(c.spec.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	location := "s3://" + c.spec.Bucket + "/" + strings.ReplaceAll(l.location, PathVarTable, table.Name) + "/"
+	params := map[string]string{
+		"EXTERNAL":       "TRUE",
+		"classification": string(c.spec.Format),
+	}
+
+	partitionKeys := make([]gluetypes.Column, 0, len(l.partitions))
+	partitionNames := make(map[string]bool, len(l.partitions))
+	if len(l.partitions) > 0 {
+		params["projection.enabled"] = "true"
+		params["storage.location.template"] = location + strings.ReplaceAll(l.locationTemplate, PathVarTable, table.Name)
+	}
+	for _, p := range l.partitions {
+		partitionNames[p.name] = true
+		if p.column != "" {
+			// values are not known in advance, so queries must filter on the partition
+			partitionKeys = append(partitionKeys, gluetypes.Column{Name: aws.String(p.name), Type: aws.String("string")})
+			params["projection."+p.name+".type"] = "injected"
+			continue
+		}
+		tp := timeProjections[p.variable]
+		partitionKeys = append(partitionKeys, gluetypes.Column{Name: aws.String(p.name), Type: aws.String("int")})
+		params["projection."+p.name+".type"] = "integer"
+		params["projection."+p.name+".range"] = tp.rng
+		params["projection."+p.name+".digits"] = strconv.Itoa(tp.digits)
+	}
+
+	columns := make([]gluetypes.Column, 0, len(table.Columns))
+	for _, col := range table.Columns {
+		if partitionNames[col.Name] {
+			// the partition key holds the same value, and Athena doesn't allow duplicate column names
+			continue
+		}
+		typ := glueType(col.Type)
+		if c.spec.Format == filetypes.FormatTypeCSV {
+			// OpenCSVSerde reads all values as strings, and fails to query columns declared with other types
+			typ = "string"
+		}
+		gc := gluetypes.Column{Name: aws.String(col.Name), Type: aws.String(typ)}
+		if col.Description != "" {
+			gc.Comment = aws.String(truncate(col.Description, 255))
+		}
+		columns = append(columns, gc)
+	}
+
+	sd := &gluetypes.StorageDescriptor{
+		Columns:   columns,
+		Location:  aws.String(location),
+		SerdeInfo: &gluetypes.SerDeInfo{},
+	}
+	switch c.spec.Format {
+	case filetypes.FormatTypeParquet:
+		sd.InputFormat = aws.String("org.apache.hadoop.hive.ql.io.parquet.MapredParquetInputFormat")
+		sd.OutputFormat = aws.String("org.apache.hadoop.hive.ql.io.parquet.MapredParquetOutputFormat")
+		sd.SerdeInfo.SerializationLibrary = aws.String("org.apache.hadoop.hive.ql.io.parquet.serde.ParquetHiveSerDe")
+	case filetypes.FormatTypeJSON:
+		sd.InputFormat = aws.String("org.apache.hadoop.mapred.TextInputFormat")
+		sd.OutputFormat = aws.String("org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat")
+		sd.SerdeInfo.SerializationLibrary = aws.String("org.openx.data.jsonserde.JsonSerDe")
+	case filetypes.FormatTypeCSV:
+		csvSpec, err := c.csvSpec()
+		if err != nil {
+			return nil, err
+		}
+		sd.InputFormat = aws.String("org.apache.hadoop.mapred.TextInputFormat")
+		sd.OutputFormat = aws.String("org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat")
+		sd.SerdeInfo.SerializationLibrary = aws.String("org.apache.hadoop.hive.serde2.OpenCSVSerde")
+		sd.SerdeInfo.Parameters = map[string]string{"separatorChar": csvSpec.Delimiter}
+		if !csvSpec.SkipHeader {
+			params["skip.header.line.count"] = "1"
+		}
+	default:
+		return nil, fmt.Errorf("format %q is not supported by Glue", c.spec.Format)
+	}
+
+	input := &gluetypes.TableInput{
+		Name:              aws.String(c.spec.Glue.TablePrefix + table.Name),
+		TableType:         aws.String("EXTERNAL_TABLE"),
+		Parameters:        params,
+		PartitionKeys:     partitionKeys,
+		StorageDescriptor: sd,
+	}
+	if table.Description != "" {
+		input.Description = aws.String(truncate(table.Description, 2048))
+	}
+	return input, nil
+}
+
+func (c *Client) csvSpec() (*csv.Spec, error) {
+	s := &csv.Spec{}
+	if c.spec.FormatSpec != nil {
+		b, err := json.Marshal(c.spec.FormatSpec)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, s); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal csv format spec: %w", err)
+		}
+	}
+	s.SetDefaults()
+	return s, nil
+}
+
+// glueType returns the Hive type matching the way filetypes writes the Arrow type.
+func glueType(dt arrow.DataType) string {
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		return "boolean"
+	case *arrow.Int8Type:
+		return "tinyint"
+	case *arrow.Int16Type, *arrow.Uint8Type:
+		return "smallint"
+	case *arrow.Int32Type, *arrow.Uint16Type:
+		return "int"
+	case *arrow.Int64Type, *arrow.Uint32Type, *arrow.Uint64Type:
+		return "bigint"
+	case *arrow.Float16Type, *arrow.Float32Type:
+		return "float"
+	case *arrow.Float64Type:
+		return "double"
+	case *arrow.Decimal128Type:
+		return fmt.Sprintf("decimal(%d,%d)", dt.Precision, dt.Scale)
+	case *arrow.Decimal256Type:
+		return fmt.Sprintf("decimal(%d,%d)", dt.Precision, dt.Scale)
+	case *arrow.BinaryType, *arrow.FixedSizeBinaryType:
+		return "binary"
+	case *arrow.TimestampType:
+		return "timestamp"
+	case *arrow.Date32Type, *arrow.Date64Type:
+		return "date"
+	case *arrow.StructType:
+		fields := make([]string, len(dt.Fields()))
+		for i, f := range dt.Fields() {
+			fields[i] = f.Name + ":" + glueType(f.Type)
+		}
+		return "struct<" + strings.Join(fields, ",") + ">"
+	case *arrow.MapType:
+		return "map<" + glueType(dt.KeyType()) + "," + glueType(dt.ItemType()) + ">"
+	case arrow.ListLikeType:
+		return "array<" + glueType(dt.Elem()) + ">"
+	default:
+		// strings, and extension (JSON, UUID, inet, MAC) & interval types which are written as strings
+		return "string"
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
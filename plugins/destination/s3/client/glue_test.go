@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	gluetypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type mockGlue struct {
+	created []*glue.CreateTableInput
+	updated []*glue.UpdateTableInput
+	exists  map[string]bool
+}
+
+func (m *mockGlue) CreateTable(_ context.Context, params *glue.CreateTableInput, _ ...func(*glue.Options)) (*glue.CreateTableOutput, error) {
+	if m.exists[*params.TableInput.Name] {
+		return nil, &gluetypes.AlreadyExistsException{Message: aws.String("table already exists")}
+	}
+	m.created = append(m.created, params)
+	return &glue.CreateTableOutput{}, nil
+}
+
+func (m *mockGlue) UpdateTable(_ context.Context, params *glue.UpdateTableInput, _ ...func(*glue.Options)) (*glue.UpdateTableOutput, error) {
+	m.updated = append(m.updated, params)
+	return &glue.UpdateTableOutput{}, nil
+}
+
+func TestParseGlueLayout(t *testing.T) {
+	cases := []struct {
+		path             string
+		location         string
+		locationTemplate string
+		partitions       []gluePartition
+		wantErr          bool
+	}{
+		{
+			path:     "data/{{TABLE}}/{{UUID}}.parquet",
+			location: "data/{{TABLE}}",
+		},
+		{
+			path:             "data/{{TABLE}}/year={{YEAR}}/month={{MONTH}}/{{COLUMN:account_id}}/static/{{UUID}}.parquet",
+			location:         "data/{{TABLE}}",
+			locationTemplate: "year=${year}/month=${month}/${account_id}/static",
+			partitions: []gluePartition{
+				{name: "year", variable: YearVar},
+				{name: "month", variable: MonthVar},
+				{name: "account_id", column: "account_id"},
+			},
+		},
+		{
+			path:             "{{TABLE}}/acct={{COLUMN:account_id}}/{{UUID}}.json",
+			location:         "{{TABLE}}",
+			locationTemplate: "acct=${acct}",
+			partitions:       []gluePartition{{name: "acct", column: "account_id"}},
+		},
+		{path: "{{TABLE}}.{{UUID}}.json", wantErr: true},                             // table is not a directory
+		{path: "data/{{YEAR}}/{{TABLE}}/{{UUID}}.json", wantErr: true},               // table is below a partition
+		{path: "data/{{TABLE}}/{{YEAR}}-{{MONTH}}/{{UUID}}.json", wantErr: true},     // two variables in a directory
+		{path: "data/{{TABLE}}/{{UUID}}/data.json", wantErr: true},                   // UUID directory
+		{path: "data/{{TABLE}}/a={{YEAR}}/a={{MONTH}}/{{UUID}}.json", wantErr: true}, // duplicate partition
+	}
+	for _, tc := range cases {
+		l, err := parseGlueLayout(tc.path)
+		if tc.wantErr {
+			require.Error(t, err, tc.path)
+			continue
+		}
+		require.NoError(t, err, tc.path)
+		require.Equal(t, tc.location, l.location, tc.path)
+		require.Equal(t, tc.locationTemplate, l.locationTemplate, tc.path)
+		require.Equal(t, tc.partitions, l.partitions, tc.path)
+	}
+}
+
+func TestRegisterGlueTable(t *testing.T) {
+	m := &mockGlue{exists: map[string]bool{"cq_test_existing": true}}
+	c := &Client{
+		logger: zerolog.Nop(),
+		spec: &Spec{
+			Bucket:   bucket,
+			Path:     "data/{{TABLE}}/account_id={{COLUMN:account_id}}/year={{YEAR}}/{{UUID}}.parquet",
+			FileSpec: &filetypes.FileSpec{Format: filetypes.FormatTypeParquet},
+			Glue:     &GlueSpec{Database: "cloudquery", TablePrefix: "cq_"},
+		},
+		glueClient: m,
+	}
+	table := &schema.Table{
+		Name:        "test",
+		Description: "Test table",
+		Columns: []schema.Column{
+			{Name: "account_id", Type: arrow.BinaryTypes.String},
+			{Name: "id", Type: arrow.PrimitiveTypes.Int64, Description: "The ID"},
+			{Name: "tags", Type: types.ExtensionTypes.JSON},
+			{Name: "created_at", Type: arrow.FixedWidthTypes.Timestamp_us},
+			{Name: "ips", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+			{Name: "meta", Type: arrow.StructOf(arrow.Field{Name: "a", Type: arrow.FixedWidthTypes.Boolean})},
+		},
+	}
+
+	ctx := context.Background()
+	require.NoError(t, c.registerGlueTable(ctx, table))
+	require.NoError(t, c.registerGlueTable(ctx, table)) // registered only once per sync
+	require.Len(t, m.created, 1)
+	require.Empty(t, m.updated)
+
+	got := m.created[0]
+	require.Equal(t, "cloudquery", *got.DatabaseName)
+	require.Nil(t, got.CatalogId)
+	require.Equal(t, "cq_test", *got.TableInput.Name)
+	require.Equal(t, "s3://"+bucket+"/data/test/", *got.TableInput.StorageDescriptor.Location)
+	require.Equal(t, "org.apache.hadoop.hive.ql.io.parquet.serde.ParquetHiveSerDe", *got.TableInput.StorageDescriptor.SerdeInfo.SerializationLibrary)
+	require.Equal(t, map[string]string{
+		"EXTERNAL":                   "TRUE",
+		"classification":             "parquet",
+		"projection.enabled":         "true",
+		"storage.location.template":  "s3://" + bucket + "/data/test/account_id=${account_id}/year=${year}",
+		"projection.account_id.type": "injected",
+		"projection.year.type":       "integer",
+		"projection.year.range":      "2000,2100",
+		"projection.year.digits":     "4",
+	}, got.TableInput.Parameters)
+
+	partitions := make(map[string]string)
+	for _, col := range got.TableInput.PartitionKeys {
+		partitions[*col.Name] = *col.Type
+	}
+	require.Equal(t, map[string]string{"account_id": "string", "year": "int"}, partitions)
+
+	columns := make(map[string]string)
+	for _, col := range got.TableInput.StorageDescriptor.Columns {
+		columns[*col.Name] = *col.Type
+	}
+	require.Equal(t, map[string]string{
+		"id":         "bigint",
+		"tags":       "string",
+		"created_at": "timestamp",
+		"ips":        "array<string>",
+		"meta":       "struct<a:boolean>",
+	}, columns)
+
+	require.NoError(t, c.registerGlueTable(ctx, &schema.Table{Name: "test_existing", Columns: table.Columns}))
+	require.Len(t, m.created, 1)
+	require.Len(t, m.updated, 1)
+	require.Equal(t, "cq_test_existing", *m.updated[0].TableInput.Name)
+}
+
+func TestRegisterGlueTableCSV(t *testing.T) {
+	m := &mockGlue{}
+	c := &Client{
+		logger: zerolog.Nop(),
+		spec: &Spec{
+			Bucket:   bucket,
+			Path:     "data/{{TABLE}}/year={{YEAR}}/{{UUID}}.csv",
+			FileSpec: &filetypes.FileSpec{Format: filetypes.FormatTypeCSV},
+			Glue:     &GlueSpec{Database: "cloudquery"},
+		},
+		glueClient: m,
+	}
+	table := &schema.Table{
+		Name: "test",
+		Columns: []schema.Column{
+			{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "created_at", Type: arrow.FixedWidthTypes.Timestamp_us},
+			{Name: "ips", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+		},
+	}
+	require.NoError(t, c.registerGlueTable(context.Background(), table))
+	require.Len(t, m.created, 1)
+
+	got := m.created[0].TableInput
+	require.Equal(t, "org.apache.hadoop.hive.serde2.OpenCSVSerde", *got.StorageDescriptor.SerdeInfo.SerializationLibrary)
+	require.Equal(t, map[string]string{"separatorChar": ","}, got.StorageDescriptor.SerdeInfo.Parameters)
+	require.Equal(t, "1", got.Parameters["skip.header.line.count"])
+	for _, col := range got.StorageDescriptor.Columns {
+		require.Equal(t, "string", *col.Type, *col.Name)
+	}
+	require.Equal(t, "int", *got.PartitionKeys[0].Type)
+}
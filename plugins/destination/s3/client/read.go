@@ -10,18 +10,25 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/partition"
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 )
 
 const maxFileSize = 1024 * 1024 * 20
 
 func (c *Client) Read(ctx context.Context, table *schema.Table, res chan<- arrow.Record) error {
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Read(ctx, table, res)
+	}
 	if !c.spec.NoRotate {
 		return fmt.Errorf("reading is not supported when no_rotate is false. Table: %q", table.Name)
 	}
 	if strings.Contains(c.spec.Path, PathVarUUID) {
 		return fmt.Errorf("reading is not supported when path contains uuid variable. Table: %q", table.Name)
 	}
+	if partition.HasColumnVariables(c.spec.Path) {
+		return fmt.Errorf("reading is not supported when `path` contains {{COLUMN:...}} variables. Table: %q", table.Name)
+	}
 	name := strings.ReplaceAll(c.spec.Path, PathVarTable, table.Name)
 	writerAtBuffer := manager.NewWriteAtBuffer(make([]byte, 0, maxFileSize))
 	_, err := c.downloader.Download(ctx,
@@ -10,22 +10,38 @@ import (
 	"github.com/cloudquery/plugin-sdk/v4/configtype"
 )
 
+const TableFormatDelta = "delta"
+
 type Spec struct {
 	*filetypes.FileSpec
-	NoRotate  bool   `json:"no_rotate,omitempty"`
-	Bucket    string `json:"bucket,omitempty"`
-	Region    string `json:"region,omitempty"`
-	Path      string `json:"path,omitempty"`
-	Athena    bool   `json:"athena,omitempty"`
-	TestWrite *bool  `json:"test_write,omitempty"`
-	Endpoint  string `json:"endpoint,omitempty"`
+	NoRotate  bool      `json:"no_rotate,omitempty"`
+	Bucket    string    `json:"bucket,omitempty"`
+	Region    string    `json:"region,omitempty"`
+	Path      string    `json:"path,omitempty"`
+	Athena    bool      `json:"athena,omitempty"`
+	Glue      *GlueSpec `json:"glue,omitempty"`
+	TestWrite *bool     `json:"test_write,omitempty"`
+	Endpoint  string    `json:"endpoint,omitempty"`
+
+	TableFormat string `json:"table_format,omitempty"`
 
 	BatchSize      *int64               `json:"batch_size"`
 	BatchSizeBytes *int64               `json:"batch_size_bytes"`
 	BatchTimeout   *configtype.Duration `json:"batch_timeout"`
 }
 
+// GlueSpec configures registering the written tables in the AWS Glue Data Catalog.
+type GlueSpec struct {
+	Database    string `json:"database,omitempty"`
+	CatalogID   string `json:"catalog_id,omitempty"`
+	TablePrefix string `json:"table_prefix,omitempty"`
+}
+
 func (s *Spec) SetDefaults() {
+	if s.TableFormat == TableFormatDelta && !strings.Contains(s.Path, PathVarTable) {
+		// path is the table directory, file names are generated
+		s.Path = path.Join(s.Path, PathVarTable)
+	}
 	if !strings.Contains(s.Path, PathVarTable) {
 		// for backwards-compatibility, default to given path plus /{{TABLE}}.[format].{{UUID}} if
 		// no {{TABLE}} value is found in the path string
@@ -73,6 +89,13 @@ func (s *Spec) Validate() error {
 	if s.Region == "" {
 		return fmt.Errorf("`region` is required")
 	}
+	switch s.TableFormat {
+	case "":
+	case TableFormatDelta:
+		return s.validateDelta()
+	default:
+		return fmt.Errorf("unsupported `table_format` %q, only %q is supported", s.TableFormat, TableFormatDelta)
+	}
 	if s.NoRotate && strings.Contains(s.Path, PathVarUUID) {
 		return fmt.Errorf("`path` should not contain %s when `no_rotate` = true", PathVarUUID)
 	}
@@ -88,6 +111,14 @@ func (s *Spec) Validate() error {
 	if s.Format == "" {
 		return fmt.Errorf("`format` is required")
 	}
+	if s.Glue != nil {
+		if err := s.Glue.Validate(); err != nil {
+			return err
+		}
+		if _, err := parseGlueLayout(s.Path); err != nil {
+			return err
+		}
+	}
 	if s.NoRotate && ((s.BatchSize != nil && *s.BatchSize > 0) || (s.BatchSizeBytes != nil && *s.BatchSizeBytes > 0) || (s.BatchTimeout != nil && s.BatchTimeout.Duration() > 0)) {
 		return fmt.Errorf("`no_rotate` cannot be used with non-zero `batch_size`, `batch_size_bytes` or `batch_timeout_ms`")
 	}
@@ -95,6 +126,35 @@ func (s *Spec) Validate() error {
 	return nil
 }
 
+func (s *Spec) validateDelta() error {
+	if s.Format != filetypes.FormatTypeParquet {
+		return fmt.Errorf("`table_format` %q requires the %q `format`", TableFormatDelta, filetypes.FormatTypeParquet)
+	}
+	if s.NoRotate {
+		return fmt.Errorf("`no_rotate` is not supported with `table_format` %q", TableFormatDelta)
+	}
+	if s.Glue != nil {
+		return fmt.Errorf("`glue` is not supported with `table_format` %q", TableFormatDelta)
+	}
+	if strings.Contains(strings.ReplaceAll(s.Path, PathVarTable, ""), "{{") {
+		return fmt.Errorf("`path` should not contain variables other than %s when `table_format` is %q", PathVarTable, TableFormatDelta)
+	}
+	if path.IsAbs(s.Path) {
+		return fmt.Errorf("`path` should not start with a \"/\"")
+	}
+	if s.Path != path.Clean(s.Path) {
+		return fmt.Errorf("`path` should not contain relative paths or duplicate slashes")
+	}
+	return nil
+}
+
+func (s *GlueSpec) Validate() error {
+	if s.Database == "" {
+		return fmt.Errorf("`glue.database` is required")
+	}
+	return nil
+}
+
 func (s *Spec) batchingEnabled() bool {
 	switch {
 	case (s.BatchSize != nil && *s.BatchSize > 0) ||
@@ -32,6 +32,10 @@ func TestSpec_SetDefaults(t *testing.T) {
 			Give: Spec{Path: "test/path", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: true},
 			Want: Spec{Path: "test/path/{{TABLE}}.json", FileSpec: &filetypes.FileSpec{Format: "json"}, TestWrite: boolPtr(true), NoRotate: true, BatchSize: int64Ptr(0), BatchSizeBytes: int64Ptr(0), BatchTimeout: &dur0},
 		},
+		{
+			Give: Spec{Path: "test/path", FileSpec: &filetypes.FileSpec{Format: "parquet"}, TableFormat: TableFormatDelta},
+			Want: Spec{Path: "test/path/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "parquet"}, TableFormat: TableFormatDelta, TestWrite: boolPtr(true), BatchSize: int64Ptr(10000), BatchSizeBytes: int64Ptr(50 * 1024 * 1024), BatchTimeout: &dur30},
+		},
 	}
 	for _, tc := range cases {
 		got := tc.Give
@@ -57,6 +61,11 @@ func TestSpec_Validate(t *testing.T) {
 		{Give: Spec{Path: "/test/path/{{TABLE}}.{{UUID}}", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: true, Bucket: "mybucket", Region: region, BatchSize: &zero, BatchSizeBytes: &zero, BatchTimeout: &dur0}, WantErr: true},  // begins with a slash
 		{Give: Spec{Path: "//test/path/{{TABLE}}.{{UUID}}", FileSpec: &filetypes.FileSpec{Format: "json"}, NoRotate: true, Bucket: "mybucket", Region: region, BatchSize: &zero, BatchSizeBytes: &zero, BatchTimeout: &dur0}, WantErr: true}, // duplicate slashes
 		{Give: Spec{Path: "test//path", FileSpec: &filetypes.FileSpec{Format: "json"}, Bucket: "mybucket", Region: region, BatchSize: &zero, BatchSizeBytes: &zero, BatchTimeout: &dur0}, WantErr: true},                                     // duplicate slashes
+		{Give: Spec{Path: "test/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "parquet"}, Bucket: "mybucket", Region: region, TableFormat: TableFormatDelta}, WantErr: false},
+		{Give: Spec{Path: "test/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "json"}, Bucket: "mybucket", Region: region, TableFormat: TableFormatDelta}, WantErr: true},                       // delta requires parquet
+		{Give: Spec{Path: "test/{{TABLE}}/{{UUID}}", FileSpec: &filetypes.FileSpec{Format: "parquet"}, Bucket: "mybucket", Region: region, TableFormat: TableFormatDelta}, WantErr: true},           // file names are generated
+		{Give: Spec{Path: "test/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "parquet"}, Bucket: "mybucket", Region: region, TableFormat: TableFormatDelta, Glue: &GlueSpec{}}, WantErr: true}, // no glue
+		{Give: Spec{Path: "test/{{TABLE}}", FileSpec: &filetypes.FileSpec{Format: "parquet"}, Bucket: "mybucket", Region: region, TableFormat: "iceberg"}, WantErr: true},
 	}
 	for i, tc := range cases {
 		tc := tc
@@ -14,8 +14,10 @@ import (
 	"github.com/apache/arrow/go/v13/arrow/memory"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/cloudquery/cloudquery/plugins/destination/filetables/partition"
 	"github.com/cloudquery/filetypes/v4"
 	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/cloudquery/plugin-sdk/v4/types"
 	"github.com/google/uuid"
 )
@@ -34,13 +36,51 @@ const (
 var reInvalidJSONKey = regexp.MustCompile(`\W`)
 
 func (c *Client) WriteTable(ctx context.Context, msgs <-chan *message.WriteInsert) error {
-	var s *filetypes.Stream
+	if c.spec.TableFormat == TableFormatDelta {
+		return c.delta.Write(ctx, msgs)
+	}
+
+	var (
+		table   *schema.Table
+		streams []*filetypes.Stream
+		byPath  = make(map[string]*filetypes.Stream)
+		now     = time.Now().UTC()
+	)
 
 	for msg := range msgs {
-		if s == nil {
-			table := msg.GetTable()
+		table = msg.GetTable()
+
+		if c.spec.Athena {
+			msg.Record = sanitizeRecordJSONKeys(msg.Record)
+		}
+
+		parts := partition.Split(c.spec.Path, msg.Record)
+		err := c.writePartitions(ctx, table, parts, byPath, &streams, now)
+		partition.Release(parts)
+		if err != nil {
+			finishStreamsWithError(streams, err)
+			return err
+		}
+	}
+
+	for i, s := range streams {
+		if err := s.Finish(); err != nil {
+			finishStreamsWithError(streams[i+1:], err)
+			return err
+		}
+	}
+	if table == nil {
+		return nil
+	}
+	return c.registerGlueTable(ctx, table)
+}
 
-			objKey := replacePathVariables(c.spec.Path, table.Name, uuid.NewString(), c.spec.Format, time.Now().UTC())
+// writePartitions writes the partitions to the streams of their paths, starting the streams of new paths.
+func (c *Client) writePartitions(ctx context.Context, table *schema.Table, parts []partition.Partition, byPath map[string]*filetypes.Stream, streams *[]*filetypes.Stream, now time.Time) error {
+	for _, part := range parts {
+		s := byPath[part.Path]
+		if s == nil {
+			objKey := replacePathVariables(part.Path, table.Name, uuid.NewString(), c.spec.Format, now)
 
 			var err error
 			s, err = c.Client.StartStream(table, func(r io.Reader) error {
@@ -54,23 +94,32 @@ func (c *Client) WriteTable(ctx context.Context, msgs <-chan *message.WriteInser
 			if err != nil {
 				return err
 			}
+			byPath[part.Path] = s
+			*streams = append(*streams, s)
 		}
 
-		if c.spec.Athena {
-			msg.Record = sanitizeRecordJSONKeys(msg.Record)
-		}
-
-		if err := s.Write([]arrow.Record{msg.Record}); err != nil {
-			_ = s.FinishWithError(err)
+		if err := s.Write(part.Records); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	return s.Finish()
+func finishStreamsWithError(streams []*filetypes.Stream, err error) {
+	for _, s := range streams {
+		_ = s.FinishWithError(err)
+	}
 }
 
 func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) error {
-	return c.writer.Write(ctx, msgs)
+	if err := c.writer.Write(ctx, msgs); err != nil {
+		return err
+	}
+	if c.spec.TableFormat == TableFormatDelta {
+		// all batches are flushed by now, so the whole sync is committed at once
+		return c.delta.Commit(ctx)
+	}
+	return nil
 }
 
 // sanitizeRecordJSONKeys replaces all invalid characters in JSON keys with underscores. This is required
@@ -7,7 +7,10 @@ require (
 	github.com/aws/aws-sdk-go-v2 v1.19.0
 	github.com/aws/aws-sdk-go-v2/config v1.18.28
 	github.com/aws/aws-sdk-go-v2/feature/s3/manager v1.11.72
+	github.com/aws/aws-sdk-go-v2/service/glue v1.54.1
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.37.0
+	github.com/aws/smithy-go v1.13.5
+	github.com/cloudquery/cloudquery/plugins/destination/filetables v0.0.0-00010101000000-000000000000
 	github.com/cloudquery/filetypes/v4 v4.0.3
 	github.com/cloudquery/plugin-sdk/v4 v4.2.3
 	github.com/google/go-cmp v0.5.9
@@ -19,6 +22,8 @@ require (
 // TODO: remove once all updates are merged
 replace github.com/apache/arrow/go/v13 => github.com/cloudquery/arrow/go/v13 v13.0.0-20230717001540-8e2219bec8ee
 
+replace github.com/cloudquery/cloudquery/plugins/destination/filetables => ../filetables
+
 require (
 	github.com/JohnCGriffin/overflow v0.0.0-20211019200055-46fa312c352c // indirect
 	github.com/andybalholm/brotli v1.0.5 // indirect
@@ -37,7 +42,6 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/sso v1.12.13 // indirect
 	github.com/aws/aws-sdk-go-v2/service/ssooidc v1.14.13 // indirect
 	github.com/aws/aws-sdk-go-v2/service/sts v1.19.3 // indirect
-	github.com/aws/smithy-go v1.13.5 // indirect
 	github.com/cenkalti/backoff/v4 v4.2.1 // indirect
 	github.com/cloudquery/plugin-pb-go v1.8.0 // indirect
 	github.com/cloudquery/plugin-sdk/v2 v2.7.0 // indirect
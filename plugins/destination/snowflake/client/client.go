@@ -41,6 +41,15 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, _ plugin.NewCl
 	if err != nil {
 		return nil, err
 	}
+	if c.spec.Warehouse != "" {
+		cfg.Warehouse = c.spec.Warehouse
+	}
+	if c.spec.Role != "" {
+		cfg.Role = c.spec.Role
+	}
+	if c.spec.Schema != "" {
+		cfg.Schema = c.spec.Schema
+	}
 	binaryFormat := "BASE64"
 	cfg.Params["BINARY_INPUT_FORMAT"] = &binaryFormat
 	cfg.Params["BINARY_OUTPUT_FORMAT"] = &binaryFormat
@@ -56,6 +65,9 @@ func New(ctx context.Context, logger zerolog.Logger, spec []byte, _ plugin.NewCl
 	if _, err := c.db.ExecContext(ctx, createOrReplaceFileFormat); err != nil {
 		return nil, fmt.Errorf("failed to create file format %s: %w", createOrReplaceFileFormat, err)
 	}
+	if _, err := c.db.ExecContext(ctx, createOrReplaceParquetFileFormat); err != nil {
+		return nil, fmt.Errorf("failed to create file format %s: %w", createOrReplaceParquetFileFormat, err)
+	}
 	if _, err := c.db.ExecContext(ctx, createOrReplaceStage); err != nil {
 		return nil, fmt.Errorf("failed to create stage %s: %w", createOrReplaceStage, err)
 	}
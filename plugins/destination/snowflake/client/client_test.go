@@ -26,7 +26,6 @@ func TestPlugin(t *testing.T) {
 	plugin.TestWriterSuiteRunner(t,
 		p,
 		plugin.WriterTestSuiteTests{
-			SkipUpsert:  true,
 			SkipMigrate: true,
 		},
 		plugin.WithTestDataOptions(schema.TestSourceOptions{
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// loadOrderColumn is added to the temporary tables batches of tables with primary keys are loaded into,
+// numbering the rows in the order they were written, so that the last of the rows with the same primary keys is merged.
+const loadOrderColumn = "_cq_load_order"
+
+func quoteColumn(name string) string {
+	return `"` + strings.ToUpper(name) + `"`
+}
+
+func quotedColumns(columns []string) []string {
+	quoted := make([]string, len(columns))
+	for i, name := range columns {
+		quoted[i] = quoteColumn(name)
+	}
+	return quoted
+}
+
+// loadExpr returns the expression converting the staged value src of the column, see stagedString, to the column type.
+func (c *Client) loadExpr(src string, col schema.Column) string {
+	switch typ := c.SchemaTypeToSnowflake(col.Type); typ {
+	case "variant":
+		return "parse_json(" + src + "::text)"
+	case "array":
+		return "parse_json(" + src + "::text)::array"
+	case "binary":
+		return "to_binary(" + src + "::text, 'BASE64')"
+	default:
+		return src + "::" + typ
+	}
+}
+
+// copyIntoSQL returns the statement loading the staged Parquet file into the table tableName, having the columns of table.
+// With loadOrder, the row numbers of the file are loaded into the loadOrderColumn of the table too.
+func (c *Client) copyIntoSQL(tableName string, table *schema.Table, fileName string, loadOrder bool) string {
+	columns := quotedColumns(table.Columns.Names())
+	exprs := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		exprs[i] = c.loadExpr(`$1:"`+col.Name+`"`, col)
+	}
+	if loadOrder {
+		columns = append(columns, quoteColumn(loadOrderColumn))
+		exprs = append(exprs, "metadata$file_row_number")
+	}
+	return fmt.Sprintf("copy into %s (%s) from (select %s from @cq_plugin_stage/%s) file_format = (format_name = cq_plugin_parquet_format) purge = true",
+		tableName,
+		strings.Join(columns, ", "),
+		strings.Join(exprs, ", "),
+		fileName,
+	)
+}
+
+// insertSQL returns the statement inserting the rows into the table tableName, having the columns of table, and its arguments.
+// With loadOrder, the row numbers of the batch are inserted into the loadOrderColumn of the table too.
+func (c *Client) insertSQL(tableName string, table *schema.Table, msgs message.WriteInserts, loadOrder bool) (string, []any, error) {
+	columns := quotedColumns(table.Columns.Names())
+	exprs := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		exprs[i] = c.loadExpr(fmt.Sprintf("column%d", i+1), col)
+	}
+	if loadOrder {
+		columns = append(columns, quoteColumn(loadOrderColumn))
+		exprs = append(exprs, fmt.Sprintf("column%d::number", len(exprs)+1))
+	}
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+
+	var rows []string
+	var args []any
+	for _, msg := range msgs {
+		record := msg.Record
+		for i := 0; i < int(record.NumRows()); i++ {
+			rows = append(rows, placeholders)
+			for j, col := range record.Columns() {
+				if col.IsNull(i) {
+					args = append(args, nil)
+					continue
+				}
+				v, err := stagedString(col, i)
+				if err != nil {
+					return "", nil, fmt.Errorf("failed to stage value of column %s: %w", table.Columns[j].Name, err)
+				}
+				args = append(args, v)
+			}
+			if loadOrder {
+				args = append(args, strconv.Itoa(len(rows)))
+			}
+		}
+	}
+	query := fmt.Sprintf("insert into %s (%s) select %s from values %s",
+		tableName,
+		strings.Join(columns, ", "),
+		strings.Join(exprs, ", "),
+		strings.Join(rows, ", "),
+	)
+	return query, args, nil
+}
+
+// mergeSQL returns the statement merging the rows of the source table into the table, by their primary keys.
+// Only the last loaded of the source rows with the same primary keys is merged, see loadOrderColumn.
+func (*Client) mergeSQL(tableName string, source string, table *schema.Table) string {
+	pks := quotedColumns(table.PrimaryKeys())
+	columns := quotedColumns(table.Columns.Names())
+
+	var sb strings.Builder
+	sb.WriteString("merge into " + tableName + " using (select * from " + source)
+	sb.WriteString(" qualify row_number() over (partition by " + strings.Join(pks, ", ") + " order by " + quoteColumn(loadOrderColumn) + " desc) = 1) as src on ")
+	for i, pk := range pks {
+		if i > 0 {
+			sb.WriteString(" and ")
+		}
+		sb.WriteString(tableName + "." + pk + " = src." + pk)
+	}
+
+	var updates []string
+	for i, col := range table.Columns {
+		if !col.PrimaryKey {
+			updates = append(updates, tableName+"."+columns[i]+" = src."+columns[i])
+		}
+	}
+	if len(updates) > 0 {
+		sb.WriteString(" when matched then update set " + strings.Join(updates, ", "))
+	}
+
+	values := make([]string, len(columns))
+	for i, col := range columns {
+		values[i] = "src." + col
+	}
+	sb.WriteString(" when not matched then insert (" + strings.Join(columns, ", ") + ") values (" + strings.Join(values, ", ") + ")")
+	return sb.String()
+}
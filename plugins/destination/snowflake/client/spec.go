@@ -6,10 +6,20 @@ const (
 	defaultBatchSize          = 1000
 	defaultBatchSizeBytes     = 4 * 1024 * 1024
 	defaultMigrateConcurrency = 1
+
+	// LoadMethodCopy stages the batches as Parquet files and loads them with COPY INTO
+	LoadMethodCopy = "copy"
+	// LoadMethodInsert loads the batches with INSERT statements
+	LoadMethodInsert = "insert"
 )
 
 type Spec struct {
-	ConnectionString   string `json:"connection_string,omitempty"`
+	ConnectionString string `json:"connection_string,omitempty"`
+	// Warehouse, Role and Schema override the ones set in the connection string
+	Warehouse          string `json:"warehouse,omitempty"`
+	Role               string `json:"role,omitempty"`
+	Schema             string `json:"schema,omitempty"`
+	LoadMethod         string `json:"load_method,omitempty"`
 	BatchSize          int    `json:"batch_size,omitempty"`
 	BatchSizeBytes     int    `json:"batch_size_bytes,omitempty"`
 	MigrateConcurrency int    `json:"migrate_concurrency,omitempty"`
@@ -26,11 +36,19 @@ func (s *Spec) SetDefaults() {
 	if s.MigrateConcurrency == 0 {
 		s.MigrateConcurrency = defaultMigrateConcurrency
 	}
+	if s.LoadMethod == "" {
+		s.LoadMethod = LoadMethodCopy
+	}
 }
 
 func (s *Spec) Validate() error {
 	if s.ConnectionString == "" {
 		return fmt.Errorf("connection_string is required")
 	}
+	switch s.LoadMethod {
+	case "", LoadMethodCopy, LoadMethodInsert:
+	default:
+		return fmt.Errorf("unsupported load_method %q, only %q and %q are supported", s.LoadMethod, LoadMethodCopy, LoadMethodInsert)
+	}
 	return nil
 }
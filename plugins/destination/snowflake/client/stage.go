@@ -0,0 +1,145 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet"
+	"github.com/apache/arrow/go/v13/parquet/compress"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/goccy/go-json"
+)
+
+const stagedTimestampFormat = "2006-01-02 15:04:05.999999999"
+
+// stagedType returns the type the values of the type are staged as: booleans, numbers and strings are staged as they are,
+// other types as strings, converted to the column type by the load expressions.
+func stagedType(dt arrow.DataType) arrow.DataType {
+	switch dt.(type) {
+	case *arrow.BooleanType,
+		*arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type,
+		*arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type,
+		*arrow.Float32Type, *arrow.Float64Type,
+		*arrow.StringType:
+		return dt
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+// stagedString returns the non-null value of the array as a string the load expressions can convert:
+// timestamps in UTC, binaries encoded as base64, and lists, maps and structs as JSON.
+func stagedString(arr arrow.Array, i int) (string, error) {
+	switch arr := arr.(type) {
+	case *array.String:
+		return arr.Value(i), nil
+	case *array.Timestamp:
+		return arr.Value(i).ToTime(arr.DataType().(*arrow.TimestampType).Unit).UTC().Format(stagedTimestampFormat), nil
+	case *array.Binary:
+		return base64.StdEncoding.EncodeToString(arr.Value(i)), nil
+	case *array.LargeBinary:
+		return base64.StdEncoding.EncodeToString(arr.Value(i)), nil
+	case array.ListLike, *array.Struct:
+		b, err := marshalStaged(arr, i)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	default:
+		return arr.ValueStr(i), nil
+	}
+}
+
+// marshalStaged marshals the value of the list or struct array as JSON. This fails for values JSON can't represent,
+// such as NaN or infinite floats, which Arrow panics on when they are nested in lists, so the panic is returned as an error.
+func marshalStaged(arr arrow.Array, i int) (b []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to marshal value: %v", r)
+		}
+	}()
+	return json.Marshal(arr.GetOneForMarshal(i))
+}
+
+func stagedRecord(sc *arrow.Schema, record arrow.Record) (arrow.Record, error) {
+	cols := make([]arrow.Array, 0, record.NumCols())
+	release := func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}
+	for i, col := range record.Columns() {
+		if arrow.TypeEqual(sc.Field(i).Type, col.DataType()) {
+			col.Retain()
+			cols = append(cols, col)
+			continue
+		}
+		bldr := array.NewStringBuilder(memory.DefaultAllocator)
+		for j := 0; j < col.Len(); j++ {
+			if col.IsNull(j) {
+				bldr.AppendNull()
+				continue
+			}
+			v, err := stagedString(col, j)
+			if err != nil {
+				bldr.Release()
+				release()
+				return nil, fmt.Errorf("failed to stage value of column %s: %w", sc.Field(i).Name, err)
+			}
+			bldr.Append(v)
+		}
+		cols = append(cols, bldr.NewArray())
+		bldr.Release()
+	}
+	rec := array.NewRecord(sc, cols, record.NumRows())
+	release()
+	return rec, nil
+}
+
+// writeParquetFile writes the batch to a temporary Parquet file, with the values staged as by stagedType, and returns its name.
+func writeParquetFile(table *schema.Table, msgs message.WriteInserts) (fileName string, err error) {
+	fields := make([]arrow.Field, len(table.Columns))
+	for i, col := range table.Columns {
+		fields[i] = arrow.Field{Name: col.Name, Type: stagedType(col.Type), Nullable: true}
+	}
+	sc := arrow.NewSchema(fields, nil)
+
+	f, err := os.CreateTemp(os.TempDir(), table.Name+"-*.parquet")
+	if err != nil {
+		return "", err
+	}
+	fileName = f.Name()
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(fileName)
+		}
+	}()
+
+	fw, err := pqarrow.NewFileWriter(sc, f,
+		parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy)),
+		pqarrow.DefaultWriterProps(),
+	)
+	if err != nil {
+		return "", err
+	}
+	for _, msg := range msgs {
+		record, err := stagedRecord(sc, msg.Record)
+		if err != nil {
+			return "", err
+		}
+		err = fw.WriteBuffered(record)
+		record.Release()
+		if err != nil {
+			return "", fmt.Errorf("failed to write record: %w", err)
+		}
+	}
+	// closing the writer closes the file too
+	return fileName, fw.Close()
+}
@@ -2,20 +2,25 @@ package client
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"os"
 	"path"
+	"strings"
 
-	"github.com/apache/arrow/go/v13/arrow/array"
 	"github.com/cloudquery/plugin-sdk/v4/message"
-	"github.com/goccy/go-json"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/google/uuid"
 )
 
 const (
-	createOrReplaceFileFormat = `create or replace file format cq_plugin_json_format type = 'JSON'`
-	createOrReplaceStage      = `create or replace stage cq_plugin_stage file_format = cq_plugin_json_format;`
-	putFileIntoStage          = `put file://%s @cq_plugin_stage auto_compress=true`
-	copyIntoTable             = `copy into %s from @cq_plugin_stage/%s file_format = (format_name = cq_plugin_json_format) match_by_column_name = case_insensitive`
+	createOrReplaceFileFormat        = `create or replace file format cq_plugin_json_format type = 'JSON'`
+	createOrReplaceParquetFileFormat = `create or replace file format cq_plugin_parquet_format type = 'PARQUET'`
+	createOrReplaceStage             = `create or replace stage cq_plugin_stage file_format = cq_plugin_json_format;`
+	putFileIntoStage                 = `put file://%s @cq_plugin_stage auto_compress=false`
+	createTempTable                  = `create temporary table %s like %s`
+	addLoadOrderColumn               = `alter table %s add column %s number`
+	dropTable                        = `drop table if exists %s`
 )
 
 func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) error {
@@ -28,39 +33,73 @@ func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) er
 	return nil
 }
 
+// WriteTableBatch loads the batch into the table. Batches of tables with primary keys are loaded into a temporary table first,
+// which is then merged into the table.
 func (c *Client) WriteTableBatch(ctx context.Context, name string, msgs message.WriteInserts) error {
-	tableName := name
-	f, err := os.CreateTemp(os.TempDir(), tableName+".json.*")
+	if len(msgs) == 0 {
+		return nil
+	}
+	table := msgs[0].GetTable()
+
+	// temporary tables are only visible to the session that created them
+	conn, err := c.db.Conn(ctx)
 	if err != nil {
 		return err
 	}
+	defer conn.Close()
+
+	if len(table.PrimaryKeys()) == 0 {
+		return c.load(ctx, conn, name, table, msgs, false)
+	}
+
+	tmpTableName := name + "_cq_tmp_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(createTempTable, tmpTableName, name)); err != nil {
+		return fmt.Errorf("failed to create temporary table %s: %w", tmpTableName, err)
+	}
 	defer func() {
-		f.Close()
-		os.Remove(f.Name())
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf(dropTable, tmpTableName)); err != nil {
+			c.logger.Warn().Err(err).Str("table", tmpTableName).Msg("failed to drop temporary table")
+		}
 	}()
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(addLoadOrderColumn, tmpTableName, quoteColumn(loadOrderColumn))); err != nil {
+		return fmt.Errorf("failed to add load order column to temporary table %s: %w", tmpTableName, err)
+	}
+	if err := c.load(ctx, conn, tmpTableName, table, msgs, true); err != nil {
+		return err
+	}
+	query := c.mergeSQL(name, tmpTableName, table)
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to merge into table %s with %s: %w", name, query, err)
+	}
+	return nil
+}
 
-	enc := json.NewEncoder(f)
-	enc.SetEscapeHTML(false)
-
-	for _, r := range msgs {
-		arr := array.RecordToStructArray(r.Record)
-		for i := 0; i < arr.Len(); i++ {
-			if err := enc.Encode(arr.GetOneForMarshal(i)); err != nil {
-				return err
-			}
+// load loads the batch into the table tableName, having the columns of table, and the loadOrderColumn with loadOrder.
+func (c *Client) load(ctx context.Context, conn *sql.Conn, tableName string, table *schema.Table, msgs message.WriteInserts, loadOrder bool) error {
+	if c.spec.LoadMethod == LoadMethodInsert {
+		query, args, err := c.insertSQL(tableName, table, msgs, loadOrder)
+		if err != nil {
+			return err
 		}
+		if _, err := conn.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert into table %s: %w", tableName, err)
+		}
+		return nil
 	}
 
-	if err := f.Close(); err != nil {
-		return fmt.Errorf("failed to close temp file with last resource %s: %w", f.Name(), err)
+	fileName, err := writeParquetFile(table, msgs)
+	if err != nil {
+		return fmt.Errorf("failed to write parquet file: %w", err)
 	}
-	sql := fmt.Sprintf(putFileIntoStage, f.Name())
-	if _, err := c.db.ExecContext(ctx, sql); err != nil {
-		return fmt.Errorf("failed to put file into stage with last resource %s: %w", sql, err)
+	defer os.Remove(fileName)
+
+	query := fmt.Sprintf(putFileIntoStage, fileName)
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to put file into stage with %s: %w", query, err)
 	}
-	sql = fmt.Sprintf(copyIntoTable, tableName, path.Base(f.Name()))
-	if _, err := c.db.ExecContext(ctx, sql); err != nil {
-		return fmt.Errorf("failed to copy file into table with last resource %s: %w", sql, err)
+	query = c.copyIntoSQL(tableName, table, path.Base(fileName), loadOrder)
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to copy file into table with %s: %w", query, err)
 	}
-	return err
+	return nil
 }
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"database/sql/driver"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func testTable(pk bool) *schema.Table {
+	return &schema.Table{
+		Name: "test_table",
+		Columns: schema.ColumnList{
+			{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: pk},
+			{Name: "count", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "created_at", Type: arrow.FixedWidthTypes.Timestamp_us},
+			{Name: "data", Type: types.ExtensionTypes.JSON},
+			{Name: "raw", Type: arrow.BinaryTypes.Binary},
+		},
+	}
+}
+
+var testTime = time.Date(2023, 7, 20, 12, 30, 0, 123456000, time.UTC)
+
+func testRecord(table *schema.Table) arrow.Record {
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+	defer bldr.Release()
+	bldr.Field(0).(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	bldr.Field(1).(*array.Int64Builder).AppendValues([]int64{1, 2}, []bool{true, false})
+	bldr.Field(2).(*array.TimestampBuilder).AppendTime(testTime)
+	bldr.Field(2).(*array.TimestampBuilder).AppendTime(testTime)
+	for _, v := range []string{`{"key":"value"}`, `[1,2]`} {
+		if err := bldr.Field(3).AppendValueFromString(v); err != nil {
+			panic(err)
+		}
+	}
+	bldr.Field(4).(*array.BinaryBuilder).AppendValues([][]byte{{1, 2}, nil}, []bool{true, false})
+	return bldr.NewRecord()
+}
+
+func TestCopyIntoSQL(t *testing.T) {
+	c := &Client{}
+	got := c.copyIntoSQL("test_table", testTable(false), "test_table-1.parquet", false)
+	want := `copy into test_table ("ID", "COUNT", "CREATED_AT", "DATA", "RAW") from (select $1:"id"::text, $1:"count"::number, $1:"created_at"::timestamp_ntz, parse_json($1:"data"::text), to_binary($1:"raw"::text, 'BASE64') from @cq_plugin_stage/test_table-1.parquet) file_format = (format_name = cq_plugin_parquet_format) purge = true`
+	require.Equal(t, want, got)
+
+	got = c.copyIntoSQL("test_table_tmp", testTable(true), "test_table-1.parquet", true)
+	require.Contains(t, got, `"RAW", "_CQ_LOAD_ORDER") from (select `)
+	require.Contains(t, got, `, metadata$file_row_number from @cq_plugin_stage/`)
+}
+
+func TestInsertSQL(t *testing.T) {
+	c := &Client{}
+	table := testTable(false)
+	got, args, err := c.insertSQL("test_table", table, message.WriteInserts{{Record: testRecord(table)}}, false)
+	require.NoError(t, err)
+	want := `insert into test_table ("ID", "COUNT", "CREATED_AT", "DATA", "RAW") select column1::text, column2::number, column3::timestamp_ntz, parse_json(column4::text), to_binary(column5::text, 'BASE64') from values (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)`
+	require.Equal(t, want, got)
+	require.Equal(t, []any{
+		"a", "1", "2023-07-20 12:30:00.123456", `{"key":"value"}`, "AQI=",
+		"b", nil, "2023-07-20 12:30:00.123456", `[1,2]`, nil,
+	}, args)
+
+	got, args, err = c.insertSQL("test_table_tmp", table, message.WriteInserts{{Record: testRecord(table)}}, true)
+	require.NoError(t, err)
+	want = `insert into test_table_tmp ("ID", "COUNT", "CREATED_AT", "DATA", "RAW", "_CQ_LOAD_ORDER") select column1::text, column2::number, column3::timestamp_ntz, parse_json(column4::text), to_binary(column5::text, 'BASE64'), column6::number from values (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)`
+	require.Equal(t, want, got)
+	require.Equal(t, []any{
+		"a", "1", "2023-07-20 12:30:00.123456", `{"key":"value"}`, "AQI=", "1",
+		"b", nil, "2023-07-20 12:30:00.123456", `[1,2]`, nil, "2",
+	}, args)
+}
+
+func TestInsertSQLUnsupportedJSON(t *testing.T) {
+	c := &Client{}
+	table := &schema.Table{Name: "test_table", Columns: schema.ColumnList{{Name: "values", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64)}}}
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+	defer bldr.Release()
+	lb := bldr.Field(0).(*array.ListBuilder)
+	lb.Append(true)
+	lb.ValueBuilder().(*array.Float64Builder).Append(math.NaN())
+	record := bldr.NewRecord()
+	defer record.Release()
+
+	_, _, err := c.insertSQL("test_table", table, message.WriteInserts{{Record: record}}, false)
+	require.ErrorContains(t, err, "values")
+}
+
+func TestMergeSQL(t *testing.T) {
+	c := &Client{}
+	got := c.mergeSQL("test_table", "test_table_tmp", testTable(true))
+	want := `merge into test_table using (select * from test_table_tmp qualify row_number() over (partition by "ID" order by "_CQ_LOAD_ORDER" desc) = 1) as src on test_table."ID" = src."ID"` +
+		` when matched then update set test_table."COUNT" = src."COUNT", test_table."CREATED_AT" = src."CREATED_AT", test_table."DATA" = src."DATA", test_table."RAW" = src."RAW"` +
+		` when not matched then insert ("ID", "COUNT", "CREATED_AT", "DATA", "RAW") values (src."ID", src."COUNT", src."CREATED_AT", src."DATA", src."RAW")`
+	require.Equal(t, want, got)
+
+	pkOnly := &schema.Table{Name: "test_table", Columns: schema.ColumnList{{Name: "id", Type: arrow.BinaryTypes.String, PrimaryKey: true}}}
+	got = c.mergeSQL("test_table", "test_table_tmp", pkOnly)
+	require.NotContains(t, got, "when matched")
+}
+
+// readStagedFile reads the rows of the Parquet file as strings, by column.
+func readStagedFile(t *testing.T, fileName string) map[string][]string {
+	t.Helper()
+	f, err := os.Open(fileName)
+	require.NoError(t, err)
+	defer f.Close()
+	tbl, err := pqarrow.ReadTable(context.Background(), f, nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	defer tbl.Release()
+
+	values := make(map[string][]string)
+	for i := 0; i < int(tbl.NumCols()); i++ {
+		col := tbl.Column(i)
+		for _, chunk := range col.Data().Chunks() {
+			for j := 0; j < chunk.Len(); j++ {
+				values[col.Name()] = append(values[col.Name()], chunk.ValueStr(j))
+			}
+		}
+	}
+	return values
+}
+
+func TestWriteTableBatchCopy(t *testing.T) {
+	var staged map[string][]string
+	matcher := sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+		if err := sqlmock.QueryMatcherRegexp.Match(expectedSQL, actualSQL); err != nil {
+			return err
+		}
+		// the file is removed once loaded, so it's read when it's put into the stage
+		if fileName, ok := strings.CutPrefix(actualSQL, "put file://"); ok {
+			staged = readStagedFile(t, strings.Fields(fileName)[0])
+		}
+		return nil
+	})
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(matcher))
+	require.NoError(t, err)
+	defer db.Close()
+
+	result := sqlmock.NewResult(0, 0)
+	tmpTable := `test_table_cq_tmp_[0-9a-f]{32}`
+	mock.ExpectExec(`^create temporary table ` + tmpTable + ` like test_table$`).WillReturnResult(result)
+	mock.ExpectExec(`^alter table ` + tmpTable + ` add column "_CQ_LOAD_ORDER" number$`).WillReturnResult(result)
+	mock.ExpectExec(`^put file://\S+/test_table-\d+\.parquet @cq_plugin_stage auto_compress=false$`).WillReturnResult(result)
+	mock.ExpectExec(`^copy into ` + tmpTable + ` \(.+, "_CQ_LOAD_ORDER"\) from \(select .+, metadata\$file_row_number from @cq_plugin_stage/test_table-\d+\.parquet\)`).WillReturnResult(result)
+	mock.ExpectExec(`^merge into test_table using \(select \* from ` + tmpTable + ` `).WillReturnResult(result)
+	mock.ExpectExec(`^drop table if exists ` + tmpTable + `$`).WillReturnResult(result)
+
+	c := &Client{db: db, logger: zerolog.Nop(), spec: Spec{LoadMethod: LoadMethodCopy}}
+	table := testTable(true)
+	require.NoError(t, c.WriteTableBatch(context.Background(), table.Name, message.WriteInserts{{Record: testRecord(table)}}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Equal(t, map[string][]string{
+		"id":         {"a", "b"},
+		"count":      {"1", "(null)"},
+		"created_at": {"2023-07-20 12:30:00.123456", "2023-07-20 12:30:00.123456"},
+		"data":       {`{"key":"value"}`, `[1,2]`},
+		"raw":        {"AQI=", "(null)"},
+	}, staged)
+}
+
+func TestWriteTableBatchInsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	c := &Client{db: db, logger: zerolog.Nop(), spec: Spec{LoadMethod: LoadMethodInsert}}
+	table := testTable(false)
+	msgs := message.WriteInserts{{Record: testRecord(table)}}
+	query, args, err := c.insertSQL(table.Name, table, msgs, false)
+	require.NoError(t, err)
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	mock.ExpectExec(`^` + regexp.QuoteMeta(query) + `$`).WithArgs(values...).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	require.NoError(t, c.WriteTableBatch(context.Background(), table.Name, msgs))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/filetypes/v4"
+	"github.com/cloudquery/plugin-sdk/v4/glob"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/writers/batchwriter"
+	"github.com/rs/zerolog"
+	"golang.org/x/exp/slices"
+)
+
+type Client struct {
+	plugin.UnimplementedSource
+
+	logger zerolog.Logger
+	spec   *Spec
+
+	httpClient *http.Client
+	// encoder writes the records as JSON lines
+	encoder *filetypes.Client
+	writer  *batchwriter.BatchWriter
+}
+
+func New(_ context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewClientOptions) (plugin.Client, error) {
+	c := &Client{
+		logger: logger.With().Str("module", "webhook").Logger(),
+	}
+	if opts.NoConnection {
+		return c, nil
+	}
+
+	if err := json.Unmarshal(spec, &c.spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook spec: %w", err)
+	}
+	if err := c.spec.Validate(); err != nil {
+		return nil, err
+	}
+	c.spec.SetDefaults()
+
+	var err error
+	c.encoder, err = filetypes.NewClient(&filetypes.FileSpec{Format: filetypes.FormatTypeJSON})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create filetypes client: %w", err)
+	}
+	c.httpClient = &http.Client{Timeout: c.spec.Timeout.Duration()}
+
+	c.writer, err = batchwriter.New(c,
+		batchwriter.WithLogger(c.logger),
+		batchwriter.WithBatchSize(c.spec.BatchSize),
+		batchwriter.WithBatchSizeBytes(c.spec.BatchSizeBytes),
+		batchwriter.WithBatchTimeout(c.spec.BatchTimeout.Duration()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch writer: %w", err)
+	}
+	return c, nil
+}
+
+// urlFor returns the URL of the requests of the table, with {{TABLE}} replaced by the escaped table name.
+// The `tables` entries are tried in order before `url`.
+func (c *Client) urlFor(table string) (string, error) {
+	template := c.spec.URL
+	matches := func(pattern string) bool { return glob.Glob(pattern, table) }
+	if i := slices.IndexFunc(c.spec.Tables, func(t TableSpec) bool { return slices.ContainsFunc(t.Tables, matches) }); i >= 0 {
+		template = c.spec.Tables[i].URL
+	}
+	if template == "" {
+		return "", fmt.Errorf("no url configured for table %s", table)
+	}
+	return strings.ReplaceAll(template, URLVarTable, url.PathEscape(table)), nil
+}
+
+func (*Client) MigrateTables(context.Context, message.WriteMigrateTables) error {
+	// there are no tables to migrate, the receiving service decides what to do with the records
+	return nil
+}
+
+func (c *Client) Close(ctx context.Context) error {
+	if c.writer == nil {
+		return nil
+	}
+	if err := c.writer.Close(ctx); err != nil {
+		return fmt.Errorf("failed to close batch writer: %w", err)
+	}
+	return nil
+}
+
+func (*Client) Read(context.Context, *schema.Table, chan<- arrow.Record) error {
+	return plugin.ErrNotImplemented
+}
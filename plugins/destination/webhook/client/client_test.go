@@ -0,0 +1,262 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/cloudquery/plugin-sdk/v4/configtype"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// testServer records the requests it receives, responding with the statuses in order and then with 200.
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []receivedRequest
+	statuses []int
+}
+
+func newTestServer(t *testing.T, statuses ...int) *testServer {
+	s := &testServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, receivedRequest{method: r.Method, path: r.URL.Path, header: r.Header, body: body})
+		if len(s.statuses) > 0 {
+			status := s.statuses[0]
+			s.statuses = s.statuses[1:]
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// received returns the requests, sorted by path.
+func (s *testServer) received() []receivedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := append([]receivedRequest(nil), s.requests...)
+	sort.SliceStable(requests, func(i, j int) bool { return requests[i].path < requests[j].path })
+	return requests
+}
+
+// newClient returns a client waiting a millisecond between retries, so the retries can be tested quickly.
+func newClient(t *testing.T, spec Spec) *Client {
+	t.Helper()
+	wait := configtype.NewDuration(time.Millisecond)
+	spec.MinRetryWait, spec.MaxRetryWait = &wait, &wait
+	b, err := json.Marshal(spec)
+	require.NoError(t, err)
+	c, err := New(context.Background(), zerolog.Nop(), b, plugin.NewClientOptions{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, c.Close(context.Background())) })
+	return c.(*Client)
+}
+
+// idsTable is a table with the rows of idsRecord.
+func idsTable(name string) *schema.Table {
+	return &schema.Table{
+		Name: name,
+		Columns: schema.ColumnList{
+			{Name: "id", Type: arrow.PrimitiveTypes.Int64, PrimaryKey: true},
+			{Name: "name", Type: arrow.BinaryTypes.String},
+		},
+	}
+}
+
+// idsRecord returns a record with a row per id.
+func idsRecord(table *schema.Table, ids ...int64) arrow.Record {
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, table.ToArrowSchema())
+	defer bldr.Release()
+	for _, id := range ids {
+		bldr.Field(0).(*array.Int64Builder).Append(id)
+		bldr.Field(1).(*array.StringBuilder).Append("name")
+	}
+	return bldr.NewRecord()
+}
+
+func write(t *testing.T, c *Client, msgs ...message.WriteMessage) error {
+	t.Helper()
+	ch := make(chan message.WriteMessage, len(msgs))
+	for _, msg := range msgs {
+		ch <- msg
+	}
+	close(ch)
+	return c.Write(context.Background(), ch)
+}
+
+func TestWriteJSON(t *testing.T) {
+	server := newTestServer(t)
+	c := newClient(t, Spec{
+		URL:         server.URL + "/{{TABLE}}",
+		Headers:     map[string]string{"X-Custom": "value"},
+		BearerToken: "token",
+	})
+
+	table, other := idsTable("test_table"), idsTable("other_table")
+	require.NoError(t, write(t, c,
+		&message.WriteInsert{Record: idsRecord(table, 1, 2)},
+		&message.WriteInsert{Record: idsRecord(other, 3)},
+		&message.WriteInsert{Record: idsRecord(table, 4)},
+	))
+
+	requests := server.received()
+	require.Len(t, requests, 2)
+	wantIDs := map[string][]int64{"other_table": {3}, "test_table": {1, 2, 4}}
+	for _, r := range requests {
+		require.Equal(t, http.MethodPost, r.method)
+		require.Equal(t, "application/json", r.header.Get("Content-Type"))
+		require.Equal(t, "Bearer token", r.header.Get("Authorization"))
+		require.Equal(t, "value", r.header.Get("X-Custom"))
+		require.Equal(t, EventInsert, r.header.Get(HeaderEvent))
+
+		var payload struct {
+			Type    string `json:"type"`
+			Table   string `json:"table"`
+			Records []struct {
+				ID   int64  `json:"id"`
+				Name string `json:"name"`
+			} `json:"records"`
+		}
+		require.NoError(t, json.Unmarshal(r.body, &payload))
+		require.Equal(t, EventInsert, payload.Type)
+		require.Equal(t, "/"+payload.Table, r.path)
+		require.Equal(t, payload.Table, r.header.Get(HeaderTable))
+		ids := make([]int64, len(payload.Records))
+		for i, rec := range payload.Records {
+			ids[i] = rec.ID
+			require.Equal(t, "name", rec.Name)
+		}
+		require.Equal(t, wantIDs[payload.Table], ids)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	server := newTestServer(t)
+	c := newClient(t, Spec{
+		URL:       server.URL + "/ingest",
+		Method:    http.MethodPut,
+		Format:    FormatNDJSON,
+		BasicAuth: &BasicAuth{Username: "user", Password: "pass"},
+		HMAC:      &HMAC{Secret: "secret"},
+	})
+
+	require.NoError(t, write(t, c, &message.WriteInsert{Record: idsRecord(idsTable("test_table"), 1, 2)}))
+
+	requests := server.received()
+	require.Len(t, requests, 1)
+	r := requests[0]
+	require.Equal(t, http.MethodPut, r.method)
+	require.Equal(t, "/ingest", r.path)
+	require.Equal(t, "application/x-ndjson", r.header.Get("Content-Type"))
+	require.Equal(t, Signature("secret", r.body), r.header.Get(defaultSignatureHeader))
+	req := &http.Request{Header: r.header}
+	username, password, ok := req.BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, "user", username)
+	require.Equal(t, "pass", password)
+
+	lines := bytes.Split(bytes.TrimSuffix(r.body, []byte("\n")), []byte("\n"))
+	require.Len(t, lines, 2)
+	require.JSONEq(t, `{"id":1,"name":"name"}`, string(lines[0]))
+	require.JSONEq(t, `{"id":2,"name":"name"}`, string(lines[1]))
+}
+
+func TestWriteRetries(t *testing.T) {
+	cases := []struct {
+		Name         string
+		Statuses     []int
+		MaxRetries   int
+		WantRequests int
+		WantErr      bool
+	}{
+		{Name: "retryable", Statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}, MaxRetries: 5, WantRequests: 3},
+		{Name: "not retryable", Statuses: []int{http.StatusBadRequest}, MaxRetries: 5, WantRequests: 1, WantErr: true},
+		{Name: "retries exhausted", Statuses: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout}, MaxRetries: 2, WantRequests: 3, WantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			server := newTestServer(t, tc.Statuses...)
+			maxRetries := tc.MaxRetries
+			c := newClient(t, Spec{URL: server.URL, MaxRetries: &maxRetries})
+
+			// the batch writer only logs the errors of the batches, so the batch is written directly
+			table := idsTable("test_table")
+			err := c.WriteTableBatch(context.Background(), table.Name, message.WriteInserts{{Record: idsRecord(table, 1)}})
+			if tc.WantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, server.received(), tc.WantRequests)
+		})
+	}
+}
+
+func TestDeleteStale(t *testing.T) {
+	server := newTestServer(t)
+	c := newClient(t, Spec{URL: server.URL + "/{{TABLE}}", HMAC: &HMAC{Secret: "secret", Header: "X-Signature"}})
+
+	syncTime := time.Date(2023, 7, 20, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, c.DeleteStale(context.Background(), message.WriteDeleteStales{
+		{TableName: "test_table", SourceName: "source", SyncTime: syncTime},
+	}))
+
+	requests := server.received()
+	require.Len(t, requests, 1)
+	r := requests[0]
+	require.Equal(t, "/test_table", r.path)
+	require.Equal(t, EventDeleteStale, r.header.Get(HeaderEvent))
+	require.Equal(t, Signature("secret", r.body), r.header.Get("X-Signature"))
+	require.JSONEq(t, `{"type":"delete_stale","table":"test_table","source_name":"source","sync_time":"2023-07-20T10:00:00Z"}`, string(r.body))
+}
+
+func TestURLFor(t *testing.T) {
+	c := &Client{spec: &Spec{
+		URL:    "https://example.com/{{TABLE}}",
+		Tables: []TableSpec{{Tables: []string{"aws_ec2_*", "aws_s3_*"}, URL: "https://aws.example.com/ingest?table={{TABLE}}"}},
+	}}
+	cases := map[string]string{
+		"aws_ec2_instances": "https://aws.example.com/ingest?table=aws_ec2_instances",
+		"aws_s3_buckets":    "https://aws.example.com/ingest?table=aws_s3_buckets",
+		"gcp_projects":      "https://example.com/gcp_projects",
+	}
+	for table, want := range cases {
+		got, err := c.urlFor(table)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	c.spec.URL = ""
+	_, err := c.urlFor("gcp_projects")
+	require.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	HeaderEvent = "X-CloudQuery-Event"
+	HeaderTable = "X-CloudQuery-Table"
+
+	// maxErrorBodySize is how much of an error response is included in the error message
+	maxErrorBodySize = 1024
+)
+
+// request is a single payload to deliver to the webhook.
+type request struct {
+	url         string
+	event       string
+	table       string
+	contentType string
+	body        []byte
+}
+
+type statusError struct {
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("webhook responded with status %d: %s", e.status, e.body)
+}
+
+// retryable reports whether the request may succeed if sent again: the service is rate limiting or failing.
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// send delivers the request, retrying with exponential backoff on connection errors and retryable statuses.
+func (c *Client) send(ctx context.Context, r *request) error {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = c.spec.MinRetryWait.Duration()
+	b.MaxInterval = c.spec.MaxRetryWait.Duration()
+	// the number of retries is limited instead
+	b.MaxElapsedTime = 0
+
+	err := backoff.RetryNotify(
+		func() error { return c.do(ctx, r) },
+		backoff.WithContext(backoff.WithMaxRetries(b, uint64(*c.spec.MaxRetries)), ctx),
+		func(err error, wait time.Duration) {
+			c.logger.Warn().Err(err).Str("table", r.table).Str("event", r.event).Dur("wait", wait).Msg("retrying webhook request")
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to send %s payload of table %s to webhook: %w", r.event, r.table, err)
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, r *request) error {
+	req, err := http.NewRequestWithContext(ctx, c.spec.Method, r.url, bytes.NewReader(r.body))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	c.setHeaders(req, r)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		// drain the body so that the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	_, _ = io.Copy(io.Discard, resp.Body)
+	statusErr := &statusError{status: resp.StatusCode, body: string(bytes.TrimSpace(body))}
+	if retryable(resp.StatusCode) {
+		return statusErr
+	}
+	return backoff.Permanent(statusErr)
+}
+
+func (c *Client) setHeaders(req *http.Request, r *request) {
+	req.Header.Set("Content-Type", r.contentType)
+	req.Header.Set(HeaderEvent, r.event)
+	req.Header.Set(HeaderTable, r.table)
+	for k, v := range c.spec.Headers {
+		req.Header.Set(k, v)
+	}
+	switch {
+	case c.spec.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.spec.BearerToken)
+	case c.spec.BasicAuth != nil:
+		req.SetBasicAuth(c.spec.BasicAuth.Username, c.spec.BasicAuth.Password)
+	}
+	if c.spec.HMAC != nil {
+		req.Header.Set(c.spec.HMAC.Header, Signature(c.spec.HMAC.Secret, r.body))
+	}
+}
+
+// Signature returns the value of the signature header of the body: "sha256=" followed by the hex-encoded HMAC-SHA256.
+func Signature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cloudquery/plugin-sdk/v4/configtype"
+)
+
+const (
+	URLVarTable = "{{TABLE}}"
+
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+
+	defaultSignatureHeader = "X-CloudQuery-Signature"
+
+	defaultBatchSize = 1000
+	// every batch is sent as a single request, and receivers commonly limit the size of request bodies
+	defaultBatchSizeBytes = 1024 * 1024
+	defaultMaxRetries     = 5
+)
+
+type Spec struct {
+	// URL is the endpoint for the tables not matching any of Tables. {{TABLE}} is replaced with the table name.
+	URL    string      `json:"url,omitempty"`
+	Tables []TableSpec `json:"tables,omitempty"`
+
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	BearerToken string     `json:"bearer_token,omitempty"`
+	BasicAuth   *BasicAuth `json:"basic_auth,omitempty"`
+	HMAC        *HMAC      `json:"hmac,omitempty"`
+
+	Format string `json:"format,omitempty"`
+
+	Timeout      *configtype.Duration `json:"timeout,omitempty"`
+	MaxRetries   *int                 `json:"max_retries,omitempty"`
+	MinRetryWait *configtype.Duration `json:"min_retry_wait,omitempty"`
+	MaxRetryWait *configtype.Duration `json:"max_retry_wait,omitempty"`
+
+	BatchSize      int                  `json:"batch_size,omitempty"`
+	BatchSizeBytes int                  `json:"batch_size_bytes,omitempty"`
+	BatchTimeout   *configtype.Duration `json:"batch_timeout,omitempty"`
+}
+
+// TableSpec sends the tables matching any of the patterns to the URL.
+type TableSpec struct {
+	Tables []string `json:"tables"`
+	URL    string   `json:"url"`
+}
+
+type BasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// HMAC signs the request bodies with HMAC-SHA256, sending the hex-encoded signature as "sha256=<signature>".
+type HMAC struct {
+	Secret string `json:"secret"`
+	Header string `json:"header,omitempty"`
+}
+
+func (s *Spec) SetDefaults() {
+	if s.Method == "" {
+		s.Method = http.MethodPost
+	}
+	if s.Format == "" {
+		s.Format = FormatJSON
+	}
+	if s.HMAC != nil && s.HMAC.Header == "" {
+		s.HMAC.Header = defaultSignatureHeader
+	}
+	if s.Timeout == nil {
+		s.Timeout = durationPtr(30 * time.Second)
+	}
+	if s.MaxRetries == nil {
+		s.MaxRetries = new(int)
+		*s.MaxRetries = defaultMaxRetries
+	}
+	if s.MinRetryWait == nil {
+		s.MinRetryWait = durationPtr(time.Second)
+	}
+	if s.MaxRetryWait == nil {
+		s.MaxRetryWait = durationPtr(30 * time.Second)
+	}
+	if s.BatchSize == 0 {
+		s.BatchSize = defaultBatchSize
+	}
+	if s.BatchSizeBytes == 0 {
+		s.BatchSizeBytes = defaultBatchSizeBytes
+	}
+	if s.BatchTimeout == nil {
+		s.BatchTimeout = durationPtr(20 * time.Second)
+	}
+}
+
+func (s *Spec) Validate() error {
+	if s.URL == "" && len(s.Tables) == 0 {
+		return fmt.Errorf("`url` is required")
+	}
+	if s.URL != "" {
+		if err := validateURL(s.URL); err != nil {
+			return err
+		}
+	}
+	for i, t := range s.Tables {
+		if len(t.Tables) == 0 {
+			return fmt.Errorf("tables[%d]: `tables` are required", i)
+		}
+		if t.URL == "" {
+			return fmt.Errorf("tables[%d]: `url` is required", i)
+		}
+		if err := validateURL(t.URL); err != nil {
+			return fmt.Errorf("tables[%d]: %w", i, err)
+		}
+	}
+	switch s.Format {
+	case "", FormatJSON, FormatNDJSON:
+	default:
+		return fmt.Errorf("unsupported `format` %q, only %q and %q are supported", s.Format, FormatJSON, FormatNDJSON)
+	}
+	if s.BearerToken != "" && s.BasicAuth != nil {
+		return fmt.Errorf("only one of `bearer_token` or `basic_auth` is allowed")
+	}
+	if s.BasicAuth != nil && s.BasicAuth.Username == "" {
+		return fmt.Errorf("`basic_auth.username` is required")
+	}
+	if s.HMAC != nil && s.HMAC.Secret == "" {
+		return fmt.Errorf("`hmac.secret` is required")
+	}
+	if s.MaxRetries != nil && *s.MaxRetries < 0 {
+		return fmt.Errorf("`max_retries` must not be negative")
+	}
+	if s.MinRetryWait != nil && s.MaxRetryWait != nil && s.MinRetryWait.Duration() > s.MaxRetryWait.Duration() {
+		return fmt.Errorf("`min_retry_wait` must not be greater than `max_retry_wait`")
+	}
+	return nil
+}
+
+// validateURL checks that the URL template is an absolute HTTP(S) URL once the variables are replaced.
+func validateURL(template string) error {
+	u, err := url.Parse(strings.ReplaceAll(template, URLVarTable, "table"))
+	if err != nil {
+		return fmt.Errorf("invalid `url` %q: %w", template, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid `url` %q: must be an absolute http or https URL", template)
+	}
+	return nil
+}
+
+func durationPtr(d time.Duration) *configtype.Duration {
+	duration := configtype.NewDuration(d)
+	return &duration
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpec_Validate(t *testing.T) {
+	negative := -1
+	cases := []struct {
+		Name    string
+		Give    Spec
+		WantErr bool
+	}{
+		{Name: "url", Give: Spec{URL: "https://example.com/{{TABLE}}"}},
+		{Name: "tables", Give: Spec{Tables: []TableSpec{{Tables: []string{"aws_*"}, URL: "http://localhost:8080/aws"}}}},
+		{Name: "no url", Give: Spec{}, WantErr: true},
+		{Name: "relative url", Give: Spec{URL: "/{{TABLE}}"}, WantErr: true},
+		{Name: "unsupported scheme", Give: Spec{URL: "ftp://example.com"}, WantErr: true},
+		{Name: "tables without patterns", Give: Spec{Tables: []TableSpec{{URL: "https://example.com"}}}, WantErr: true},
+		{Name: "tables without url", Give: Spec{URL: "https://example.com", Tables: []TableSpec{{Tables: []string{"*"}}}}, WantErr: true},
+		{Name: "ndjson", Give: Spec{URL: "https://example.com", Format: FormatNDJSON}},
+		{Name: "unsupported format", Give: Spec{URL: "https://example.com", Format: "csv"}, WantErr: true},
+		{Name: "bearer and basic", Give: Spec{URL: "https://example.com", BearerToken: "token", BasicAuth: &BasicAuth{Username: "user"}}, WantErr: true},
+		{Name: "basic without username", Give: Spec{URL: "https://example.com", BasicAuth: &BasicAuth{Password: "pass"}}, WantErr: true},
+		{Name: "hmac without secret", Give: Spec{URL: "https://example.com", HMAC: &HMAC{}}, WantErr: true},
+		{Name: "negative retries", Give: Spec{URL: "https://example.com", MaxRetries: &negative}, WantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Give.Validate()
+			if tc.WantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSpec_SetDefaults(t *testing.T) {
+	s := Spec{URL: "https://example.com", HMAC: &HMAC{Secret: "secret"}}
+	s.SetDefaults()
+	require.Equal(t, "POST", s.Method)
+	require.Equal(t, FormatJSON, s.Format)
+	require.Equal(t, defaultSignatureHeader, s.HMAC.Header)
+	require.Equal(t, defaultMaxRetries, *s.MaxRetries)
+	require.Equal(t, defaultBatchSize, s.BatchSize)
+	require.Equal(t, defaultBatchSizeBytes, s.BatchSizeBytes)
+	require.NoError(t, s.Validate())
+}
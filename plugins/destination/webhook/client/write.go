@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+)
+
+const (
+	EventInsert      = "insert"
+	EventDeleteStale = "delete_stale"
+
+	contentTypeJSON   = "application/json"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+// InsertPayload is the body of the insert requests in the JSON format.
+// In the NDJSON format the body is only the records, one per line.
+type InsertPayload struct {
+	Type    string            `json:"type"`
+	Table   string            `json:"table"`
+	Records []json.RawMessage `json:"records"`
+}
+
+// DeleteStalePayload is the body of the delete-stale requests, sent when the records of the source
+// synced before SyncTime should be deleted. In the NDJSON format it's sent as a single line.
+type DeleteStalePayload struct {
+	Type       string    `json:"type"`
+	Table      string    `json:"table"`
+	SourceName string    `json:"source_name"`
+	SyncTime   time.Time `json:"sync_time"`
+}
+
+func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) error {
+	if err := c.writer.Write(ctx, msgs); err != nil {
+		return fmt.Errorf("failed to write messages: %w", err)
+	}
+	if err := c.writer.Flush(ctx); err != nil {
+		return fmt.Errorf("failed to flush writer: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) WriteTableBatch(ctx context.Context, name string, msgs message.WriteInserts) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	u, err := c.urlFor(name)
+	if err != nil {
+		return err
+	}
+
+	records := make([]arrow.Record, len(msgs))
+	for i, msg := range msgs {
+		records[i] = msg.Record
+	}
+	var buf bytes.Buffer
+	if err := c.encoder.WriteTableBatchFile(&buf, msgs[0].GetTable(), records); err != nil {
+		return fmt.Errorf("failed to encode records of table %s: %w", name, err)
+	}
+
+	r := &request{url: u, event: EventInsert, table: name}
+	switch c.spec.Format {
+	case FormatNDJSON:
+		r.contentType = contentTypeNDJSON
+		r.body = buf.Bytes()
+	default:
+		lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+		payload := InsertPayload{Type: EventInsert, Table: name, Records: make([]json.RawMessage, len(lines))}
+		for i, line := range lines {
+			payload.Records[i] = line
+		}
+		r.contentType = contentTypeJSON
+		if r.body, err = json.Marshal(payload); err != nil {
+			return fmt.Errorf("failed to marshal records of table %s: %w", name, err)
+		}
+	}
+	return c.send(ctx, r)
+}
+
+func (c *Client) DeleteStale(ctx context.Context, msgs message.WriteDeleteStales) error {
+	for _, msg := range msgs {
+		u, err := c.urlFor(msg.TableName)
+		if err != nil {
+			return err
+		}
+		body, err := json.Marshal(DeleteStalePayload{
+			Type:       EventDeleteStale,
+			Table:      msg.TableName,
+			SourceName: msg.SourceName,
+			SyncTime:   msg.SyncTime.UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal delete stale payload of table %s: %w", msg.TableName, err)
+		}
+		r := &request{url: u, event: EventDeleteStale, table: msg.TableName, contentType: contentTypeJSON, body: body}
+		if c.spec.Format == FormatNDJSON {
+			r.contentType = contentTypeNDJSON
+			r.body = append(r.body, '\n')
+		}
+		if err := c.send(ctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
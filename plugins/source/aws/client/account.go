@@ -33,7 +33,10 @@ func (c *Client) setupAWSAccount(ctx context.Context, logger zerolog.Logger, aws
 		localRegions = awsPluginSpec.Regions
 	}
 
-	if err := verifyRegions(localRegions); err != nil {
+	// With the ec2 region discovery the regions missing from the bundled data are accepted here,
+	// and checked against the regions described by EC2 for the account instead
+	discovery := awsPluginSpec.RegionDiscovery == RegionDiscoveryEC2
+	if err := verifyRegions(localRegions, discovery); err != nil {
 		return nil, err
 	}
 
@@ -43,7 +46,7 @@ func (c *Client) setupAWSAccount(ctx context.Context, logger zerolog.Logger, aws
 		c.specificRegions = false
 	}
 
-	awsCfg, err := configureAwsSDK(ctx, logger, awsPluginSpec, account, adminAccountSts)
+	awsCfg, err := configureAwsSDK(ctx, logger, awsPluginSpec, account, adminAccountSts, c.rateLimiter)
 	if err != nil {
 		if account.source == "org" {
 			logger.Warn().Msg("Unable to assume role in account")
@@ -63,7 +66,10 @@ func (c *Client) setupAWSAccount(ctx context.Context, logger zerolog.Logger, aws
 
 		return nil, err
 	}
-	account.Regions = findEnabledRegions(ctx, logger, account.AccountName, ec2.NewFromConfig(awsCfg), localRegions, account.DefaultRegion)
+	account.Regions, err = findEnabledRegions(ctx, logger, account.AccountName, ec2.NewFromConfig(awsCfg), localRegions, account.DefaultRegion, discovery)
+	if err != nil {
+		return nil, err
+	}
 	if len(account.Regions) == 0 {
 		logger.Warn().Str("account", account.AccountName).Err(err).Msg("No enabled regions provided in config for account")
 		return nil, nil
@@ -89,7 +95,10 @@ func (c *Client) setupAWSAccount(ctx context.Context, logger zerolog.Logger, aws
 	return &svcsDetails, nil
 }
 
-func findEnabledRegions(ctx context.Context, logger zerolog.Logger, accountName string, ec2Client services.Ec2Client, localRegions []string, accountDefaultRegion string) []string {
+// findEnabledRegions returns the regions enabled for the account out of the configured ones.
+// With discovery, all the regions of the partition are described, including the ones the account hasn't opted in to,
+// so that the configured regions unknown to EC2 are rejected and the ones not opted in to are reported as skipped.
+func findEnabledRegions(ctx context.Context, logger zerolog.Logger, accountName string, ec2Client services.Ec2Client, localRegions []string, accountDefaultRegion string, discovery bool) ([]string, error) {
 	// By default we should use the default region (us-east-1)
 	regionsToCheck := []string{defaultRegion}
 	// If user specifies a Default Region we should use it
@@ -101,22 +110,28 @@ func findEnabledRegions(ctx context.Context, logger zerolog.Logger, accountName
 	}
 
 	for _, region := range regionsToCheck {
-		enabledRegions, err := getEnabledRegions(ctx, ec2Client, region)
+		regions, err := describeRegions(ctx, ec2Client, region, discovery)
 		if err != nil {
 			logger.Warn().Str("account", accountName).Err(err).Msgf("Failed to find disabled regions for account when checking: %s", region)
 			continue
 		}
-		filteredRegions := filterDisabledRegions(localRegions, enabledRegions)
+		if discovery {
+			if err := checkOptInStatus(logger, accountName, localRegions, regions); err != nil {
+				return nil, err
+			}
+		}
+		filteredRegions := filterDisabledRegions(localRegions, regions)
 		if len(filteredRegions) > 0 {
-			return filteredRegions
+			return filteredRegions, nil
 		}
 	}
-	return []string{}
+	return []string{}, nil
 }
 
-func getEnabledRegions(ctx context.Context, ec2Client services.Ec2Client, region string) ([]types.Region, error) {
+// describeRegions returns the regions enabled for the account, or all the regions of the partition with allRegions.
+func describeRegions(ctx context.Context, ec2Client services.Ec2Client, region string, allRegions bool) ([]types.Region, error) {
 	res, err := ec2Client.DescribeRegions(ctx,
-		&ec2.DescribeRegionsInput{AllRegions: aws.Bool(false)},
+		&ec2.DescribeRegionsInput{AllRegions: aws.Bool(allRegions)},
 		func(o *ec2.Options) {
 			o.Region = region
 		})
@@ -126,6 +141,30 @@ func getEnabledRegions(ctx context.Context, ec2Client services.Ec2Client, region
 	return res.Regions, nil
 }
 
+// checkOptInStatus checks the configured regions against all the regions of the partition described by EC2.
+// Regions unknown to EC2 are rejected, and regions the account hasn't opted in to are logged, as they are skipped.
+func checkOptInStatus(logger zerolog.Logger, accountName string, localRegions []string, regions []types.Region) error {
+	if isAllRegions(localRegions) {
+		return nil
+	}
+	optInStatus := make(map[string]string, len(regions))
+	for _, r := range regions {
+		if r.RegionName != nil {
+			optInStatus[*r.RegionName] = aws.ToString(r.OptInStatus)
+		}
+	}
+	for _, region := range localRegions {
+		status, ok := optInStatus[region]
+		if !ok {
+			return errUnknownRegion(region)
+		}
+		if status == "not-opted-in" {
+			logger.Warn().Str("account", accountName).Str("region", region).Msg("Skipping region the account has not opted in to")
+		}
+	}
+	return nil
+}
+
 func filterDisabledRegions(regions []string, enabledRegions []types.Region) []string {
 	regionsMap := map[string]bool{}
 	for _, r := range enabledRegions {
@@ -156,7 +195,7 @@ func getAccountId(ctx context.Context, awsCfg aws.Config) (*sts.GetCallerIdentit
 	return svc.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 }
 
-func verifyRegions(regions []string) error {
+func verifyRegions(regions []string, allowUnknown bool) error {
 	availableRegions, err := getAvailableRegions()
 	if err != nil {
 		return err
@@ -175,7 +214,7 @@ func verifyRegions(regions []string) error {
 			return errInvalidRegion
 		}
 		regionExist := availableRegions[region]
-		if !hasWildcard && !regionExist {
+		if !hasWildcard && !regionExist && !allowUnknown {
 			return errUnknownRegion(region)
 		}
 	}
@@ -183,7 +222,7 @@ func verifyRegions(regions []string) error {
 }
 func isAllRegions(regions []string) bool {
 	// if regions array is not valid return false
-	err := verifyRegions(regions)
+	err := verifyRegions(regions, true)
 	if err != nil {
 		return false
 	}
@@ -9,10 +9,11 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
 	"github.com/rs/zerolog"
 )
 
-func configureAwsSDK(ctx context.Context, logger zerolog.Logger, awsPluginSpec *Spec, account Account, stsClient AssumeRoleAPIClient) (aws.Config, error) {
+func configureAwsSDK(ctx context.Context, logger zerolog.Logger, awsPluginSpec *Spec, account Account, stsClient AssumeRoleAPIClient, rateLimiter *serviceRateLimiter) (aws.Config, error) {
 	var err error
 	var awsCfg aws.Config
 
@@ -29,13 +30,27 @@ func configureAwsSDK(ctx context.Context, logger zerolog.Logger, awsPluginSpec *
 		config.WithDefaultRegion(defaultRegion),
 		// https://aws.github.io/aws-sdk-go-v2/docs/configuring-sdk/retries-timeouts/
 		config.WithRetryer(func() aws.Retryer {
-			return retry.NewStandard(func(so *retry.StandardOptions) {
+			standardOptions := func(so *retry.StandardOptions) {
 				so.MaxAttempts = maxAttempts
 				so.MaxBackoff = time.Duration(maxBackoff) * time.Second
 				so.RateLimiter = &NoRateLimiter{}
-			})
+			}
+			if awsPluginSpec.RetryMode == string(aws.RetryModeAdaptive) {
+				// The adaptive mode also delays the requests client-side once the service starts throttling them
+				return retry.NewAdaptiveMode(func(ao *retry.AdaptiveModeOptions) {
+					ao.StandardOptions = append(ao.StandardOptions, standardOptions)
+				})
+			}
+			return retry.NewStandard(standardOptions)
 		}),
 	}
+	if rateLimiter != nil {
+		accountKey := account.ID
+		if accountKey == "" {
+			accountKey = account.AccountName
+		}
+		configFns = append(configFns, config.WithAPIOptions([]func(*middleware.Stack) error{rateLimiter.middleware(accountKey)}))
+	}
 	if awsPluginSpec.EndpointURL != "" {
 		configFns = append(configFns, config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(
 			func(service, region string, options ...any) (aws.Endpoint, error) {
@@ -49,6 +64,14 @@ func configureAwsSDK(ctx context.Context, logger zerolog.Logger, awsPluginSpec *
 		)
 	}
 
+	// The endpoint variants are resolved by every service client created from the config
+	if awsPluginSpec.UseFIPSEndpoints {
+		configFns = append(configFns, config.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+	if awsPluginSpec.UseDualStackEndpoints {
+		configFns = append(configFns, config.WithUseDualStackEndpoint(aws.DualStackEndpointStateEnabled))
+	}
+
 	if account.DefaultRegion != "" {
 		// According to the docs: If multiple WithDefaultRegion calls are made, the last call overrides the previous call values
 		configFns = append(configFns, config.WithDefaultRegion(account.DefaultRegion))
@@ -32,6 +32,8 @@ type Client struct {
 	LanguageCode         string
 	Backend              state.Client
 	specificRegions      bool
+	rateLimiter          *serviceRateLimiter
+	throttles            *throttleCounter
 	Spec                 *Spec
 	// Do not rely on this field, it will be removed once https://github.com/aws/aws-sdk-go-v2/issues/2163 is resolved
 	AWSConfig *aws.Config
@@ -121,6 +123,8 @@ func (c *Client) Services() *Services {
 
 func (c *Client) Duplicate() *Client {
 	duplicateClient := *c
+	// every sync counts its own throttled requests
+	duplicateClient.throttles = newThrottleCounter()
 	return &duplicateClient
 }
 
@@ -182,12 +186,13 @@ func Configure(ctx context.Context, logger zerolog.Logger, spec Spec) (schema.Cl
 	spec.SetDefaults()
 
 	client := NewAwsClient(logger, &spec)
+	client.rateLimiter = newServiceRateLimiter(spec.RateLimits)
 
 	var adminAccountSts AssumeRoleAPIClient
 
 	if client.Spec.Organization != nil {
 		var err error
-		client.Spec.Accounts, adminAccountSts, err = loadOrgAccounts(ctx, logger, client.Spec)
+		client.Spec.Accounts, adminAccountSts, err = loadOrgAccounts(ctx, logger, client.Spec, client.rateLimiter)
 		if err != nil {
 			logger.Error().Err(err).Msg("error getting child accounts")
 			return nil, err
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -82,7 +83,10 @@ func Test_findEnabledRegions(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		api := mocks.NewMockEc2Client(ctrl)
 		api.EXPECT().DescribeRegions(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.regionsReply, test.regionsReplyError)
-		enabledRegions := findEnabledRegions(ctx, zerolog.New(os.Stderr).With().Logger(), "test", api, test.requestedRegions, test.requestedDefaultRegion)
+		enabledRegions, err := findEnabledRegions(ctx, zerolog.New(os.Stderr).With().Logger(), "test", api, test.requestedRegions, test.requestedDefaultRegion, false)
+		if err != nil {
+			t.Fatal(err)
+		}
 		respDiff := cmp.Diff(test.expectedRegions, enabledRegions)
 
 		if respDiff != "" {
@@ -90,3 +94,83 @@ func Test_findEnabledRegions(t *testing.T) {
 		}
 	}
 }
+
+func Test_findEnabledRegionsDiscovery(t *testing.T) {
+	ctx := context.Background()
+	regionsReply := &ec2.DescribeRegionsOutput{
+		Regions: []types.Region{
+			{
+				OptInStatus: aws.String("opt-in-not-required"),
+				RegionName:  aws.String("us-east-1"),
+			}, {
+				OptInStatus: aws.String("opted-in"),
+				RegionName:  aws.String("xx-new-1"),
+			}, {
+				OptInStatus: aws.String("not-opted-in"),
+				RegionName:  aws.String("us-east-5"),
+			},
+		},
+	}
+	tests := []struct {
+		requestedRegions []string
+		expectedRegions  []string
+		wantErr          bool
+	}{
+		{
+			// regions missing from the bundled data are synced once the account has opted in to them
+			requestedRegions: []string{"us-east-1", "xx-new-1", "us-east-5"},
+			expectedRegions:  []string{"us-east-1", "xx-new-1"},
+		},
+		{
+			requestedRegions: []string{"*"},
+			expectedRegions:  []string{"us-east-1", "xx-new-1"},
+		},
+		{
+			// regions unknown to EC2 are rejected
+			requestedRegions: []string{"us-east-1", "xx-typo-1"},
+			wantErr:          true,
+		},
+	}
+
+	for _, test := range tests {
+		ctrl := gomock.NewController(t)
+		api := mocks.NewMockEc2Client(ctrl)
+		api.EXPECT().DescribeRegions(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *ec2.DescribeRegionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+				if !aws.ToBool(input.AllRegions) {
+					t.Error("expected all the regions of the partition to be described")
+				}
+				return regionsReply, nil
+			})
+		enabledRegions, err := findEnabledRegions(ctx, zerolog.New(os.Stderr).With().Logger(), "test", api, test.requestedRegions, "", true)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("got error %v, want error %v", err, test.wantErr)
+		}
+		sort.Strings(enabledRegions)
+		if diff := cmp.Diff(test.expectedRegions, enabledRegions); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+}
+
+func Test_verifyRegions(t *testing.T) {
+	tests := []struct {
+		regions      []string
+		allowUnknown bool
+		wantErr      bool
+	}{
+		{regions: []string{"us-east-1", "eu-west-1"}},
+		{regions: []string{"*"}},
+		{regions: []string{"us-east-1", "*"}, wantErr: true},
+		{regions: []string{"*", "us-east-1"}, wantErr: true},
+		{regions: []string{"us-east-1", "xx-unknown-1"}, wantErr: true},
+		// with the ec2 region discovery the regions missing from the bundled data are accepted
+		{regions: []string{"us-east-1", "xx-unknown-1"}, allowUnknown: true},
+		{regions: []string{"us-east-1", "*"}, allowUnknown: true, wantErr: true},
+	}
+	for _, test := range tests {
+		if err := verifyRegions(test.regions, test.allowUnknown); (err != nil) != test.wantErr {
+			t.Errorf("verifyRegions(%v, %v) error = %v, wantErr %v", test.regions, test.allowUnknown, err, test.wantErr)
+		}
+	}
+}
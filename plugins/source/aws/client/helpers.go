@@ -19,19 +19,29 @@ import (
 
 type AWSService string
 
+type AwsRegion struct {
+	// Variants are the endpoint variants of the service in the region, as their tags sorted and joined by ","
+	// ("dualstack", "fips" or "dualstack,fips").
+	Variants []string `json:"variants,omitempty"`
+}
+
 type AwsService struct {
-	Regions map[string]*map[string]any `json:"regions"`
+	Regions map[string]*AwsRegion `json:"regions"`
 }
 
 type AwsPartition struct {
-	Id       string                 `json:"partition"`
-	Name     string                 `json:"partitionName"`
-	Services map[string]*AwsService `json:"services"`
+	Id                string                 `json:"partition"`
+	Name              string                 `json:"partitionName"`
+	SupportsFIPS      bool                   `json:"supportsFIPS"`
+	SupportsDualStack bool                   `json:"supportsDualStack"`
+	Services          map[string]*AwsService `json:"services"`
 }
 
 type SupportedServiceRegionsData struct {
 	Partitions        map[string]AwsPartition `json:"partitions"`
 	regionVsPartition map[string]string
+	// partitionHasVariants tells whether any region of the partition lists endpoint variants
+	partitionHasVariants map[string]bool
 }
 
 // ListResolver is responsible for iterating through entire list of resources that should be grabbed (if API is paginated). It should send list of items via the `resultsChan` so that the DetailResolver can grab the details of each item. All errors should be sent to the error channel.
@@ -67,6 +77,8 @@ const (
 const (
 	PartitionServiceRegionFile = "data/partition_service_region.json"
 	defaultPartition           = "aws"
+	endpointVariantDualStack   = "dualstack"
+	endpointVariantFIPS        = "fips"
 )
 
 var (
@@ -114,16 +126,24 @@ func ReadSupportedServiceRegions() *SupportedServiceRegionsData {
 		return nil
 	}
 
-	result.regionVsPartition = make(map[string]string)
-	for _, p := range result.Partitions {
+	result.index()
+	return &result
+}
+
+// index builds the lookups of the data.
+func (d *SupportedServiceRegionsData) index() {
+	d.regionVsPartition = make(map[string]string)
+	d.partitionHasVariants = make(map[string]bool)
+	for _, p := range d.Partitions {
 		for _, svc := range p.Services {
-			for reg := range svc.Regions {
-				result.regionVsPartition[reg] = p.Id
+			for reg, r := range svc.Regions {
+				d.regionVsPartition[reg] = p.Id
+				if r != nil && len(r.Variants) > 0 {
+					d.partitionHasVariants[p.Id] = true
+				}
 			}
 		}
 	}
-
-	return &result
 }
 
 func supportedRegions(service string) []string {
@@ -163,6 +183,54 @@ func isSupportedServiceForRegion(service string, region string) bool {
 	return false
 }
 
+// supportsEndpointVariant returns whether the service has a FIPS and/or dual-stack endpoint in the region.
+func supportsEndpointVariant(service string, region string, fips bool, dualStack bool) bool {
+	if !fips && !dualStack {
+		return true
+	}
+	readOnce.Do(func() {
+		supportedServiceRegion = ReadSupportedServiceRegions()
+	})
+	if supportedServiceRegion == nil {
+		return true
+	}
+	return supportedServiceRegion.supportsEndpointVariant(service, region, fips, dualStack)
+}
+
+// supportsEndpointVariant returns whether the service has a FIPS and/or dual-stack endpoint in the region.
+// The regions of a service list the variants of their endpoints, so a listed region without the variant doesn't support it.
+// The unknown services and regions are assumed to have the variants their partition supports, as are all the services
+// of the partitions whose data lists no variants at all.
+func (d *SupportedServiceRegionsData) supportsEndpointVariant(service string, region string, fips bool, dualStack bool) bool {
+	partition, ok := d.Partitions[d.regionVsPartition[region]]
+	if !ok {
+		return true
+	}
+	if (fips && !partition.SupportsFIPS) || (dualStack && !partition.SupportsDualStack) {
+		return false
+	}
+	if !d.partitionHasVariants[partition.Id] {
+		return true
+	}
+	if partition.Services[service] == nil || partition.Services[service].Regions[region] == nil {
+		return true
+	}
+	var tags []string
+	if dualStack {
+		tags = append(tags, endpointVariantDualStack)
+	}
+	if fips {
+		tags = append(tags, endpointVariantFIPS)
+	}
+	variant := strings.Join(tags, ",")
+	for _, v := range partition.Services[service].Regions[region].Variants {
+		if v == variant {
+			return true
+		}
+	}
+	return false
+}
+
 func getAvailableRegions() (map[string]bool, error) {
 	readOnce.Do(func() {
 		supportedServiceRegion = ReadSupportedServiceRegions()
@@ -180,3 +180,73 @@ func TestTagsIntoMap(t *testing.T) {
 
 	assert.Equal(t, map[string]string{"k": "v", "k2": "v2"}, res)
 }
+
+func TestSupportsEndpointVariant(t *testing.T) {
+	tests := []struct {
+		service   string
+		region    string
+		fips      bool
+		dualStack bool
+		expected  bool
+	}{
+		{service: "ec2", region: "us-east-1", expected: true},
+		{service: "ec2", region: "us-east-1", fips: true, dualStack: true, expected: true},
+		{service: "ec2", region: "us-iso-east-1", fips: true, expected: true},
+		// the iso partitions have no dual-stack endpoints
+		{service: "ec2", region: "us-iso-east-1", dualStack: true, expected: false},
+		{service: "ec2", region: "xx-unknown-1", fips: true, dualStack: true, expected: true},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, supportsEndpointVariant(tc.service, tc.region, tc.fips, tc.dualStack), "%s %s fips=%v dualstack=%v", tc.service, tc.region, tc.fips, tc.dualStack)
+	}
+}
+
+func TestSupportsEndpointVariantListed(t *testing.T) {
+	data := &SupportedServiceRegionsData{
+		Partitions: map[string]AwsPartition{
+			"aws": {
+				Id:                "aws",
+				SupportsFIPS:      true,
+				SupportsDualStack: true,
+				Services: map[string]*AwsService{
+					"ec2": {Regions: map[string]*AwsRegion{
+						"us-east-1":  {Variants: []string{"dualstack", "dualstack,fips", "fips"}},
+						"ap-south-1": {Variants: []string{"dualstack"}},
+						"eu-west-1":  {},
+					}},
+				},
+			},
+			// the data of a partition listing no variants, as generated before they were recorded
+			"aws-us-gov": {
+				Id:                "aws-us-gov",
+				SupportsFIPS:      true,
+				SupportsDualStack: true,
+				Services: map[string]*AwsService{
+					"ec2": {Regions: map[string]*AwsRegion{"us-gov-west-1": {}}},
+				},
+			},
+		},
+	}
+	data.index()
+
+	tests := []struct {
+		service   string
+		region    string
+		fips      bool
+		dualStack bool
+		expected  bool
+	}{
+		{service: "ec2", region: "us-east-1", fips: true, expected: true},
+		{service: "ec2", region: "us-east-1", fips: true, dualStack: true, expected: true},
+		{service: "ec2", region: "ap-south-1", dualStack: true, expected: true},
+		{service: "ec2", region: "ap-south-1", fips: true, expected: false},
+		{service: "ec2", region: "ap-south-1", fips: true, dualStack: true, expected: false},
+		{service: "ec2", region: "eu-west-1", fips: true, expected: false},
+		{service: "ec2", region: "eu-west-1", dualStack: true, expected: false},
+		{service: "s3", region: "us-east-1", fips: true, expected: true},
+		{service: "ec2", region: "us-gov-west-1", fips: true, dualStack: true, expected: true},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, data.supportsEndpointVariant(tc.service, tc.region, tc.fips, tc.dualStack), "%s %s fips=%v dualstack=%v", tc.service, tc.region, tc.fips, tc.dualStack)
+	}
+}
@@ -0,0 +1,134 @@
+package iampolicy
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ActionsFile is the action catalog, mapping the service prefixes to the names of their actions.
+// It is generated by tools/iam_actions.
+const ActionsFile = "data/actions.json"
+
+var (
+	//go:embed data/actions.json
+	actionsFile []byte
+	actionsOnce sync.Once
+	actions     []string
+)
+
+// Actions returns the sorted actions of the catalog, as <service prefix>:<action name>.
+func Actions() []string {
+	actionsOnce.Do(func() {
+		var catalog map[string][]string
+		if err := json.Unmarshal(actionsFile, &catalog); err != nil {
+			panic(err)
+		}
+		for prefix, names := range catalog {
+			for _, name := range names {
+				actions = append(actions, prefix+":"+name)
+			}
+		}
+		sort.Strings(actions)
+	})
+	return actions
+}
+
+// ExpandActions returns the actions of the catalog matching the action patterns.
+// The patterns without wildcards, and those matching no action of the catalog (such as
+// the actions of a service missing from it), are returned as they are. The patterns with
+// wildcards in the service prefix, such as "*", are returned too, as they also match the
+// actions of the services missing from the catalog.
+func ExpandActions(patterns []string) []string {
+	var expanded []string
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, "*?") {
+			expanded = append(expanded, pattern)
+			continue
+		}
+		matched := false
+		for _, action := range Actions() {
+			if MatchAction(pattern, action) {
+				expanded = append(expanded, action)
+				matched = true
+			}
+		}
+		if !matched || matchesAnyService(pattern) {
+			expanded = append(expanded, pattern)
+		}
+	}
+	return sortedSet(expanded)
+}
+
+// ExpandNotActions returns the actions of the catalog not matching any of the action patterns,
+// which are the actions a statement with a NotAction element applies to. As the statement also
+// applies to the actions of the services missing from the catalog, "*" is returned too.
+func ExpandNotActions(patterns []string) []string {
+	expanded := []string{"*"}
+	for _, action := range Actions() {
+		matched := false
+		for _, pattern := range patterns {
+			if MatchAction(pattern, action) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			expanded = append(expanded, action)
+		}
+	}
+	return expanded
+}
+
+// matchesAnyService reports whether the service prefix of the action pattern has wildcards.
+func matchesAnyService(pattern string) bool {
+	prefix, _, _ := strings.Cut(pattern, ":")
+	return strings.ContainsAny(prefix, "*?")
+}
+
+// MatchAction reports whether the action matches the pattern, in which "*" matches any sequence
+// of characters and "?" any single character. Actions are case-insensitive.
+func MatchAction(pattern, action string) bool {
+	return match(strings.ToLower(pattern), strings.ToLower(action))
+}
+
+func match(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			pattern = strings.TrimLeft(pattern, "*")
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if match(pattern, s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+		default:
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return len(s) == 0
+}
+
+func sortedSet(values []string) []string {
+	sort.Strings(values)
+	unique := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,64 @@
+package iampolicy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchAction(t *testing.T) {
+	cases := []struct {
+		pattern string
+		action  string
+		want    bool
+	}{
+		{"*", "s3:GetObject", true},
+		{"s3:*", "s3:GetObject", true},
+		{"s3:Get*", "s3:GetObject", true},
+		{"S3:getobject", "s3:GetObject", true},
+		{"s3:Get*Acl", "s3:GetObjectAcl", true},
+		{"s3:Get*Acl", "s3:GetObject", false},
+		{"s3:GetObjec?", "s3:GetObject", true},
+		{"s3:GetObject?", "s3:GetObject", false},
+		{"sqs:*", "s3:GetObject", false},
+		{"s3:Put*", "s3:GetObject", false},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, MatchAction(tc.pattern, tc.action), "%s %s", tc.pattern, tc.action)
+	}
+}
+
+func TestExpandActions(t *testing.T) {
+	got := ExpandActions([]string{"sqs:*Message", "sqs:SendMessage", "ec2:DescribeInstances", "unknown:Get*"})
+	want := []string{"ec2:DescribeInstances", "sqs:DeleteMessage", "sqs:ReceiveMessage", "sqs:SendMessage", "unknown:Get*"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("actions mismatch (-want +got):\n%s", diff)
+	}
+
+	// the patterns with wildcard service prefixes are kept for the services missing from the catalog
+	assert.Equal(t, append([]string{"*"}, Actions()...), ExpandActions([]string{"*"}))
+	got = ExpandActions([]string{"s?s:GetQueue*"})
+	want = []string{"s?s:GetQueue*", "sqs:GetQueueAttributes", "sqs:GetQueueUrl"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("actions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExpandNotActions(t *testing.T) {
+	got := ExpandNotActions([]string{"sqs:*Queue*", "sqs:*Permission", "sqs:*Message"})
+	assert.Contains(t, got, "s3:GetObject")
+	// the statement applies to the services missing from the catalog too
+	assert.Equal(t, "*", got[0])
+
+	var sqs []string
+	for _, action := range got {
+		if MatchAction("sqs:*", action) {
+			sqs = append(sqs, action)
+		}
+	}
+	want := []string{"sqs:CancelMessageMoveTask", "sqs:ChangeMessageVisibility", "sqs:ListMessageMoveTasks", "sqs:StartMessageMoveTask"}
+	if diff := cmp.Diff(want, sqs); diff != "" {
+		t.Errorf("actions mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,113 @@
+// Package iampolicy parses IAM policy documents into normalized statements and
+// expands the action patterns of the statements against a bundled action catalog.
+package iampolicy
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// Document is an IAM policy document.
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_grammar.html
+type Document struct {
+	Version   string
+	ID        string `json:"Id"`
+	Statement Statements
+}
+
+// Statement is a statement of a policy document, normalized so that its elements are always lists.
+type Statement struct {
+	// Index is the position of the statement in the document.
+	Index        int `json:"-"`
+	Sid          string
+	Effect       string
+	Principal    Principals
+	NotPrincipal Principals
+	Action       Values
+	NotAction    Values
+	Resource     Values
+	NotResource  Values
+	Condition    map[string]any
+}
+
+// Statements unmarshals both a single statement and a list of statements.
+type Statements []Statement
+
+func (s *Statements) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var statement Statement
+		if err := json.Unmarshal(data, &statement); err != nil {
+			return err
+		}
+		*s = Statements{statement}
+		return nil
+	}
+	var statements []Statement
+	if err := json.Unmarshal(data, &statements); err != nil {
+		return err
+	}
+	*s = statements
+	return nil
+}
+
+// Values unmarshals both a single value and a list of values.
+type Values []string
+
+func (v *Values) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*v = Values{value}
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*v = values
+	return nil
+}
+
+// Principals maps the principal types (AWS, Service, Federated, CanonicalUser) to the principals.
+// The "*" principal is the same as {"AWS": "*"}.
+type Principals map[string]Values
+
+func (p *Principals) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*p = Principals{"AWS": Values{value}}
+		return nil
+	}
+	var principals map[string]Values
+	if err := json.Unmarshal(data, &principals); err != nil {
+		return err
+	}
+	*p = principals
+	return nil
+}
+
+// Parse parses the policy document, which may be URL-encoded as returned by the IAM API.
+func Parse(document string) (*Document, error) {
+	document = strings.TrimSpace(document)
+	if !strings.HasPrefix(document, "{") {
+		decoded, err := url.QueryUnescape(document)
+		if err != nil {
+			return nil, err
+		}
+		document = decoded
+	}
+	var doc Document
+	if err := json.Unmarshal([]byte(document), &doc); err != nil {
+		return nil, err
+	}
+	for i := range doc.Statement {
+		doc.Statement[i].Index = i
+	}
+	return &doc, nil
+}
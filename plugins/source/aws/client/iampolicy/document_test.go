@@ -0,0 +1,73 @@
+package iampolicy
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	const document = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "Read",
+      "Effect": "Allow",
+      "Principal": {"AWS": "arn:aws:iam::123456789012:root", "Service": ["s3.amazonaws.com", "sqs.amazonaws.com"]},
+      "Action": "s3:Get*",
+      "Resource": ["arn:aws:s3:::bucket", "arn:aws:s3:::bucket/*"],
+      "Condition": {"Bool": {"aws:SecureTransport": "true"}}
+    },
+    {
+      "Effect": "Deny",
+      "NotPrincipal": "*",
+      "NotAction": ["s3:List*"],
+      "NotResource": "arn:aws:s3:::bucket"
+    }
+  ]
+}`
+	want := &Document{
+		Version: "2012-10-17",
+		Statement: Statements{
+			{
+				Sid:       "Read",
+				Effect:    "Allow",
+				Principal: Principals{"AWS": {"arn:aws:iam::123456789012:root"}, "Service": {"s3.amazonaws.com", "sqs.amazonaws.com"}},
+				Action:    Values{"s3:Get*"},
+				Resource:  Values{"arn:aws:s3:::bucket", "arn:aws:s3:::bucket/*"},
+				Condition: map[string]any{"Bool": map[string]any{"aws:SecureTransport": "true"}},
+			},
+			{
+				Index:        1,
+				Effect:       "Deny",
+				NotPrincipal: Principals{"AWS": {"*"}},
+				NotAction:    Values{"s3:List*"},
+				NotResource:  Values{"arn:aws:s3:::bucket"},
+			},
+		},
+	}
+
+	for name, doc := range map[string]string{
+		"plain":       document,
+		"url encoded": url.QueryEscape(document),
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse(doc)
+			require.NoError(t, err)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("document mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseSingleStatement(t *testing.T) {
+	got, err := Parse(`{"Statement": {"Effect": "Allow", "Action": "*", "Resource": "*"}}`)
+	require.NoError(t, err)
+	want := &Document{Statement: Statements{{Effect: "Allow", Action: Values{"*"}, Resource: Values{"*"}}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("document mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -59,7 +59,7 @@ func ServiceAccountRegionMultiplexer(table, service string) func(meta schema.Cli
 		for partition := range client.ServicesManager.services {
 			for accountID := range client.ServicesManager.services[partition] {
 				for _, region := range client.ServicesManager.services[partition][accountID].Regions {
-					if !isSupportedServiceForRegion(service, region) {
+					if !client.isSupportedServiceForRegion(service, region) {
 						if client.specificRegions {
 							notSupportedRegions = append(notSupportedRegions, region)
 						}
@@ -97,7 +97,7 @@ func ServiceAccountRegionNamespaceMultiplexer(table, service string) func(meta s
 		for partition := range client.ServicesManager.services {
 			for accountID := range client.ServicesManager.services[partition] {
 				for _, region := range client.ServicesManager.services[partition][accountID].Regions {
-					if !isSupportedServiceForRegion(service, region) {
+					if !client.isSupportedServiceForRegion(service, region) {
 						if client.specificRegions {
 							notSupportedRegions = append(notSupportedRegions, region)
 						}
@@ -131,7 +131,7 @@ func ServiceAccountRegionScopeMultiplexer(table, service string) func(meta schem
 				}
 
 				for _, region := range client.ServicesManager.services[partition][accountID].Regions {
-					if !isSupportedServiceForRegion(service, region) {
+					if !client.isSupportedServiceForRegion(service, region) {
 						if client.specificRegions {
 							notSupportedRegions = append(notSupportedRegions, region)
 						}
@@ -147,6 +147,25 @@ func ServiceAccountRegionScopeMultiplexer(table, service string) func(meta schem
 	}
 }
 
+// isSupportedServiceForRegion returns whether the service has an endpoint in the region
+// matching the endpoint variants of the spec.
+func (c *Client) isSupportedServiceForRegion(service string, region string) bool {
+	if c.Spec == nil {
+		return isSupportedServiceForRegion(service, region)
+	}
+	if !isSupportedServiceForRegion(service, region) {
+		// The regions discovered through EC2 which are missing from the bundled data are newer than it,
+		// so the services are assumed to be available in them
+		if c.Spec.RegionDiscovery != RegionDiscoveryEC2 {
+			return false
+		}
+		if _, known := RegionsPartition(region); known {
+			return false
+		}
+	}
+	return supportsEndpointVariant(service, region, c.Spec.UseFIPSEndpoints, c.Spec.UseDualStackEndpoints)
+}
+
 func generateLogMessages(client *Client, table, service string, skippedRegions []string, emptyMultiplexer bool) {
 	if len(skippedRegions) == 0 {
 		return
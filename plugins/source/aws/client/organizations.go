@@ -15,7 +15,7 @@ import (
 )
 
 // Parses org configuration and grabs the appropriate accounts
-func loadOrgAccounts(ctx context.Context, logger zerolog.Logger, awsPluginSpec *Spec) ([]Account, AssumeRoleAPIClient, error) {
+func loadOrgAccounts(ctx context.Context, logger zerolog.Logger, awsPluginSpec *Spec, rateLimiter *serviceRateLimiter) ([]Account, AssumeRoleAPIClient, error) {
 	// If user doesn't specify any configs for admin account instantiate default values
 	if awsPluginSpec.Organization.AdminAccount == nil {
 		awsPluginSpec.Organization.AdminAccount = &Account{
@@ -23,7 +23,7 @@ func loadOrgAccounts(ctx context.Context, logger zerolog.Logger, awsPluginSpec *
 			LocalProfile: "",
 		}
 	}
-	awsCfg, err := configureAwsSDK(ctx, logger, awsPluginSpec, *awsPluginSpec.Organization.AdminAccount, nil)
+	awsCfg, err := configureAwsSDK(ctx, logger, awsPluginSpec, *awsPluginSpec.Organization.AdminAccount, nil, rateLimiter)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -37,7 +37,7 @@ func loadOrgAccounts(ctx context.Context, logger zerolog.Logger, awsPluginSpec *
 		return nil, nil, err
 	}
 	if awsPluginSpec.Organization.MemberCredentials != nil {
-		awsCfg, err = configureAwsSDK(ctx, logger, awsPluginSpec, *awsPluginSpec.Organization.MemberCredentials, nil)
+		awsCfg, err = configureAwsSDK(ctx, logger, awsPluginSpec, *awsPluginSpec.Organization.MemberCredentials, nil, rateLimiter)
 		if err != nil {
 			return nil, nil, err
 		}
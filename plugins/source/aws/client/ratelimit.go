@@ -1,6 +1,19 @@
 package client
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+)
 
 type NoRateLimiter struct {
 }
@@ -14,3 +27,193 @@ func (*NoRateLimiter) GetToken(ctx context.Context, cost uint) (func() error, er
 func (*NoRateLimiter) AddTokens(uint) error {
 	return nil
 }
+
+// RateLimit limits the requests made to a service, or to a single API of a service, in each account and region.
+type RateLimit struct {
+	// Service is the service ID of the SDK in lowercase without spaces, such as "route53" or "cloudwatch"
+	Service string `json:"service"`
+	// API is the name of the operation, such as "GetMetricData". If empty the limit applies to all the APIs of the service.
+	API string `json:"api,omitempty"`
+	// RequestsPerSecond is the rate at which the tokens of the bucket are refilled. 0 disables the limit.
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	// Burst is the size of the bucket. It defaults to the requests per second, rounded up.
+	Burst int `json:"burst,omitempty"`
+}
+
+func (r RateLimit) Validate() error {
+	if r.Service == "" {
+		return fmt.Errorf("service is required")
+	}
+	if r.RequestsPerSecond < 0 {
+		return fmt.Errorf("requests_per_second must be greater than or equal to 0")
+	}
+	if r.Burst < 0 {
+		return fmt.Errorf("burst must be greater than or equal to 0")
+	}
+	return nil
+}
+
+// defaultRateLimits are the limits of the APIs with quotas low enough to be reached when syncing large accounts.
+// They can be overridden by the limits of the spec with the same service and API.
+var defaultRateLimits = []RateLimit{
+	// https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/DNSLimitations.html#limits-api-requests
+	{Service: "route53", RequestsPerSecond: 5},
+	// https://docs.aws.amazon.com/awscloudtrail/latest/userguide/WhatIsCloudTrail-Limits.html
+	{Service: "cloudtrail", API: "LookupEvents", RequestsPerSecond: 2},
+	// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/cloudwatch_limits.html
+	{Service: "cloudwatch", API: "DescribeAlarms", RequestsPerSecond: 9},
+	{Service: "cloudwatch", API: "GetMetricData", RequestsPerSecond: 50},
+	{Service: "cloudwatch", API: "ListMetrics", RequestsPerSecond: 25},
+	// https://docs.aws.amazon.com/organizations/latest/userguide/orgs_reference_limits.html
+	{Service: "organizations", RequestsPerSecond: 10},
+}
+
+// serviceKey normalizes the service ID of the SDK ("Route 53") or of a rate limit ("route53").
+func serviceKey(serviceID string) string {
+	return strings.ToLower(strings.ReplaceAll(serviceID, " ", ""))
+}
+
+// mergeRateLimits returns the default limits overridden by the limits of the spec.
+func mergeRateLimits(limits []RateLimit) []RateLimit {
+	merged := make([]RateLimit, 0, len(defaultRateLimits)+len(limits))
+	for _, d := range defaultRateLimits {
+		overridden := false
+		for _, l := range limits {
+			if serviceKey(l.Service) == d.Service && strings.EqualFold(l.API, d.API) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, d)
+		}
+	}
+	return append(merged, limits...)
+}
+
+// serviceRateLimiter enforces the rate limits in each account and region.
+type serviceRateLimiter struct {
+	limits []RateLimit
+
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+}
+
+func newServiceRateLimiter(limits []RateLimit) *serviceRateLimiter {
+	return &serviceRateLimiter{
+		limits:  mergeRateLimits(limits),
+		buckets: make(map[string]*rate.Limiter),
+	}
+}
+
+// matchingLimits returns the limits applying to the API of the service.
+func (r *serviceRateLimiter) matchingLimits(service, api string) []RateLimit {
+	var limits []RateLimit
+	for _, l := range r.limits {
+		if l.RequestsPerSecond == 0 || serviceKey(l.Service) != service {
+			continue
+		}
+		if l.API == "" || strings.EqualFold(l.API, api) {
+			limits = append(limits, l)
+		}
+	}
+	return limits
+}
+
+func (r *serviceRateLimiter) bucket(account, region string, limit RateLimit) *rate.Limiter {
+	key := strings.Join([]string{account, region, serviceKey(limit.Service), strings.ToLower(limit.API)}, "/")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.buckets[key]; ok {
+		return b
+	}
+	burst := limit.Burst
+	if burst == 0 {
+		burst = int(math.Ceil(limit.RequestsPerSecond))
+	}
+	b := rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst)
+	r.buckets[key] = b
+	return b
+}
+
+// throttleCounter counts the requests throttled by AWS per service.
+type throttleCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func newThrottleCounter() *throttleCounter {
+	return &throttleCounter{counts: make(map[string]int64)}
+}
+
+func (t *throttleCounter) add(service string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.counts[service]++
+}
+
+// Counts returns the number of throttled requests per service.
+func (t *throttleCounter) Counts() map[string]int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	counts := make(map[string]int64, len(t.counts))
+	for service, count := range t.counts {
+		counts[service] = count
+	}
+	return counts
+}
+
+type throttleCounterKey struct{}
+
+// WithThrottleCounter returns the context counting the requests throttled with it in the counter of the client.
+// The service clients are shared by all the syncs, so the counter is passed with the context of each sync.
+func (c *Client) WithThrottleCounter(ctx context.Context) context.Context {
+	if c.throttles == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, throttleCounterKey{}, c.throttles)
+}
+
+// countThrottle counts the throttled request in the counter of the context, if any.
+func countThrottle(ctx context.Context, service string) {
+	if t, ok := ctx.Value(throttleCounterKey{}).(*throttleCounter); ok {
+		t.add(service)
+	}
+}
+
+// middleware returns the API option adding the rate limiting of the account to the service clients.
+// It runs after the retry middleware, so that every attempt waits for a token and the throttled attempts are counted
+// in the counter of the request context.
+func (r *serviceRateLimiter) middleware(account string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("ServiceRateLimiter", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			service := serviceKey(awsmiddleware.GetServiceID(ctx))
+			for _, limit := range r.matchingLimits(service, awsmiddleware.GetOperationName(ctx)) {
+				if err := r.bucket(account, awsmiddleware.GetRegion(ctx), limit).Wait(ctx); err != nil {
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+				}
+			}
+			out, metadata, err := next.HandleFinalize(ctx, in)
+			if err != nil && retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary {
+				countThrottle(ctx, service)
+			}
+			return out, metadata, err
+		}), middleware.After)
+	}
+}
+
+// LogThrottles logs the number of requests throttled by AWS per service during the sync of the client, if any.
+func (c *Client) LogThrottles() {
+	if c.throttles == nil {
+		return
+	}
+	throttles := c.throttles.Counts()
+	if len(throttles) == 0 {
+		return
+	}
+	dict := zerolog.Dict()
+	for service, count := range throttles {
+		dict = dict.Int64(service, count)
+	}
+	c.logger.Info().Dict("throttled_requests", dict).Msg("requests throttled by AWS per service. To reduce them, configure `rate_limits` for the services")
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceKey(t *testing.T) {
+	assert.Equal(t, "route53", serviceKey("Route 53"))
+	assert.Equal(t, "elasticloadbalancingv2", serviceKey("Elastic Load Balancing v2"))
+	assert.Equal(t, "cloudwatch", serviceKey("cloudwatch"))
+}
+
+func TestServiceRateLimiterMatchingLimits(t *testing.T) {
+	r := newServiceRateLimiter([]RateLimit{
+		// overrides the default limit
+		{Service: "Route53", RequestsPerSecond: 2},
+		// disables the default limit
+		{Service: "cloudtrail", API: "LookupEvents"},
+		{Service: "cloudwatch", RequestsPerSecond: 100},
+	})
+
+	assert.Equal(t, []RateLimit{{Service: "Route53", RequestsPerSecond: 2}}, r.matchingLimits("route53", "ListHostedZones"))
+	assert.Empty(t, r.matchingLimits("cloudtrail", "LookupEvents"))
+	assert.Equal(t, []RateLimit{
+		{Service: "cloudwatch", API: "GetMetricData", RequestsPerSecond: 50},
+		{Service: "cloudwatch", RequestsPerSecond: 100},
+	}, r.matchingLimits("cloudwatch", "getmetricdata"))
+	assert.Equal(t, []RateLimit{{Service: "organizations", RequestsPerSecond: 10}}, r.matchingLimits("organizations", "ListAccounts"))
+	assert.Empty(t, r.matchingLimits("ec2", "DescribeInstances"))
+}
+
+func TestServiceRateLimiterBucket(t *testing.T) {
+	r := newServiceRateLimiter(nil)
+	limit := RateLimit{Service: "cloudtrail", API: "LookupEvents", RequestsPerSecond: 2.5}
+
+	b := r.bucket("123456789012", "us-east-1", limit)
+	assert.Equal(t, 3, b.Burst())
+	assert.Same(t, b, r.bucket("123456789012", "us-east-1", limit))
+	assert.NotSame(t, b, r.bucket("123456789012", "us-west-2", limit))
+	assert.NotSame(t, b, r.bucket("210987654321", "us-east-1", limit))
+}
+
+func TestThrottleCounterPerSync(t *testing.T) {
+	c := &Client{}
+	first, second := c.Duplicate(), c.Duplicate()
+	firstCtx, secondCtx := first.WithThrottleCounter(context.Background()), second.WithThrottleCounter(context.Background())
+	countThrottle(firstCtx, "route53")
+	countThrottle(firstCtx, "route53")
+	countThrottle(firstCtx, "cloudwatch")
+	countThrottle(secondCtx, "route53")
+	// requests made outside of a sync aren't counted
+	countThrottle(context.Background(), "organizations")
+
+	assert.Equal(t, map[string]int64{"route53": 2, "cloudwatch": 1}, first.throttles.Counts())
+	assert.Equal(t, map[string]int64{"route53": 1}, second.throttles.Counts())
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/autoscalingplans"
 	"github.com/aws/aws-sdk-go-v2/service/backup"
 	"github.com/aws/aws-sdk-go-v2/service/batch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
 	"github.com/aws/aws-sdk-go-v2/service/cloudhsmv2"
@@ -143,6 +144,7 @@ func initServices(c aws.Config, regions []string) Services {
 		Batch:                     batch.NewFromConfig(awsCfg),
 		Backup:                    backup.NewFromConfig(awsCfg),
 		Cloudformation:            cloudformation.NewFromConfig(awsCfg),
+		Cloudcontrol:              cloudcontrol.NewFromConfig(awsCfg),
 		Cloudfront:                cloudfront.NewFromConfig(awsCfg),
 		Cloudhsmv2:                cloudhsmv2.NewFromConfig(awsCfg),
 		Cloudtrail:                cloudtrail.NewFromConfig(awsCfg),
@@ -263,6 +265,7 @@ type Services struct {
 	Backup                    services.BackupClient
 	Batch                     services.BatchClient
 	Cloudformation            services.CloudformationClient
+	Cloudcontrol              services.CloudcontrolClient
 	Cloudfront                services.CloudfrontClient
 	Cloudhsmv2                services.Cloudhsmv2Client
 	Cloudtrail                services.CloudtrailClient
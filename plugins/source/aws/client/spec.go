@@ -5,12 +5,17 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/client/tableoptions"
 	"github.com/cloudquery/plugin-sdk/v4/scheduler"
 )
 
 const (
 	defaultMaxConcurrency = 50000
+
+	// RegionDiscoveryEC2 makes the enabled regions reported by EC2 for each account take precedence
+	// over the bundled region data, so that the regions missing from it are synced too.
+	RegionDiscoveryEC2 = "ec2"
 )
 
 type Account struct {
@@ -44,10 +49,15 @@ type Spec struct {
 	AWSDebug                  bool                       `json:"aws_debug,omitempty"`
 	MaxRetries                *int                       `json:"max_retries,omitempty"`
 	MaxBackoff                *int                       `json:"max_backoff,omitempty"`
+	RetryMode                 string                     `json:"retry_mode,omitempty"`
+	RateLimits                []RateLimit                `json:"rate_limits,omitempty"`
 	EndpointURL               string                     `json:"custom_endpoint_url,omitempty"`
 	HostnameImmutable         *bool                      `json:"custom_endpoint_hostname_immutable,omitempty"`
 	PartitionID               string                     `json:"custom_endpoint_partition_id,omitempty"`
 	SigningRegion             string                     `json:"custom_endpoint_signing_region,omitempty"`
+	UseFIPSEndpoints          bool                       `json:"use_fips_endpoints,omitempty"`
+	UseDualStackEndpoints     bool                       `json:"use_dualstack_endpoints,omitempty"`
+	RegionDiscovery           string                     `json:"region_discovery,omitempty"`
 	InitializationConcurrency int                        `json:"initialization_concurrency"`
 	UsePaidAPIs               bool                       `json:"use_paid_apis"`
 	TableOptions              *tableoptions.TableOptions `json:"table_options,omitempty"`
@@ -68,6 +78,19 @@ func (s *Spec) Validate() error {
 		}
 	}
 
+	if s.RetryMode != "" && s.RetryMode != string(aws.RetryModeStandard) && s.RetryMode != string(aws.RetryModeAdaptive) {
+		return fmt.Errorf("invalid retry_mode: %q (should be %q or %q)", s.RetryMode, aws.RetryModeStandard, aws.RetryModeAdaptive)
+	}
+	for _, l := range s.RateLimits {
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("invalid rate_limits: %w", err)
+		}
+	}
+
+	if s.RegionDiscovery != "" && s.RegionDiscovery != RegionDiscoveryEC2 {
+		return fmt.Errorf("invalid region_discovery: %q (should be empty or %q)", s.RegionDiscovery, RegionDiscoveryEC2)
+	}
+
 	if s.Organization != nil && len(s.Accounts) > 0 {
 		return errors.New("specifying accounts via both the Accounts and Org properties is not supported. To achieve both, use multiple source configurations")
 	}
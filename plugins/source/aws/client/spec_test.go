@@ -79,6 +79,46 @@ func TestSpecValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ec2 region discovery",
+			spec: &Spec{
+				RegionDiscovery:  RegionDiscoveryEC2,
+				UseFIPSEndpoints: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "rate limits",
+			spec: &Spec{
+				RetryMode: "adaptive",
+				RateLimits: []RateLimit{
+					{Service: "route53", RequestsPerSecond: 2},
+					{Service: "cloudwatch", API: "GetMetricData", RequestsPerSecond: 10, Burst: 20},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid retry mode",
+			spec: &Spec{
+				RetryMode: "legacy",
+			},
+			wantErr: true,
+		},
+		{
+			name: "rate limit without service",
+			spec: &Spec{
+				RateLimits: []RateLimit{{API: "GetMetricData", RequestsPerSecond: 10}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid region discovery",
+			spec: &Spec{
+				RegionDiscovery: "static",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,48 @@
+package tableoptions
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	"github.com/cloudquery/plugin-sdk/v4/caser"
+)
+
+type CloudControlAPIs struct {
+	ListResourcesOpts []CustomCloudControlListResourcesInput `json:"list_resources,omitempty"`
+}
+
+type CustomCloudControlListResourcesInput struct {
+	cloudcontrol.ListResourcesInput
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for the CustomCloudControlListResourcesInput type.
+// It is the same as default, but allows the use of underscore in the JSON field names.
+func (c *CustomCloudControlListResourcesInput) UnmarshalJSON(data []byte) error {
+	m := map[string]any{}
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+	csr := caser.New()
+	changeCaseForObject(m, csr.ToPascal)
+	b, _ := json.Marshal(m)
+	return json.Unmarshal(b, &c.ListResourcesInput)
+}
+
+func (c *CloudControlAPIs) validateListResources() error {
+	for _, opt := range c.ListResourcesOpts {
+		if aws.ToString(opt.NextToken) != "" {
+			return errors.New("invalid input: cannot set NextToken in ListResources")
+		}
+		if aws.ToString(opt.TypeName) == "" {
+			return errors.New("invalid input: TypeName is required in ListResources")
+		}
+	}
+	return nil
+}
+
+func (c *CloudControlAPIs) Validate() error {
+	return c.validateListResources()
+}
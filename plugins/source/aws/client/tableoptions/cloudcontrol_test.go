@@ -0,0 +1,30 @@
+package tableoptions
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cloudquery/plugin-sdk/v4/faker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudControlListResources(t *testing.T) {
+	u := CustomCloudControlListResourcesInput{}
+	require.NoError(t, faker.FakeObject(&u))
+	api := CloudControlAPIs{
+		ListResourcesOpts: []CustomCloudControlListResourcesInput{u},
+	}
+	// Ensure that the validation works as expected
+	err := api.Validate()
+	assert.EqualError(t, err, "invalid input: cannot set NextToken in ListResources")
+
+	api.ListResourcesOpts[0].NextToken = nil
+	api.ListResourcesOpts[0].TypeName = nil
+	err = api.Validate()
+	assert.EqualError(t, err, "invalid input: TypeName is required in ListResources")
+
+	// Ensure that as soon as the validation passes that there are no unexpected empty or nil fields
+	api.ListResourcesOpts[0].TypeName = aws.String("AWS::Macie::Session")
+	assert.NoError(t, api.Validate())
+}
@@ -0,0 +1,36 @@
+package tableoptions
+
+import (
+	"errors"
+	"time"
+)
+
+type CloudtrailLogFiles struct {
+	Trails []CloudtrailTrailLogFiles `json:"trails,omitempty"`
+}
+
+// CloudtrailTrailLogFiles is the location of the log files a trail delivers to S3.
+type CloudtrailTrailLogFiles struct {
+	// Bucket is the name of the bucket the trail delivers the log files to.
+	Bucket string `json:"bucket"`
+	// Region is the region of the bucket.
+	Region string `json:"region"`
+	// Prefix is the S3 key prefix of the trail, if any.
+	Prefix string `json:"prefix,omitempty"`
+	// OrganizationID is set for organization trails, as their log files are stored under the organization ID.
+	OrganizationID string `json:"organization_id,omitempty"`
+	// StartTime skips the log files delivered before the day of the time on the first sync.
+	StartTime *time.Time `json:"start_time,omitempty"`
+}
+
+func (c *CloudtrailLogFiles) Validate() error {
+	for _, trail := range c.Trails {
+		if trail.Bucket == "" {
+			return errors.New("invalid input: bucket is required in trails")
+		}
+		if trail.Region == "" {
+			return errors.New("invalid input: region is required in trails")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package tableoptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudtrailLogFiles(t *testing.T) {
+	api := CloudtrailLogFiles{
+		Trails: []CloudtrailTrailLogFiles{{Region: "us-east-1"}},
+	}
+	assert.EqualError(t, api.Validate(), "invalid input: bucket is required in trails")
+
+	api.Trails[0] = CloudtrailTrailLogFiles{Bucket: "trail-bucket"}
+	assert.EqualError(t, api.Validate(), "invalid input: region is required in trails")
+
+	api.Trails[0].Region = "us-east-1"
+	assert.NoError(t, api.Validate())
+}
@@ -0,0 +1,42 @@
+package tableoptions
+
+import (
+	"errors"
+	"time"
+)
+
+type CurReports struct {
+	Reports []CurReport `json:"reports,omitempty"`
+}
+
+// CurReport is the location of the files of a Cost and Usage Report, or of a Data Exports export, in S3.
+type CurReport struct {
+	// Bucket is the name of the bucket the report is delivered to.
+	Bucket string `json:"bucket"`
+	// Region is the region of the bucket.
+	Region string `json:"region"`
+	// Prefix is the S3 path prefix of the report, if any.
+	Prefix string `json:"prefix,omitempty"`
+	// Name is the name of the report or export.
+	Name string `json:"name"`
+	// AccountID is the ID of the account whose credentials are used to read the report.
+	// When syncing several accounts it should be set, so that the report is read only once.
+	AccountID string `json:"account_id,omitempty"`
+	// StartTime skips the billing periods ending before the time.
+	StartTime *time.Time `json:"start_time,omitempty"`
+}
+
+func (c *CurReports) Validate() error {
+	for _, report := range c.Reports {
+		if report.Bucket == "" {
+			return errors.New("invalid input: bucket is required in reports")
+		}
+		if report.Region == "" {
+			return errors.New("invalid input: region is required in reports")
+		}
+		if report.Name == "" {
+			return errors.New("invalid input: name is required in reports")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package tableoptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurReports(t *testing.T) {
+	api := CurReports{
+		Reports: []CurReport{{Region: "us-east-1", Name: "cur"}},
+	}
+	assert.EqualError(t, api.Validate(), "invalid input: bucket is required in reports")
+
+	api.Reports[0] = CurReport{Bucket: "cur-bucket", Name: "cur"}
+	assert.EqualError(t, api.Validate(), "invalid input: region is required in reports")
+
+	api.Reports[0] = CurReport{Bucket: "cur-bucket", Region: "us-east-1"}
+	assert.EqualError(t, api.Validate(), "invalid input: name is required in reports")
+
+	api.Reports[0].Name = "cur"
+	assert.NoError(t, api.Validate())
+}
@@ -9,11 +9,14 @@ type customInputValidation interface {
 type TableOptions struct {
 	CloudwatchMetrics      CloudwatchMetrics       `json:"aws_alpha_cloudwatch_metrics,omitempty"`
 	CloudTrailEvents       *CloudtrailAPIs         `json:"aws_cloudtrail_events,omitempty"`
+	CloudTrailLogFiles     *CloudtrailLogFiles     `json:"aws_cloudtrail_log_file_events,omitempty"`
 	AccessAnalyzerFindings *AccessanalyzerFindings `json:"aws_accessanalyzer_analyzer_findings,omitempty"`
 	Inspector2Findings     *Inspector2APIs         `json:"aws_inspector2_findings,omitempty"`
 	CustomCostExplorer     *CostExplorerAPIs       `json:"aws_alpha_costexplorer_cost_custom,omitempty"`
 	SecurityHubFindings    *SecurityHubAPIs        `json:"aws_securityhub_findings,omitempty"`
 	ECSTasks               *ECSTaskAPIs            `json:"aws_ecs_cluster_tasks,omitempty"`
+	CloudControlResources  *CloudControlAPIs       `json:"aws_cloudcontrol_resources,omitempty"`
+	CurBillingPeriods      *CurReports             `json:"aws_cur_billing_periods,omitempty"`
 }
 
 func (t TableOptions) Validate() error {
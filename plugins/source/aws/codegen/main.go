@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/autoscalingplans"
 	"github.com/aws/aws-sdk-go-v2/service/backup"
 	"github.com/aws/aws-sdk-go-v2/service/batch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
 	"github.com/aws/aws-sdk-go-v2/service/cloudhsmv2"
@@ -144,6 +145,7 @@ var clients = []any{
 	&backup.Client{},
 	&batch.Client{},
 	&cloudformation.Client{},
+	&cloudcontrol.Client{},
 	&cloudfront.Client{},
 	&cloudhsmv2.Client{},
 	&cloudtrail.Client{},
@@ -99,5 +99,7 @@ func (c *Client) Sync(ctx context.Context, options plugin.SyncOptions, res chan<
 	// for each sync we want to create a copy of the client so they won't share state
 	awsClient = awsClient.Duplicate()
 	awsClient.Backend = stateClient
-	return c.scheduler.Sync(ctx, awsClient, tt, res, scheduler.WithSyncDeterministicCQID(options.DeterministicCQID))
+	err = c.scheduler.Sync(awsClient.WithThrottleCounter(ctx), awsClient, tt, res, scheduler.WithSyncDeterministicCQID(options.DeterministicCQID))
+	awsClient.LogThrottles()
+	return err
 }
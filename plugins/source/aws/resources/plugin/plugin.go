@@ -30,6 +30,7 @@ var awsExceptions = map[string]string{
 	"autoscalingplans":       "Auto Scaling Plans",
 	"aws":                    "", // remove "AWS" from names, because in most cases it will be replaced with either Amazon or AWS
 	"byoip":                  "Bring your own IP addresses (BYOIP)",
+	"cloudcontrol":           "AWS Cloud Control API",
 	"cloudformation":         "AWS CloudFormation",
 	"cloudhsm":               "AWS CloudHSM",
 	"cloudhsmv2":             "AWS CloudHSM v2",
@@ -39,6 +40,7 @@ var awsExceptions = map[string]string{
 	"codecommit":             "AWS CodeCommit",
 	"computeoptimizer":       "Compute Optimizer",
 	"costexplorer":           "AWS Cost Explorer",
+	"cur":                    "AWS Cost and Usage Report",
 	"detective":              "Amazon Detective",
 	"directconnect":          "AWS Direct Connect",
 	"docdb":                  "Amazon DocumentDB",
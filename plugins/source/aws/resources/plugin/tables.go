@@ -21,6 +21,7 @@ import (
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/autoscalingplans"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/backup"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/batch"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/cloudcontrol"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/cloudformation"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/cloudfront"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/cloudhsmv2"
@@ -35,6 +36,7 @@ import (
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/computeoptimizer"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/config"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/costexplorer"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/cur"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/dax"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/detective"
 	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/directconnect"
@@ -172,6 +174,7 @@ func getTables() schema.Tables {
 		backup.Vaults(),
 		batch.JobQueues(),
 		batch.JobDefinitions(),
+		cloudcontrol.Resources(),
 		cloudformation.Stacks(),
 		cloudformation.StackSets(),
 		cloudfront.CachePolicies(),
@@ -185,6 +188,7 @@ func getTables() schema.Tables {
 		cloudtrail.Channels(),
 		cloudtrail.Events(),
 		cloudtrail.Imports(),
+		cloudtrail.LogFileEvents(),
 		cloudtrail.Trails(),
 		cloudwatch.Alarms(),
 		cloudwatch.Metrics(),
@@ -215,6 +219,7 @@ func getTables() schema.Tables {
 		costexplorer.CustomCost(),
 		costexplorer.ThirtyDayCost(),
 		costexplorer.ThirtyDayCostForecast(),
+		cur.BillingPeriods(),
 		dax.Clusters(),
 		detective.Graphs(),
 		directconnect.Connections(),
@@ -367,6 +372,8 @@ func getTables() schema.Tables {
 		iam.OpenidConnectIdentityProviders(),
 		iam.PasswordPolicies(),
 		iam.Policies(),
+		iam.PolicyStatements(),
+		iam.PrincipalEffectiveActions(),
 		iam.Roles(),
 		iam.SamlIdentityProviders(),
 		iam.ServerCertificates(),
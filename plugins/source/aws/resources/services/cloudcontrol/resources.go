@@ -0,0 +1,131 @@
+package cloudcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/types"
+)
+
+// resource is a resource of any type supported by the Cloud Control API.
+type resource struct {
+	TypeName   string
+	Identifier string
+	// Properties is the JSON of the resource properties
+	Properties string
+}
+
+func Resources() *schema.Table {
+	tableName := "aws_cloudcontrol_resources"
+	return &schema.Table{
+		Name: tableName,
+		Description: `https://docs.aws.amazon.com/cloudcontrolapi/latest/APIReference/API_ResourceDescription.html
+The resource types to sync are set in the 'list_resources' table options, e.g. 'AWS::Macie::Session'.
+The 'arn' column is set when it can be derived from the properties or the identifier of the resource.`,
+		Resolver:            fetchCloudcontrolResources,
+		PreResourceResolver: getCloudcontrolResource,
+		Multiplex:           client.ServiceAccountRegionMultiplexer(tableName, "cloudcontrolapi"),
+		Columns: []schema.Column{
+			client.DefaultAccountIDColumn(true),
+			client.DefaultRegionColumn(true),
+			{
+				Name:       "type_name",
+				Type:       arrow.BinaryTypes.String,
+				Resolver:   schema.PathResolver("TypeName"),
+				PrimaryKey: true,
+			},
+			{
+				Name:       "identifier",
+				Type:       arrow.BinaryTypes.String,
+				Resolver:   schema.PathResolver("Identifier"),
+				PrimaryKey: true,
+			},
+			{
+				Name:     "arn",
+				Type:     arrow.BinaryTypes.String,
+				Resolver: resolveResourceArn,
+			},
+			{
+				Name:     "properties",
+				Type:     types.ExtensionTypes.JSON,
+				Resolver: schema.PathResolver("Properties"),
+			},
+		},
+	}
+}
+
+func fetchCloudcontrolResources(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- any) error {
+	cl := meta.(*client.Client)
+	svc := cl.Services().Cloudcontrol
+
+	if cl.Spec.TableOptions.CloudControlResources == nil {
+		return nil
+	}
+	for _, input := range cl.Spec.TableOptions.CloudControlResources.ListResourcesOpts {
+		typeName := aws.ToString(input.TypeName)
+		paginator := cloudcontrol.NewListResourcesPaginator(svc, &input.ListResourcesInput)
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx, func(options *cloudcontrol.Options) {
+				options.Region = cl.Region
+			})
+			if err != nil {
+				// a type not being available in the region doesn't stop the other types from being synced
+				if client.IsAWSError(err, "TypeNotFoundException", "UnsupportedActionException") {
+					cl.Logger().Warn().Err(err).Str("type_name", typeName).Msg("Cloud Control resource type is not supported, skipping")
+					break
+				}
+				return err
+			}
+			for _, d := range page.ResourceDescriptions {
+				res <- &resource{
+					TypeName:   typeName,
+					Identifier: aws.ToString(d.Identifier),
+					Properties: aws.ToString(d.Properties),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getCloudcontrolResource gets the full properties of the resource, as the listed ones may only contain its identifier.
+func getCloudcontrolResource(ctx context.Context, meta schema.ClientMeta, r *schema.Resource) error {
+	cl := meta.(*client.Client)
+	svc := cl.Services().Cloudcontrol
+	item := r.Item.(*resource)
+
+	output, err := svc.GetResource(ctx, &cloudcontrol.GetResourceInput{
+		TypeName:   aws.String(item.TypeName),
+		Identifier: aws.String(item.Identifier),
+	}, func(options *cloudcontrol.Options) {
+		options.Region = cl.Region
+	})
+	if err != nil {
+		return err
+	}
+	if output.ResourceDescription != nil && output.ResourceDescription.Properties != nil {
+		item.Properties = aws.ToString(output.ResourceDescription.Properties)
+	}
+	return nil
+}
+
+// resolveResourceArn sets the ARN of the resource from its "Arn" property, or its identifier if that is an ARN.
+func resolveResourceArn(_ context.Context, _ schema.ClientMeta, r *schema.Resource, c schema.Column) error {
+	item := r.Item.(*resource)
+	var properties map[string]any
+	if err := json.Unmarshal([]byte(item.Properties), &properties); err == nil {
+		if arn, ok := properties["Arn"].(string); ok && arn != "" {
+			return r.Set(c.Name, arn)
+		}
+	}
+	if strings.HasPrefix(item.Identifier, "arn:") {
+		return r.Set(c.Name, item.Identifier)
+	}
+	return nil
+}
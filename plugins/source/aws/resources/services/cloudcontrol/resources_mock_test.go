@@ -0,0 +1,53 @@
+package cloudcontrol
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/mocks"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/tableoptions"
+	"github.com/golang/mock/gomock"
+)
+
+func buildCloudcontrolResources(t *testing.T, ctrl *gomock.Controller) client.Services {
+	m := mocks.NewMockCloudcontrolClient(ctrl)
+
+	m.EXPECT().ListResources(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&cloudcontrol.ListResourcesOutput{
+			TypeName: aws.String("AWS::Macie::Session"),
+			ResourceDescriptions: []types.ResourceDescription{
+				{Identifier: aws.String("123456789012"), Properties: aws.String(`{"AwsAccountId":"123456789012"}`)},
+			},
+		},
+		nil,
+	)
+	m.EXPECT().GetResource(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&cloudcontrol.GetResourceOutput{
+			TypeName: aws.String("AWS::Macie::Session"),
+			ResourceDescription: &types.ResourceDescription{
+				Identifier: aws.String("123456789012"),
+				Properties: aws.String(`{"AwsAccountId":"123456789012","Status":"ENABLED","Arn":"arn:aws:macie2:us-east-1:123456789012:session"}`),
+			},
+		},
+		nil,
+	)
+
+	return client.Services{
+		Cloudcontrol: m,
+	}
+}
+
+func TestCloudcontrolResources(t *testing.T) {
+	client.AwsMockTestHelper(t, Resources(), buildCloudcontrolResources, client.TestOptions{
+		TableOptions: tableoptions.TableOptions{
+			CloudControlResources: &tableoptions.CloudControlAPIs{
+				ListResourcesOpts: []tableoptions.CustomCloudControlListResourcesInput{
+					{ListResourcesInput: cloudcontrol.ListResourcesInput{TypeName: aws.String("AWS::Macie::Session")}},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,157 @@
+package cloudtrail
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/tableoptions"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/cloudtrail/models"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/mitchellh/hashstructure/v2"
+)
+
+const logFileEventsTableName = "aws_cloudtrail_log_file_events"
+
+func LogFileEvents() *schema.Table {
+	return &schema.Table{
+		Name: logFileEventsTableName,
+		Description: `https://docs.aws.amazon.com/awscloudtrail/latest/userguide/cloudtrail-event-reference-record-contents.html
+
+The events are read from the log files that the trails configured in the table options deliver to S3, so unlike 'aws_cloudtrail_events' they are not limited to the management events of the last 90 days.`,
+		Resolver:      fetchCloudtrailLogFileEvents,
+		Multiplex:     client.ServiceAccountRegionMultiplexer(logFileEventsTableName, "cloudtrail"),
+		Transform:     transformers.TransformWithStruct(&models.LogFileEvent{}, transformers.WithPrimaryKeys("EventID")),
+		IsIncremental: true,
+		Columns: []schema.Column{
+			client.DefaultAccountIDColumn(false),
+			client.DefaultRegionColumn(false),
+			{
+				Name:           "event_time",
+				Type:           arrow.FixedWidthTypes.Timestamp_us,
+				Resolver:       schema.PathResolver("EventTime"),
+				IncrementalKey: true,
+			},
+			{
+				Name:     "log_file_key",
+				Type:     arrow.BinaryTypes.String,
+				Resolver: schema.PathResolver("LogFileKey"),
+			},
+		},
+	}
+}
+
+func fetchCloudtrailLogFileEvents(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- any) error {
+	cl := meta.(*client.Client)
+	if cl.Spec.TableOptions.CloudTrailLogFiles == nil {
+		cl.Logger().Debug().Msg("no trails configured in the table options, skipping")
+		return nil
+	}
+	for _, trail := range cl.Spec.TableOptions.CloudTrailLogFiles.Trails {
+		if err := fetchTrailLogFileEvents(ctx, cl, trail, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fetchTrailLogFileEvents(ctx context.Context, cl *client.Client, trail tableoptions.CloudtrailTrailLogFiles, res chan<- any) error {
+	svc := cl.Services().S3
+	// The log files of the account and region are delivered under
+	// <prefix>/AWSLogs/[<organization id>/]<account id>/CloudTrail/<region>/<yyyy>/<mm>/<dd>/
+	prefix := path.Join(trail.Prefix, "AWSLogs", trail.OrganizationID, cl.AccountID, "CloudTrail", cl.Region) + "/"
+
+	input := s3.ListObjectsV2Input{
+		Bucket: aws.String(trail.Bucket),
+		Prefix: aws.String(prefix),
+	}
+	if trail.StartTime != nil {
+		input.StartAfter = aws.String(prefix + trail.StartTime.UTC().Format("2006/01/02/"))
+	}
+
+	var backendKey string
+	if cl.Backend != nil {
+		// Retrieve the key of the last log file read from the backend for this trail.
+		// We use a hash of the trail as the key, so changing it will cause a full refresh.
+		hash, err := hashstructure.Hash(trail, hashstructure.FormatV2, nil)
+		if err != nil {
+			return err
+		}
+		backendKey = fmt.Sprintf("%s-%d", cl.ID(), hash)
+		value, err := cl.Backend.GetKey(ctx, logFileEventsTableName+backendKey)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve state from backend: %w", err)
+		}
+		if value != "" {
+			input.StartAfter = aws.String(value)
+		}
+	}
+
+	// The log files are listed in the order of their keys, which start with the delivery date.
+	var lastKey string
+	paginator := s3.NewListObjectsV2Paginator(svc, &input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, func(options *s3.Options) {
+			options.Region = trail.Region
+		})
+		if err != nil {
+			return err
+		}
+		for _, object := range page.Contents {
+			key := aws.ToString(object.Key)
+			if !strings.HasSuffix(key, ".json.gz") {
+				continue
+			}
+			events, err := readLogFile(ctx, cl, trail, key)
+			if err != nil {
+				return fmt.Errorf("failed to read log file %s: %w", key, err)
+			}
+			res <- events
+			lastKey = key
+		}
+	}
+
+	if cl.Backend != nil && lastKey != "" {
+		err := cl.Backend.SetKey(ctx, logFileEventsTableName+backendKey, lastKey)
+		if err != nil {
+			return fmt.Errorf("failed to save state to backend: %w", err)
+		}
+	}
+	return nil
+}
+
+func readLogFile(ctx context.Context, cl *client.Client, trail tableoptions.CloudtrailTrailLogFiles, key string) ([]models.LogFileEvent, error) {
+	output, err := cl.Services().S3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(trail.Bucket),
+		Key:    aws.String(key),
+	}, func(options *s3.Options) {
+		options.Region = trail.Region
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+
+	r, err := gzip.NewReader(output.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var logFile models.LogFile
+	if err := json.NewDecoder(r).Decode(&logFile); err != nil {
+		return nil, err
+	}
+	for i := range logFile.Records {
+		logFile.Records[i].LogFileKey = key
+	}
+	return logFile.Records, nil
+}
@@ -0,0 +1,79 @@
+package cloudtrail
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/mocks"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/tableoptions"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func buildCloudtrailLogFileEventsMock(t *testing.T, ctrl *gomock.Controller) client.Services {
+	m := mocks.NewMockS3Client(ctrl)
+	services := client.Services{
+		S3: m,
+	}
+
+	data, err := os.ReadFile("testdata/log_file.json")
+	require.NoError(t, err)
+	var logFile bytes.Buffer
+	w := gzip.NewWriter(&logFile)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	const prefix = "trail/AWSLogs/o-example/testAccount/CloudTrail/us-east-1/"
+	const key = prefix + "2023/07/01/testAccount_CloudTrail_us-east-1_20230701T1005Z_EXAMPLE.json.gz"
+	m.EXPECT().ListObjectsV2(gomock.Any(), &s3.ListObjectsV2Input{
+		Bucket:     aws.String("trail-bucket"),
+		Prefix:     aws.String(prefix),
+		StartAfter: aws.String(prefix + "2023/07/01/"),
+	}, gomock.Any()).Return(
+		&s3.ListObjectsV2Output{
+			Contents: []types.Object{
+				{Key: aws.String(prefix + "2023/07/01/")},
+				{Key: aws.String(key)},
+			},
+		},
+		nil,
+	)
+	m.EXPECT().GetObject(gomock.Any(), &s3.GetObjectInput{
+		Bucket: aws.String("trail-bucket"),
+		Key:    aws.String(key),
+	}, gomock.Any()).Return(
+		&s3.GetObjectOutput{
+			Body: io.NopCloser(&logFile),
+		},
+		nil,
+	)
+
+	return services
+}
+
+func TestCloudtrailLogFileEvents(t *testing.T) {
+	startTime, err := time.Parse(time.RFC3339, "2023-07-01T10:00:00Z")
+	require.NoError(t, err)
+	client.AwsMockTestHelper(t, LogFileEvents(), buildCloudtrailLogFileEventsMock, client.TestOptions{
+		TableOptions: tableoptions.TableOptions{
+			CloudTrailLogFiles: &tableoptions.CloudtrailLogFiles{
+				Trails: []tableoptions.CloudtrailTrailLogFiles{{
+					Bucket:         "trail-bucket",
+					Region:         "us-east-1",
+					Prefix:         "trail",
+					OrganizationID: "o-example",
+					StartTime:      &startTime,
+				}},
+			},
+		},
+	})
+}
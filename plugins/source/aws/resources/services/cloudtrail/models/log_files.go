@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// LogFile is the content of a CloudTrail log file.
+type LogFile struct {
+	Records []LogFileEvent `json:"Records"`
+}
+
+// LogFileEvent is an event record of a CloudTrail log file.
+// https://docs.aws.amazon.com/awscloudtrail/latest/userguide/cloudtrail-event-reference-record-contents.html
+type LogFileEvent struct {
+	EventVersion        string           `json:"eventVersion"`
+	EventID             string           `json:"eventID"`
+	EventTime           time.Time        `json:"eventTime"`
+	EventSource         string           `json:"eventSource"`
+	EventName           string           `json:"eventName"`
+	EventType           string           `json:"eventType"`
+	EventCategory       string           `json:"eventCategory"`
+	AwsRegion           string           `json:"awsRegion"`
+	SourceIPAddress     string           `json:"sourceIPAddress"`
+	UserAgent           string           `json:"userAgent"`
+	UserIdentity        map[string]any   `json:"userIdentity"`
+	RequestParameters   map[string]any   `json:"requestParameters"`
+	ResponseElements    map[string]any   `json:"responseElements"`
+	AdditionalEventData map[string]any   `json:"additionalEventData"`
+	ServiceEventDetails map[string]any   `json:"serviceEventDetails"`
+	ErrorCode           string           `json:"errorCode"`
+	ErrorMessage        string           `json:"errorMessage"`
+	RequestID           string           `json:"requestID"`
+	SharedEventID       string           `json:"sharedEventID"`
+	ReadOnly            *bool            `json:"readOnly"`
+	ManagementEvent     *bool            `json:"managementEvent"`
+	Resources           []map[string]any `json:"resources"`
+	RecipientAccountID  string           `json:"recipientAccountId"`
+	VpcEndpointID       string           `json:"vpcEndpointId"`
+	TLSDetails          map[string]any   `json:"tlsDetails"`
+
+	// LogFileKey is the S3 key of the log file the event was read from.
+	LogFileKey string `json:"-"`
+}
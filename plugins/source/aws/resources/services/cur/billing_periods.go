@@ -0,0 +1,176 @@
+package cur
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/tableoptions"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/cur/models"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/mitchellh/hashstructure/v2"
+)
+
+const billingPeriodsTableName = "aws_cur_billing_periods"
+
+func BillingPeriods() *schema.Table {
+	return &schema.Table{
+		Name: billingPeriodsTableName,
+		Description: `https://docs.aws.amazon.com/cur/latest/userguide/dataexports-export-delivery.html
+
+The billing periods of the Cost and Usage Reports and Data Exports configured in the table options, read from their manifest files.
+A billing period is synced again, along with its line items, only when its report files have been replaced by a new delivery (assembly) since the last sync.`,
+		Resolver:      fetchCurBillingPeriods,
+		Multiplex:     client.AccountMultiplex(billingPeriodsTableName),
+		Transform:     transformers.TransformWithStruct(&models.BillingPeriod{}, transformers.WithPrimaryKeys("ReportName", "BillingPeriodStart")),
+		IsIncremental: true,
+		Columns: []schema.Column{
+			client.DefaultAccountIDColumn(true),
+			{
+				Name:           "assembly_id",
+				Type:           arrow.BinaryTypes.String,
+				Resolver:       schema.PathResolver("AssemblyID"),
+				PrimaryKey:     true,
+				IncrementalKey: true,
+			},
+		},
+		Relations: []*schema.Table{
+			lineItems(),
+		},
+	}
+}
+
+func fetchCurBillingPeriods(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- any) error {
+	cl := meta.(*client.Client)
+	if cl.Spec.TableOptions.CurBillingPeriods == nil {
+		cl.Logger().Debug().Msg("no reports configured in the table options, skipping")
+		return nil
+	}
+	for _, report := range cl.Spec.TableOptions.CurBillingPeriods.Reports {
+		if report.AccountID != "" && report.AccountID != cl.AccountID {
+			continue
+		}
+		if err := fetchReportBillingPeriods(ctx, cl, report, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fetchReportBillingPeriods(ctx context.Context, cl *client.Client, report tableoptions.CurReport, res chan<- any) error {
+	svc := cl.Services().S3
+	prefix := path.Join(report.Prefix, report.Name) + "/"
+	// The manifests of the billing periods are delivered as
+	// <prefix>/<name>/<yyyymmdd>-<yyyymmdd>/<name>-Manifest.json for the Cost and Usage Reports, and as
+	// <prefix>/<name>/metadata/BILLING_PERIOD=<yyyy>-<mm>/<name>-Manifest.json for the Data Exports.
+	manifestKey := regexp.MustCompile(`^(\d{8}-\d{8}|metadata/BILLING_PERIOD=\d{4}-\d{2})/` + regexp.QuoteMeta(report.Name) + `-Manifest\.json$`)
+
+	// Use a hash of the report as part of the state keys, so changing it will cause a full refresh.
+	hash, err := hashstructure.Hash(report, hashstructure.FormatV2, nil)
+	if err != nil {
+		return err
+	}
+
+	paginator := s3.NewListObjectsV2Paginator(svc, &s3.ListObjectsV2Input{
+		Bucket: aws.String(report.Bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, func(options *s3.Options) {
+			options.Region = report.Region
+		})
+		if err != nil {
+			return err
+		}
+		for _, object := range page.Contents {
+			key := aws.ToString(object.Key)
+			if !manifestKey.MatchString(strings.TrimPrefix(key, prefix)) {
+				continue
+			}
+			period, err := readManifest(ctx, cl, report, key)
+			if err != nil {
+				return fmt.Errorf("failed to read manifest %s: %w", key, err)
+			}
+			if report.StartTime != nil && period.BillingPeriodEnd.Before(*report.StartTime) {
+				continue
+			}
+			period.StateKey = fmt.Sprintf("%s-%d-%s", cl.ID(), hash, period.BillingPeriodStart.Format("2006-01"))
+			if cl.Backend != nil {
+				// Skip the billing periods whose current assembly has already been loaded
+				value, err := cl.Backend.GetKey(ctx, billingPeriodsTableName+period.StateKey)
+				if err != nil {
+					return fmt.Errorf("failed to retrieve state from backend: %w", err)
+				}
+				if value == period.AssemblyID {
+					continue
+				}
+			}
+			res <- period
+		}
+	}
+	return nil
+}
+
+func readManifest(ctx context.Context, cl *client.Client, report tableoptions.CurReport, key string) (*models.BillingPeriod, error) {
+	output, err := cl.Services().S3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(report.Bucket),
+		Key:    aws.String(key),
+	}, func(options *s3.Options) {
+		options.Region = report.Region
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+
+	var manifest models.Manifest
+	if err := json.NewDecoder(output.Body).Decode(&manifest); err != nil {
+		return nil, err
+	}
+	start, err := parseBillingPeriodTime(manifest.BillingPeriod.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseBillingPeriodTime(manifest.BillingPeriod.End)
+	if err != nil {
+		return nil, err
+	}
+
+	period := &models.BillingPeriod{
+		ReportName:         report.Name,
+		ManifestKey:        key,
+		BillingPeriodStart: start,
+		BillingPeriodEnd:   end,
+		AssemblyID:         manifest.AssemblyID,
+		ReportKeys:         manifest.ReportKeys,
+		Columns:            manifest.Columns,
+		Bucket:             report.Bucket,
+		Region:             report.Region,
+	}
+	if period.AssemblyID == "" {
+		period.AssemblyID = manifest.ExecutionID
+	}
+	// The Data Exports list the data files as S3 URIs
+	for _, file := range manifest.DataFiles {
+		period.ReportKeys = append(period.ReportKeys, strings.TrimPrefix(file, "s3://"+report.Bucket+"/"))
+	}
+	return period, nil
+}
+
+// parseBillingPeriodTime parses the billing period times, which are formatted as "20230701T000000.000Z"
+// in the manifests of the Cost and Usage Reports, and as RFC 3339 times in those of the Data Exports.
+func parseBillingPeriodTime(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405.000Z", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
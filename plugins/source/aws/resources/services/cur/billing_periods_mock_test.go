@@ -0,0 +1,90 @@
+package cur
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/mocks"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/tableoptions"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func buildCurBillingPeriodsMock(t *testing.T, ctrl *gomock.Controller) client.Services {
+	m := mocks.NewMockS3Client(ctrl)
+	services := client.Services{
+		S3: m,
+	}
+
+	manifest, err := os.ReadFile("testdata/manifest.json")
+	require.NoError(t, err)
+	data, err := os.ReadFile("testdata/report.csv")
+	require.NoError(t, err)
+	var report bytes.Buffer
+	w := gzip.NewWriter(&report)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	const prefix = "reports/cur/20230701-20230801/"
+	const assemblyPrefix = prefix + "d6d8ed6f-0a77-4e1b-9c2c-7bd14ab4d1b4/"
+	m.EXPECT().ListObjectsV2(gomock.Any(), &s3.ListObjectsV2Input{
+		Bucket: aws.String("cur-bucket"),
+		Prefix: aws.String("reports/cur/"),
+	}, gomock.Any()).Return(
+		&s3.ListObjectsV2Output{
+			Contents: []types.Object{
+				{Key: aws.String(prefix + "cur-Manifest.json")},
+				{Key: aws.String(assemblyPrefix + "cur-Manifest.json")},
+				{Key: aws.String(assemblyPrefix + "cur-00001.csv.gz")},
+			},
+		},
+		nil,
+	)
+	m.EXPECT().GetObject(gomock.Any(), &s3.GetObjectInput{
+		Bucket: aws.String("cur-bucket"),
+		Key:    aws.String(prefix + "cur-Manifest.json"),
+	}, gomock.Any()).Return(
+		&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader(manifest)),
+		},
+		nil,
+	)
+	m.EXPECT().GetObject(gomock.Any(), &s3.GetObjectInput{
+		Bucket: aws.String("cur-bucket"),
+		Key:    aws.String(assemblyPrefix + "cur-00001.csv.gz"),
+	}, gomock.Any()).Return(
+		&s3.GetObjectOutput{
+			Body: io.NopCloser(&report),
+		},
+		nil,
+	)
+
+	return services
+}
+
+func TestCurBillingPeriods(t *testing.T) {
+	startTime, err := time.Parse(time.RFC3339, "2023-07-15T00:00:00Z")
+	require.NoError(t, err)
+	client.AwsMockTestHelper(t, BillingPeriods(), buildCurBillingPeriodsMock, client.TestOptions{
+		TableOptions: tableoptions.TableOptions{
+			CurBillingPeriods: &tableoptions.CurReports{
+				Reports: []tableoptions.CurReport{{
+					Bucket:    "cur-bucket",
+					Region:    "us-east-1",
+					Prefix:    "reports",
+					Name:      "cur",
+					StartTime: &startTime,
+				}},
+			},
+		},
+	})
+}
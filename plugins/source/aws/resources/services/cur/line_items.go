@@ -0,0 +1,352 @@
+package cur
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet"
+	"github.com/apache/arrow/go/v13/parquet/file"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/cur/models"
+	"github.com/cloudquery/plugin-sdk/v4/caser"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+)
+
+// lineItemsBatchSize is the number of line items sent at once to the resources channel.
+const lineItemsBatchSize = 1000
+
+func lineItems() *schema.Table {
+	tableName := "aws_cur_line_items"
+	return &schema.Table{
+		Name: tableName,
+		Description: `https://docs.aws.amazon.com/cur/latest/userguide/table-dictionary-cur2.html
+
+The line items of the report files of the billing periods, from both the CSV and Parquet reports.
+The most used columns are typed, the other columns are kept in 'columns' and the resource tags in 'resource_tags'.
+The line items of a billing period are loaded again each time its report files are replaced, so only the line items of the latest 'assembly_id' of a billing period should be used.`,
+		Resolver: fetchCurLineItems,
+		Transform: transformers.TransformWithStruct(&models.LineItem{},
+			transformers.WithPrimaryKeys("AssemblyID", "ReportKey", "IdentityLineItemID", "IdentityTimeInterval"),
+		),
+		IsIncremental: true,
+	}
+}
+
+func fetchCurLineItems(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- any) error {
+	cl := meta.(*client.Client)
+	period := parent.Item.(*models.BillingPeriod)
+	for _, key := range period.ReportKeys {
+		var err error
+		switch {
+		case strings.HasSuffix(key, ".csv.gz"), strings.HasSuffix(key, ".csv"):
+			err = readCSVReport(ctx, cl, period, key, res)
+		case strings.HasSuffix(key, ".parquet"):
+			err = readParquetReport(ctx, cl, period, key, res)
+		default:
+			cl.Logger().Debug().Str("key", key).Msg("skipping report file of unknown format")
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read report file %s: %w", key, err)
+		}
+	}
+
+	if cl.Backend != nil {
+		err := cl.Backend.SetKey(ctx, billingPeriodsTableName+period.StateKey, period.AssemblyID)
+		if err != nil {
+			return fmt.Errorf("failed to save state to backend: %w", err)
+		}
+	}
+	return nil
+}
+
+func getReportFile(ctx context.Context, cl *client.Client, period *models.BillingPeriod, key string) (io.ReadCloser, error) {
+	output, err := cl.Services().S3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(period.Bucket),
+		Key:    aws.String(key),
+	}, func(options *s3.Options) {
+		options.Region = period.Region
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+func readCSVReport(ctx context.Context, cl *client.Client, period *models.BillingPeriod, key string, res chan<- any) error {
+	body, err := getReportFile(ctx, cl, period, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	var r io.Reader = body
+	if strings.HasSuffix(key, ".gz") {
+		gr, err := gzip.NewReader(body)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return err
+	}
+	items := make([]*models.LineItem, 0, lineItemsBatchSize)
+	values := make([]any, len(header))
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for i := range values {
+			values[i] = record[i]
+		}
+		item, err := newLineItem(header, values)
+		if err != nil {
+			return err
+		}
+		items = append(items, withReportFile(item, period, key))
+		if len(items) == lineItemsBatchSize {
+			res <- items
+			items = make([]*models.LineItem, 0, lineItemsBatchSize)
+		}
+	}
+	if len(items) > 0 {
+		res <- items
+	}
+	return nil
+}
+
+func readParquetReport(ctx context.Context, cl *client.Client, period *models.BillingPeriod, key string, res chan<- any) error {
+	body, err := getReportFile(ctx, cl, period, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// The Parquet reader needs to seek, so the file is downloaded first
+	f, err := os.CreateTemp("", "cq-cur-*.parquet")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if _, err := io.Copy(f, body); err != nil {
+		return err
+	}
+
+	return readParquetLineItems(ctx, f, period, key, res)
+}
+
+func readParquetLineItems(ctx context.Context, r parquet.ReaderAtSeeker, period *models.BillingPeriod, key string, res chan<- any) error {
+	pf, err := file.NewParquetReader(r)
+	if err != nil {
+		return err
+	}
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: lineItemsBatchSize}, memory.DefaultAllocator)
+	if err != nil {
+		return err
+	}
+	rr, err := fr.GetRecordReader(ctx, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer rr.Release()
+
+	columns := make([]string, len(rr.Schema().Fields()))
+	for i, field := range rr.Schema().Fields() {
+		columns[i] = field.Name
+	}
+	values := make([]any, len(columns))
+	for rr.Next() {
+		record := rr.Record()
+		items := make([]*models.LineItem, 0, record.NumRows())
+		for row := 0; row < int(record.NumRows()); row++ {
+			for i, column := range record.Columns() {
+				values[i] = parquetValue(column, row)
+			}
+			item, err := newLineItem(columns, values)
+			if err != nil {
+				return err
+			}
+			items = append(items, withReportFile(item, period, key))
+		}
+		res <- items
+	}
+	if err := rr.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// parquetValue returns the value of the row as a string, or as a map of strings for the map columns of the Data Exports.
+func parquetValue(column arrow.Array, row int) any {
+	if column.IsNull(row) {
+		return nil
+	}
+	m, ok := column.(*array.Map)
+	if !ok {
+		return column.ValueStr(row)
+	}
+	start, end := m.ValueOffsets(row)
+	values := make(map[string]string, end-start)
+	for i := int(start); i < int(end); i++ {
+		if !m.Items().IsNull(i) {
+			values[m.Keys().ValueStr(i)] = m.Items().ValueStr(i)
+		}
+	}
+	return values
+}
+
+func withReportFile(item *models.LineItem, period *models.BillingPeriod, key string) *models.LineItem {
+	item.ReportName = period.ReportName
+	item.AssemblyID = period.AssemblyID
+	item.ReportKey = key
+	return item
+}
+
+var csr = caser.New()
+
+// lineItemFields maps the column names to the indexes of the fields of the line items.
+var lineItemFields = func() map[string]int {
+	fields := make(map[string]int)
+	t := reflect.TypeOf(models.LineItem{})
+	for i := 0; i < t.NumField(); i++ {
+		if name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]; name != "" {
+			fields[name] = i
+		}
+	}
+	return fields
+}()
+
+// normalizeColumnName returns the name of the column in the Parquet reports and in the Data Exports,
+// such as "line_item_unblended_cost" for the "lineItem/UnblendedCost" column of the CSV reports.
+func normalizeColumnName(name string) string {
+	category, column, found := strings.Cut(name, "/")
+	if !found {
+		return name
+	}
+	return csr.ToSnake(category) + "_" + csr.ToSnake(column)
+}
+
+// newLineItem returns the line item of the values of the columns of a report, which are either strings or maps of strings.
+func newLineItem(columns []string, values []any) (*models.LineItem, error) {
+	item := &models.LineItem{
+		ResourceTags: make(map[string]string),
+		Columns:      make(map[string]any),
+	}
+	v := reflect.ValueOf(item).Elem()
+	for i, column := range columns {
+		value := values[i]
+		if value == nil || value == "" {
+			continue
+		}
+		// The tags are "resourceTags/user:<key>" columns in the CSV reports, "resource_tags_user_<key>" columns
+		// in the Parquet reports, and a "resource_tags" map in the Data Exports
+		if key, ok := strings.CutPrefix(column, "resourceTags/"); ok {
+			item.ResourceTags[key] = value.(string)
+			continue
+		}
+		name := normalizeColumnName(column)
+		if key, ok := strings.CutPrefix(name, "resource_tags_"); ok {
+			item.ResourceTags[key] = value.(string)
+			continue
+		}
+		if name == "resource_tags" {
+			if err := mergeTags(item.ResourceTags, value); err != nil {
+				return nil, fmt.Errorf("invalid %s column: %w", column, err)
+			}
+			continue
+		}
+
+		s, ok := value.(string)
+		index, found := lineItemFields[name]
+		if !ok || !found {
+			item.Columns[name] = value
+			continue
+		}
+		if err := setField(v.Field(index), s); err != nil {
+			return nil, fmt.Errorf("invalid %s column: %w", column, err)
+		}
+	}
+	return item, nil
+}
+
+func mergeTags(tags map[string]string, value any) error {
+	switch value := value.(type) {
+	case map[string]string:
+		for k, v := range value {
+			tags[k] = v
+		}
+		return nil
+	case string:
+		// The maps of the CSV Data Exports are JSON objects
+		return json.Unmarshal([]byte(value), &tags)
+	default:
+		return fmt.Errorf("unexpected value of type %T", value)
+	}
+}
+
+func setField(field reflect.Value, value string) error {
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(value)
+	case *string:
+		field.Set(reflect.ValueOf(&value))
+	case *float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(&f))
+	case *time.Time:
+		t, err := parseLineItemTime(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(&t))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// lineItemTimeLayouts are the layouts of the times of the CSV reports, and of the times of the Parquet reports formatted by Arrow.
+var lineItemTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+}
+
+func parseLineItemTime(value string) (time.Time, error) {
+	for _, layout := range lineItemTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported time format %q", value)
+}
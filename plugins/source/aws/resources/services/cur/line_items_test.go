@@ -0,0 +1,92 @@
+package cur
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/cur/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeColumnName(t *testing.T) {
+	assert.Equal(t, "line_item_unblended_cost", normalizeColumnName("lineItem/UnblendedCost"))
+	assert.Equal(t, "identity_line_item_id", normalizeColumnName("identity/LineItemId"))
+	assert.Equal(t, "pricing_public_on_demand_cost", normalizeColumnName("pricing/publicOnDemandCost"))
+	assert.Equal(t, "product_servicecode", normalizeColumnName("product/servicecode"))
+	assert.Equal(t, "line_item_resource_id", normalizeColumnName("line_item_resource_id"))
+}
+
+func TestNewLineItem(t *testing.T) {
+	item, err := newLineItem(
+		[]string{"identity/LineItemId", "lineItem/UsageStartDate", "lineItem/UnblendedCost", "lineItem/ResourceId", "lineItem/AvailabilityZone", "resourceTags/user:team", "product/location"},
+		[]any{"abc", "2023-07-01T00:00:00Z", "0.0123", "i-1234567890abcdef0", "", "finops", "US East (N. Virginia)"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", item.IdentityLineItemID)
+	assert.Equal(t, time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), *item.LineItemUsageStartDate)
+	assert.Equal(t, 0.0123, *item.LineItemUnblendedCost)
+	assert.Equal(t, "i-1234567890abcdef0", *item.LineItemResourceID)
+	assert.Nil(t, item.LineItemAvailabilityZone)
+	assert.Equal(t, map[string]string{"user:team": "finops"}, item.ResourceTags)
+	assert.Equal(t, map[string]any{"product_location": "US East (N. Virginia)"}, item.Columns)
+
+	_, err = newLineItem([]string{"lineItem/UnblendedCost"}, []any{"not a number"})
+	assert.Error(t, err)
+}
+
+func TestReadParquetLineItems(t *testing.T) {
+	mem := memory.NewGoAllocator()
+	sc := arrow.NewSchema([]arrow.Field{
+		{Name: "identity_line_item_id", Type: arrow.BinaryTypes.String},
+		{Name: "line_item_usage_start_date", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}, Nullable: true},
+		{Name: "line_item_unblended_cost", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "resource_tags", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String), Nullable: true},
+	}, nil)
+	bldr := array.NewRecordBuilder(mem, sc)
+	defer bldr.Release()
+	bldr.Field(0).(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	start := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	bldr.Field(1).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{arrow.Timestamp(start.UnixMilli()), 0}, []bool{true, false})
+	bldr.Field(2).(*array.Float64Builder).AppendValues([]float64{1.5, 0}, []bool{true, false})
+	tags := bldr.Field(3).(*array.MapBuilder)
+	tags.Append(true)
+	tags.KeyBuilder().(*array.StringBuilder).Append("user_team")
+	tags.ItemBuilder().(*array.StringBuilder).Append("finops")
+	tags.AppendNull()
+	record := bldr.NewRecord()
+	defer record.Release()
+
+	var buf bytes.Buffer
+	table := array.NewTableFromRecords(sc, []arrow.Record{record})
+	defer table.Release()
+	require.NoError(t, pqarrow.WriteTable(table, &buf, 1024, nil, pqarrow.DefaultWriterProps()))
+
+	res := make(chan any, 10)
+	period := &models.BillingPeriod{ReportName: "cur", AssemblyID: "assembly"}
+	require.NoError(t, readParquetLineItems(context.Background(), bytes.NewReader(buf.Bytes()), period, "cur-00001.snappy.parquet", res))
+	close(res)
+
+	var items []*models.LineItem
+	for batch := range res {
+		items = append(items, batch.([]*models.LineItem)...)
+	}
+	require.Len(t, items, 2)
+	assert.Equal(t, "a", items[0].IdentityLineItemID)
+	assert.Equal(t, "cur", items[0].ReportName)
+	assert.Equal(t, "assembly", items[0].AssemblyID)
+	assert.Equal(t, "cur-00001.snappy.parquet", items[0].ReportKey)
+	assert.Equal(t, start, *items[0].LineItemUsageStartDate)
+	assert.Equal(t, 1.5, *items[0].LineItemUnblendedCost)
+	assert.Equal(t, map[string]string{"user_team": "finops"}, items[0].ResourceTags)
+	assert.Equal(t, "b", items[1].IdentityLineItemID)
+	assert.Nil(t, items[1].LineItemUsageStartDate)
+	assert.Nil(t, items[1].LineItemUnblendedCost)
+	assert.Empty(t, items[1].ResourceTags)
+}
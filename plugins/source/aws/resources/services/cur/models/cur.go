@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+// Manifest is the manifest of a billing period, delivered with the report files.
+// The Cost and Usage Reports list the report files in ReportKeys, and the Data Exports in DataFiles.
+type Manifest struct {
+	AssemblyID    string `json:"assemblyId"`
+	ExecutionID   string `json:"executionId"`
+	Account       string `json:"account"`
+	ReportName    string `json:"reportName"`
+	BillingPeriod struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	} `json:"billingPeriod"`
+	ReportKeys []string         `json:"reportKeys"`
+	DataFiles  []string         `json:"dataFiles"`
+	Columns    []ManifestColumn `json:"columns"`
+}
+
+type ManifestColumn struct {
+	Category string `json:"category,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+}
+
+// BillingPeriod is a billing period of a report, as described by its manifest.
+type BillingPeriod struct {
+	ReportName         string
+	ManifestKey        string
+	BillingPeriodStart time.Time
+	BillingPeriodEnd   time.Time
+	// AssemblyID identifies the delivery of the report files, which are replaced when the billing period is updated.
+	AssemblyID string
+	ReportKeys []string
+	Columns    []ManifestColumn
+	Bucket     string `json:"-"`
+	Region     string `json:"-"`
+	// StateKey is the key of the state backend storing the assembly whose line items have been loaded.
+	StateKey string `json:"-"`
+}
+
+// LineItem is a line item of a report. The columns are named after the columns of the Parquet reports
+// and of the Data Exports, which the columns of the CSV reports ("lineItem/UnblendedCost") are normalized to.
+// The columns missing from the struct are kept in Columns, and the resource tags in ResourceTags.
+type LineItem struct {
+	ReportName                          string     `json:"report_name"`
+	AssemblyID                          string     `json:"assembly_id"`
+	ReportKey                           string     `json:"report_key"`
+	IdentityLineItemID                  string     `json:"identity_line_item_id"`
+	IdentityTimeInterval                string     `json:"identity_time_interval"`
+	BillInvoiceID                       *string    `json:"bill_invoice_id"`
+	BillBillingEntity                   *string    `json:"bill_billing_entity"`
+	BillBillType                        *string    `json:"bill_bill_type"`
+	BillPayerAccountID                  *string    `json:"bill_payer_account_id"`
+	BillBillingPeriodStartDate          *time.Time `json:"bill_billing_period_start_date"`
+	BillBillingPeriodEndDate            *time.Time `json:"bill_billing_period_end_date"`
+	LineItemUsageAccountID              *string    `json:"line_item_usage_account_id"`
+	LineItemLineItemType                *string    `json:"line_item_line_item_type"`
+	LineItemUsageStartDate              *time.Time `json:"line_item_usage_start_date"`
+	LineItemUsageEndDate                *time.Time `json:"line_item_usage_end_date"`
+	LineItemProductCode                 *string    `json:"line_item_product_code"`
+	LineItemUsageType                   *string    `json:"line_item_usage_type"`
+	LineItemOperation                   *string    `json:"line_item_operation"`
+	LineItemAvailabilityZone            *string    `json:"line_item_availability_zone"`
+	LineItemResourceID                  *string    `json:"line_item_resource_id"`
+	LineItemUsageAmount                 *float64   `json:"line_item_usage_amount"`
+	LineItemNormalizationFactor         *float64   `json:"line_item_normalization_factor"`
+	LineItemNormalizedUsageAmount       *float64   `json:"line_item_normalized_usage_amount"`
+	LineItemCurrencyCode                *string    `json:"line_item_currency_code"`
+	LineItemUnblendedRate               *float64   `json:"line_item_unblended_rate"`
+	LineItemUnblendedCost               *float64   `json:"line_item_unblended_cost"`
+	LineItemBlendedRate                 *float64   `json:"line_item_blended_rate"`
+	LineItemBlendedCost                 *float64   `json:"line_item_blended_cost"`
+	LineItemNetUnblendedCost            *float64   `json:"line_item_net_unblended_cost"`
+	LineItemLineItemDescription         *string    `json:"line_item_line_item_description"`
+	LineItemTaxType                     *string    `json:"line_item_tax_type"`
+	LineItemLegalEntity                 *string    `json:"line_item_legal_entity"`
+	ProductProductFamily                *string    `json:"product_product_family"`
+	ProductServicecode                  *string    `json:"product_servicecode"`
+	ProductRegionCode                   *string    `json:"product_region_code"`
+	ProductInstanceType                 *string    `json:"product_instance_type"`
+	ProductSku                          *string    `json:"product_sku"`
+	PricingTerm                         *string    `json:"pricing_term"`
+	PricingUnit                         *string    `json:"pricing_unit"`
+	PricingPublicOnDemandRate           *float64   `json:"pricing_public_on_demand_rate"`
+	PricingPublicOnDemandCost           *float64   `json:"pricing_public_on_demand_cost"`
+	ReservationEffectiveCost            *float64   `json:"reservation_effective_cost"`
+	SavingsPlanSavingsPlanEffectiveCost *float64   `json:"savings_plan_savings_plan_effective_cost"`
+	ResourceTags                        map[string]string
+	Columns                             map[string]any
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/iampolicy"
 )
 
 type AccessKeyWrapper struct {
@@ -100,3 +101,33 @@ type PasswordPolicyWrapper struct {
 	types.PasswordPolicy
 	PolicyExists bool
 }
+
+type PolicyStatement struct {
+	iampolicy.Statement
+	// PolicyType is managed, inline or trust.
+	PolicyType string
+	// PolicyArn is the ARN of the managed policy, or of the user, group or role the inline or trust policy is embedded in.
+	PolicyArn  string
+	PolicyName string
+}
+
+type PrincipalEffectiveAction struct {
+	// PrincipalType is user or role.
+	PrincipalType string
+	PrincipalArn  string
+	Action        string
+	// NotAction is the NotAction element of the statement, whose actions are all the actions but those.
+	NotAction   []string
+	Effect      string
+	Resource    []string
+	NotResource []string
+	Condition   map[string]any
+	// PolicyType is managed or inline.
+	PolicyType string
+	// PolicyArn is the ARN of the managed policy, or of the user, group or role the inline policy is embedded in.
+	PolicyArn      string
+	PolicyName     string
+	StatementIndex int
+	// GroupName is the name of the group the user has the policy through, if any.
+	GroupName string
+}
@@ -0,0 +1,120 @@
+package iam
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/iampolicy"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/iam/models"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+)
+
+const (
+	policyTypeManaged = "managed"
+	policyTypeInline  = "inline"
+	policyTypeTrust   = "trust"
+)
+
+func PolicyStatements() *schema.Table {
+	tableName := "aws_iam_policy_statements"
+	return &schema.Table{
+		Name: tableName,
+		Description: `https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements.html
+The statements of the default versions of the managed policies, of the inline policies of the users, groups and roles, and of the trust policies of the roles.
+The elements of the statements are normalized, so that 'principal' maps the principal types to lists of principals, and 'action' and 'resource' are lists.`,
+		Resolver: fetchIamPolicyStatements,
+		Transform: transformers.TransformWithStruct(&models.PolicyStatement{},
+			transformers.WithUnwrapAllEmbeddedStructs(),
+			transformers.WithPrimaryKeys("PolicyType", "PolicyArn", "PolicyName"),
+		),
+		Multiplex: client.ServiceAccountRegionMultiplexer(tableName, "iam"),
+		Columns: []schema.Column{
+			client.DefaultAccountIDColumn(true),
+			{
+				Name:       "statement_index",
+				Type:       arrow.PrimitiveTypes.Int64,
+				Resolver:   schema.PathResolver("Index"),
+				PrimaryKey: true,
+			},
+		},
+	}
+}
+
+func fetchIamPolicyStatements(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- any) error {
+	cl := meta.(*client.Client)
+	svc := cl.Services().Iam
+	paginator := iam.NewGetAccountAuthorizationDetailsPaginator(svc, &iam.GetAccountAuthorizationDetailsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, func(options *iam.Options) {
+			options.Region = cl.Region
+		})
+		if err != nil {
+			return err
+		}
+
+		var statements []models.PolicyStatement
+		add := func(policyType string, arn, name, document *string) {
+			doc := parsePolicyDocument(cl, aws.ToString(arn), document)
+			if doc == nil {
+				return
+			}
+			for _, s := range doc.Statement {
+				statements = append(statements, models.PolicyStatement{
+					Statement:  s,
+					PolicyType: policyType,
+					PolicyArn:  aws.ToString(arn),
+					PolicyName: aws.ToString(name),
+				})
+			}
+		}
+		for _, p := range page.Policies {
+			add(policyTypeManaged, p.Arn, p.PolicyName, defaultPolicyDocument(p))
+		}
+		for _, u := range page.UserDetailList {
+			for _, p := range u.UserPolicyList {
+				add(policyTypeInline, u.Arn, p.PolicyName, p.PolicyDocument)
+			}
+		}
+		for _, g := range page.GroupDetailList {
+			for _, p := range g.GroupPolicyList {
+				add(policyTypeInline, g.Arn, p.PolicyName, p.PolicyDocument)
+			}
+		}
+		for _, r := range page.RoleDetailList {
+			for _, p := range r.RolePolicyList {
+				add(policyTypeInline, r.Arn, p.PolicyName, p.PolicyDocument)
+			}
+			add(policyTypeTrust, r.Arn, r.RoleName, r.AssumeRolePolicyDocument)
+		}
+		res <- statements
+	}
+	return nil
+}
+
+// defaultPolicyDocument returns the document of the default version of the managed policy.
+func defaultPolicyDocument(p types.ManagedPolicyDetail) *string {
+	for _, v := range p.PolicyVersionList {
+		if v.IsDefaultVersion {
+			return v.Document
+		}
+	}
+	return nil
+}
+
+// parsePolicyDocument returns the parsed policy document, or nil if it is missing or invalid.
+func parsePolicyDocument(cl *client.Client, arn string, document *string) *iampolicy.Document {
+	if document == nil {
+		return nil
+	}
+	doc, err := iampolicy.Parse(*document)
+	if err != nil {
+		cl.Logger().Warn().Err(err).Str("arn", arn).Msg("failed to parse policy document")
+		return nil
+	}
+	return doc
+}
@@ -0,0 +1,81 @@
+package iam
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+// testPolicyDocument has all the elements of a statement, URL-encoded as returned by the IAM API.
+var testPolicyDocument = url.QueryEscape(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "Read",
+      "Effect": "Allow",
+      "Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+      "Action": ["s3:Get*", "sqs:ReceiveMessage"],
+      "Resource": "*",
+      "Condition": {"Bool": {"aws:SecureTransport": "true"}}
+    },
+    {
+      "Effect": "Deny",
+      "NotPrincipal": {"Service": "sqs.amazonaws.com"},
+      "NotAction": "s3:*",
+      "NotResource": "arn:aws:s3:::bucket"
+    }
+  ]
+}`)
+
+func buildAuthorizationDetails(t *testing.T, ctrl *gomock.Controller) client.Services {
+	m := mocks.NewMockIamClient(ctrl)
+	const managedArn = "arn:aws:iam::123456789012:policy/managed"
+	attached := []iamTypes.AttachedPolicy{{PolicyArn: aws.String(managedArn), PolicyName: aws.String("managed")}}
+	inline := []iamTypes.PolicyDetail{{PolicyName: aws.String("inline"), PolicyDocument: aws.String(testPolicyDocument)}}
+
+	m.EXPECT().GetAccountAuthorizationDetails(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&iam.GetAccountAuthorizationDetailsOutput{
+			Policies: []iamTypes.ManagedPolicyDetail{{
+				Arn:        aws.String(managedArn),
+				PolicyName: aws.String("managed"),
+				PolicyVersionList: []iamTypes.PolicyVersion{
+					{Document: aws.String(url.QueryEscape(`{"Statement": {"Effect": "Allow", "Action": "*", "Resource": "*"}}`)), VersionId: aws.String("v1")},
+					{Document: aws.String(testPolicyDocument), VersionId: aws.String("v2"), IsDefaultVersion: true},
+				},
+			}},
+			UserDetailList: []iamTypes.UserDetail{{
+				Arn:                     aws.String("arn:aws:iam::123456789012:user/user"),
+				UserName:                aws.String("user"),
+				GroupList:               []string{"group"},
+				UserPolicyList:          inline,
+				AttachedManagedPolicies: attached,
+			}},
+			GroupDetailList: []iamTypes.GroupDetail{{
+				Arn:                     aws.String("arn:aws:iam::123456789012:group/group"),
+				GroupName:               aws.String("group"),
+				GroupPolicyList:         inline,
+				AttachedManagedPolicies: attached,
+			}},
+			RoleDetailList: []iamTypes.RoleDetail{{
+				Arn:                      aws.String("arn:aws:iam::123456789012:role/role"),
+				RoleName:                 aws.String("role"),
+				AssumeRolePolicyDocument: aws.String(testPolicyDocument),
+				RolePolicyList:           inline,
+				AttachedManagedPolicies:  attached,
+			}},
+		}, nil)
+
+	return client.Services{
+		Iam: m,
+	}
+}
+
+func TestIamPolicyStatements(t *testing.T) {
+	client.AwsMockTestHelper(t, PolicyStatements(), buildAuthorizationDetails, client.TestOptions{})
+}
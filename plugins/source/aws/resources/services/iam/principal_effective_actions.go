@@ -0,0 +1,140 @@
+package iam
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/iampolicy"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/iam/models"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+)
+
+func PrincipalEffectiveActions() *schema.Table {
+	tableName := "aws_iam_principal_effective_actions"
+	return &schema.Table{
+		Name: tableName,
+		Description: `The actions the identity-based policies of the users and roles allow or deny, one row per action and statement.
+The users have the policies of their groups too. The wildcards in the actions, and the 'NotAction' elements, are expanded using a bundled catalog of actions; the actions missing from the catalog are kept as they are written in the policies.
+As the catalog may miss services, the action patterns with a wildcard service prefix (such as '*') are kept too, and the statements with a 'NotAction' element also have a '*' row, with the 'not_action' column set.
+An action is allowed if it has an 'Allow' row and no 'Deny' row applying to the same resource and conditions. Permissions boundaries, service control policies and resource-based policies are not taken into account.`,
+		Resolver: fetchIamPrincipalEffectiveActions,
+		Transform: transformers.TransformWithStruct(&models.PrincipalEffectiveAction{},
+			transformers.WithPrimaryKeys("PrincipalArn", "Action", "PolicyArn", "PolicyName", "StatementIndex", "GroupName"),
+		),
+		Multiplex: client.ServiceAccountRegionMultiplexer(tableName, "iam"),
+		Columns: []schema.Column{
+			client.DefaultAccountIDColumn(true),
+		},
+	}
+}
+
+// principalPolicy is a policy a user or role has.
+type principalPolicy struct {
+	policyType string
+	arn        string
+	name       string
+	document   *string
+	groupName  string
+}
+
+func fetchIamPrincipalEffectiveActions(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- any) error {
+	cl := meta.(*client.Client)
+	svc := cl.Services().Iam
+
+	// The users reference their groups, and the users, groups and roles the managed policies,
+	// so all the pages are retrieved before resolving the policies of the principals.
+	var users []types.UserDetail
+	var roles []types.RoleDetail
+	groups := make(map[string]types.GroupDetail)
+	managed := make(map[string]*string)
+	paginator := iam.NewGetAccountAuthorizationDetailsPaginator(svc, &iam.GetAccountAuthorizationDetailsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, func(options *iam.Options) {
+			options.Region = cl.Region
+		})
+		if err != nil {
+			return err
+		}
+		users = append(users, page.UserDetailList...)
+		roles = append(roles, page.RoleDetailList...)
+		for _, g := range page.GroupDetailList {
+			groups[aws.ToString(g.GroupName)] = g
+		}
+		for _, p := range page.Policies {
+			managed[aws.ToString(p.Arn)] = defaultPolicyDocument(p)
+		}
+	}
+
+	attachedPolicies := func(attached []types.AttachedPolicy, groupName string) []principalPolicy {
+		policies := make([]principalPolicy, 0, len(attached))
+		for _, p := range attached {
+			arn := aws.ToString(p.PolicyArn)
+			policies = append(policies, principalPolicy{policyType: policyTypeManaged, arn: arn, name: aws.ToString(p.PolicyName), document: managed[arn], groupName: groupName})
+		}
+		return policies
+	}
+	inlinePolicies := func(arn *string, inline []types.PolicyDetail, groupName string) []principalPolicy {
+		policies := make([]principalPolicy, 0, len(inline))
+		for _, p := range inline {
+			policies = append(policies, principalPolicy{policyType: policyTypeInline, arn: aws.ToString(arn), name: aws.ToString(p.PolicyName), document: p.PolicyDocument, groupName: groupName})
+		}
+		return policies
+	}
+
+	for _, u := range users {
+		policies := append(inlinePolicies(u.Arn, u.UserPolicyList, ""), attachedPolicies(u.AttachedManagedPolicies, "")...)
+		for _, name := range u.GroupList {
+			g, ok := groups[name]
+			if !ok {
+				continue
+			}
+			policies = append(policies, inlinePolicies(g.Arn, g.GroupPolicyList, name)...)
+			policies = append(policies, attachedPolicies(g.AttachedManagedPolicies, name)...)
+		}
+		res <- principalEffectiveActions(cl, "user", aws.ToString(u.Arn), policies)
+	}
+	for _, r := range roles {
+		policies := append(inlinePolicies(r.Arn, r.RolePolicyList, ""), attachedPolicies(r.AttachedManagedPolicies, "")...)
+		res <- principalEffectiveActions(cl, "role", aws.ToString(r.Arn), policies)
+	}
+	return nil
+}
+
+// principalEffectiveActions expands the statements of the policies of the principal into a row per action.
+func principalEffectiveActions(cl *client.Client, principalType, principalArn string, policies []principalPolicy) []models.PrincipalEffectiveAction {
+	var actions []models.PrincipalEffectiveAction
+	for _, p := range policies {
+		doc := parsePolicyDocument(cl, p.arn, p.document)
+		if doc == nil {
+			continue
+		}
+		for _, s := range doc.Statement {
+			expanded := iampolicy.ExpandActions(s.Action)
+			if len(s.NotAction) > 0 {
+				expanded = iampolicy.ExpandNotActions(s.NotAction)
+			}
+			for _, action := range expanded {
+				actions = append(actions, models.PrincipalEffectiveAction{
+					PrincipalType:  principalType,
+					PrincipalArn:   principalArn,
+					Action:         action,
+					NotAction:      s.NotAction,
+					Effect:         s.Effect,
+					Resource:       s.Resource,
+					NotResource:    s.NotResource,
+					Condition:      s.Condition,
+					PolicyType:     p.policyType,
+					PolicyArn:      p.arn,
+					PolicyName:     p.name,
+					StatementIndex: s.Index,
+					GroupName:      p.groupName,
+				})
+			}
+		}
+	}
+	return actions
+}
@@ -0,0 +1,11 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+)
+
+func TestIamPrincipalEffectiveActions(t *testing.T) {
+	client.AwsMockTestHelper(t, PrincipalEffectiveActions(), buildAuthorizationDetails, client.TestOptions{})
+}
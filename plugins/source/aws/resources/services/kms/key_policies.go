@@ -47,6 +47,9 @@ func keyPolicies() *schema.Table {
 				Resolver: schema.PathResolver("Policy"),
 			},
 		},
+		Relations: []*schema.Table{
+			keyPolicyStatements(),
+		},
 	}
 }
 
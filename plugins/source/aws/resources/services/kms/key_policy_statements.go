@@ -0,0 +1,60 @@
+package kms
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/iampolicy"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+)
+
+func keyPolicyStatements() *schema.Table {
+	tableName := "aws_kms_key_policy_statements"
+	return &schema.Table{
+		Name: tableName,
+		Description: `https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements.html
+The statements of the key policy, which controls the access to the key together with its grants. The columns are those of 'aws_iam_policy_statements'.`,
+		Resolver:  fetchKeyPolicyStatements,
+		Transform: transformers.TransformWithStruct(&iampolicy.Statement{}),
+		Columns: []schema.Column{
+			client.DefaultAccountIDColumn(false),
+			client.DefaultRegionColumn(false),
+			{
+				Name:       "key_arn",
+				Type:       arrow.BinaryTypes.String,
+				Resolver:   schema.ParentColumnResolver("key_arn"),
+				PrimaryKey: true,
+			},
+			{
+				Name:       "policy_name",
+				Type:       arrow.BinaryTypes.String,
+				Resolver:   schema.ParentColumnResolver("name"),
+				PrimaryKey: true,
+			},
+			{
+				Name:       "statement_index",
+				Type:       arrow.PrimitiveTypes.Int64,
+				Resolver:   schema.PathResolver("Index"),
+				PrimaryKey: true,
+			},
+		},
+	}
+}
+
+func fetchKeyPolicyStatements(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- any) error {
+	cl := meta.(*client.Client)
+	p := parent.Item.(KeyPolicy)
+	if aws.ToString(p.Policy) == "" {
+		return nil
+	}
+	doc, err := iampolicy.Parse(*p.Policy)
+	if err != nil {
+		cl.Logger().Warn().Err(err).Str("key_arn", parent.Get("key_arn").String()).Msg("failed to parse key policy")
+		return nil
+	}
+	res <- doc.Statement
+	return nil
+}
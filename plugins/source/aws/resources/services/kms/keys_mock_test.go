@@ -43,7 +43,25 @@ func buildKmsKeys(t *testing.T, ctrl *gomock.Controller) client.Services {
 	g.NextMarker = nil
 	m.EXPECT().ListGrants(gomock.Any(), gomock.Any(), gomock.Any()).Return(&g, nil)
 
-	pj := `{"data":["data"]}`
+	pj := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "Use",
+      "Effect": "Allow",
+      "Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+      "Action": "kms:Decrypt",
+      "Resource": "*",
+      "Condition": {"Bool": {"aws:SecureTransport": "true"}}
+    },
+    {
+      "Effect": "Deny",
+      "NotPrincipal": {"AWS": "arn:aws:iam::123456789012:root"},
+      "NotAction": "kms:Decrypt",
+      "NotResource": "*"
+    }
+  ]
+}`
 	m.EXPECT().GetKeyPolicy(gomock.Any(), &kms.GetKeyPolicyInput{
 		KeyId:      keyListEntry.KeyId,
 		PolicyName: aws.String("default"),
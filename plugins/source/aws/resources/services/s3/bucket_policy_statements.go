@@ -0,0 +1,58 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/iampolicy"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/s3/models"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+)
+
+func bucketPolicyStatements() *schema.Table {
+	return &schema.Table{
+		Name: "aws_s3_bucket_policy_statements",
+		Description: `https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements.html
+The statements of the bucket policy. The columns are those of 'aws_iam_policy_statements', so a bucket policy granting access to anyone has a statement whose 'principal' has '*' as an AWS principal.`,
+		Resolver:  fetchS3BucketPolicyStatements,
+		Transform: transformers.TransformWithStruct(&iampolicy.Statement{}),
+		Columns: []schema.Column{
+			client.DefaultAccountIDColumn(false),
+			{
+				Name:       "bucket_arn",
+				Type:       arrow.BinaryTypes.String,
+				Resolver:   schema.ParentColumnResolver("arn"),
+				PrimaryKey: true,
+			},
+			{
+				Name:       "statement_index",
+				Type:       arrow.PrimitiveTypes.Int64,
+				Resolver:   schema.PathResolver("Index"),
+				PrimaryKey: true,
+			},
+		},
+	}
+}
+
+func fetchS3BucketPolicyStatements(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- any) error {
+	cl := meta.(*client.Client)
+	r := parent.Item.(*models.WrappedBucket)
+	if r.Policy == nil {
+		return nil
+	}
+	b, err := json.Marshal(r.Policy)
+	if err != nil {
+		return err
+	}
+	doc, err := iampolicy.Parse(string(b))
+	if err != nil {
+		cl.Logger().Warn().Err(err).Str("bucket", aws.ToString(r.Name)).Msg("failed to parse bucket policy")
+		return nil
+	}
+	res <- doc.Statement
+	return nil
+}
@@ -24,7 +24,25 @@ func buildS3Buckets(t *testing.T, ctrl *gomock.Controller) client.Services {
 	require.NoError(t, faker.FakeObject(&bpol))
 	bpols := s3.GetBucketPolicyStatusOutput{}
 	require.NoError(t, faker.FakeObject(&bpols))
-	jsonDoc := `{"stuff": 3}`
+	jsonDoc := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "Read",
+      "Effect": "Allow",
+      "Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::bucket/*",
+      "Condition": {"Bool": {"aws:SecureTransport": "true"}}
+    },
+    {
+      "Effect": "Deny",
+      "NotPrincipal": {"AWS": "arn:aws:iam::123456789012:root"},
+      "NotAction": "s3:GetObject",
+      "NotResource": "arn:aws:s3:::bucket/*"
+    }
+  ]
+}`
 	bpol.Policy = &jsonDoc
 	bver := s3.GetBucketVersioningOutput{}
 	require.NoError(t, faker.FakeObject(&bver))
@@ -0,0 +1,55 @@
+package sqs
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/iampolicy"
+	"github.com/cloudquery/cloudquery/plugins/source/aws/resources/services/sqs/models"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+)
+
+func queuePolicyStatements() *schema.Table {
+	tableName := "aws_sqs_queue_policy_statements"
+	return &schema.Table{
+		Name: tableName,
+		Description: `https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements.html
+The statements of the queue policy, such as those allowing SNS topics or other accounts to send messages to the queue. The columns are those of 'aws_iam_policy_statements'.`,
+		Resolver:  fetchSqsQueuePolicyStatements,
+		Transform: transformers.TransformWithStruct(&iampolicy.Statement{}),
+		Columns: []schema.Column{
+			client.DefaultAccountIDColumn(false),
+			client.DefaultRegionColumn(false),
+			{
+				Name:       "queue_arn",
+				Type:       arrow.BinaryTypes.String,
+				Resolver:   schema.ParentColumnResolver("arn"),
+				PrimaryKey: true,
+			},
+			{
+				Name:       "statement_index",
+				Type:       arrow.PrimitiveTypes.Int64,
+				Resolver:   schema.PathResolver("Index"),
+				PrimaryKey: true,
+			},
+		},
+	}
+}
+
+func fetchSqsQueuePolicyStatements(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- any) error {
+	cl := meta.(*client.Client)
+	q := parent.Item.(*models.Queue)
+	if aws.ToString(q.Policy) == "" {
+		return nil
+	}
+	doc, err := iampolicy.Parse(*q.Policy)
+	if err != nil {
+		cl.Logger().Warn().Err(err).Str("queue_arn", aws.ToString(q.Arn)).Msg("failed to parse queue policy")
+		return nil
+	}
+	res <- doc.Statement
+	return nil
+}
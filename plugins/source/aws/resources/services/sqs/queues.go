@@ -55,6 +55,9 @@ func Queues() *schema.Table {
 				Resolver: resolveSqsQueueTags,
 			},
 		},
+		Relations: []*schema.Table{
+			queuePolicyStatements(),
+		},
 	}
 }
 
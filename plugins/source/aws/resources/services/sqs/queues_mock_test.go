@@ -30,7 +30,7 @@ func buildSQSQueues(t *testing.T, ctrl *gomock.Controller) client.Services {
 	).Return(
 		&sqs.GetQueueAttributesOutput{
 			Attributes: map[string]string{
-				"Policy":                                `{"field1":1}`,
+				"Policy":                                `{"Version":"2012-10-17","Statement":[{"Sid":"Send","Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":"sqs:SendMessage","Resource":"*","Condition":{"ArnEquals":{"aws:SourceArn":"arn:aws:sns:us-east-1:704956590351:topic"}}},{"Effect":"Deny","NotPrincipal":{"Service":"sns.amazonaws.com"},"NotAction":"sqs:SendMessage","NotResource":"*"}]}`,
 				"VisibilityTimeout":                     "3600",
 				"MaximumMessageSize":                    "1000",
 				"MessageRetentionPeriod":                "7200",
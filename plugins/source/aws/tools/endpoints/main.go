@@ -1,86 +1,333 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/cloudquery/cloudquery/plugins/source/aws/client"
 )
 
-type supportedServicesData struct {
-	// nested structs are ok here to simplify JSON unmarshaling
+const (
+	sdkModule        = "github.com/aws/aws-sdk-go-v2"
+	sdkServicePrefix = sdkModule + "/service/"
+	// rulesetPartitionsFile is the partition data of the endpoint rulesets, relative to the SDK module
+	rulesetPartitionsFile = "internal/endpoints/awsrulesfn/partitions.json"
+	// serviceEndpointsFile holds the endpoints resolved by the rulesets of a service, relative to the service module
+	serviceEndpointsFile = "internal/endpoints/endpoints.go"
+)
+
+// partitionNames are the display names of the partitions, which the rulesets don't include.
+var partitionNames = map[string]string{
+	"aws":        "AWS Standard",
+	"aws-cn":     "AWS China",
+	"aws-us-gov": "AWS GovCloud (US)",
+	"aws-iso":    "AWS ISO (US)",
+	"aws-iso-b":  "AWS ISOB (US)",
+	"aws-iso-e":  "AWS ISOE (Europe)",
+	"aws-iso-f":  "AWS ISOF",
+}
+
+// variantTags maps the endpoint variants of the SDK to the tags they are stored as.
+var variantTags = map[string]string{
+	"FIPSVariant":      "fips",
+	"DualStackVariant": "dualstack",
+}
+
+// rulesetPartitionsData is the partition data of the endpoint rulesets of the SDK.
+type rulesetPartitionsData struct {
 	// nolint:revive
 	Partitions []struct {
-		PartitionId   string `json:"partition"`
-		PartitionName string `json:"partitionName"`
-		Services      map[string]struct {
-			Endpoints map[string]struct {
-				CredentialScope struct {
-					Region string `json:"region"`
-				} `json:"credentialScope"`
-				Deprecated bool `json:"endpoints" default:"false"`
-			} `json:"endpoints"`
-		} `json:"services"`
+		Id      string `json:"id"`
+		Outputs struct {
+			SupportsFIPS      bool `json:"supportsFIPS"`
+			SupportsDualStack bool `json:"supportsDualStack"`
+		} `json:"outputs"`
 	} `json:"partitions"`
 }
 
-const (
-	awsEndpointFile = "https://raw.githubusercontent.com/aws/aws-sdk-go-v2/main/codegen/smithy-aws-go-codegen/src/main/resources/software/amazon/smithy/aws/go/codegen/endpoints.json"
-)
+// serviceEndpoints are the endpoints of a service module of the SDK.
+type serviceEndpoints struct {
+	// prefix is the endpoint prefix of the service, which the service is stored as
+	prefix string
+	// regions are the regions of the service, by partition
+	regions map[string]map[string]*client.AwsRegion
+}
+
+// sdkModuleDirs returns the directories of the SDK modules required by the plugin, by module path.
+// The versions pinned in go.mod are used, so the data matches the endpoints the plugin resolves.
+func sdkModuleDirs() (map[string]string, error) {
+	out, err := exec.Command("go", "list", "-m", "-json", "all").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+	dirs := make(map[string]string)
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var m struct {
+			Path string
+			Dir  string
+		}
+		if err := dec.Decode(&m); err != nil {
+			return nil, err
+		}
+		if m.Path != sdkModule && !strings.HasPrefix(m.Path, sdkServicePrefix) {
+			continue
+		}
+		if m.Dir == "" {
+			return nil, fmt.Errorf("module %s is not downloaded, run `go mod download` first", m.Path)
+		}
+		dirs[m.Path] = m.Dir
+	}
+	return dirs, nil
+}
 
 func getPartitionRegionServiceData() (*client.SupportedServiceRegionsData, error) {
-	// fetch the aws endpoints json file
-	req, err := http.NewRequest(http.MethodGet, awsEndpointFile, nil)
+	dirs, err := sdkModuleDirs()
 	if err != nil {
 		return nil, err
 	}
-	resp, err := http.DefaultClient.Do(req)
+	sdkDir, ok := dirs[sdkModule]
+	if !ok {
+		return nil, fmt.Errorf("module %s is not required", sdkModule)
+	}
+
+	// the partitions of the endpoint rulesets tell whether the partitions support FIPS and dual-stack endpoints
+	b, err := os.ReadFile(filepath.Join(sdkDir, rulesetPartitionsFile))
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	var rulesetData rulesetPartitionsData
+	if err := json.Unmarshal(b, &rulesetData); err != nil {
+		return nil, err
+	}
+
+	awsPartitions := make(map[string]client.AwsPartition)
+	for _, rp := range rulesetData.Partitions {
+		awsPartitions[rp.Id] = client.AwsPartition{
+			Id:                rp.Id,
+			Name:              partitionNames[rp.Id],
+			SupportsFIPS:      rp.Outputs.SupportsFIPS,
+			SupportsDualStack: rp.Outputs.SupportsDualStack,
+			Services:          make(map[string]*client.AwsService),
+		}
+	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get aws supported resources for region, status code: %d", resp.StatusCode)
+	for path, dir := range dirs {
+		if !strings.HasPrefix(path, sdkServicePrefix) {
+			continue
+		}
+		se, err := parseServiceEndpoints(filepath.Join(dir, serviceEndpointsFile))
+		if os.IsNotExist(err) {
+			// e.g. internal modules of the SDK, which aren't services
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse endpoints of %s: %w", path, err)
+		}
+		for id, regions := range se.regions {
+			partition, ok := awsPartitions[id]
+			if !ok {
+				continue
+			}
+			service := partition.Services[se.prefix]
+			if service == nil {
+				service = &client.AwsService{Regions: make(map[string]*client.AwsRegion)}
+				partition.Services[se.prefix] = service
+			}
+			for name, r := range regions {
+				if service.Regions[name] == nil {
+					service.Regions[name] = &client.AwsRegion{}
+				}
+				for _, v := range r.Variants {
+					service.Regions[name].Variants = appendVariant(service.Regions[name].Variants, strings.Split(v, ","))
+				}
+			}
+		}
 	}
 
-	var data supportedServicesData
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+	return &client.SupportedServiceRegionsData{
+		Partitions: awsPartitions,
+	}, nil
+}
+
+// parseServiceEndpoints reads the endpoints of the service from the defaultPartitions variable
+// generated by the SDK for the endpoint resolver of the service.
+func parseServiceEndpoints(file string) (*serviceEndpoints, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
 		return nil, err
 	}
+	partitions := findVar(f, "defaultPartitions")
+	if partitions == nil {
+		return nil, fmt.Errorf("defaultPartitions not found in %s", file)
+	}
 
-	awsPartitions := make(map[string]client.AwsPartition)
-	for _, p := range data.Partitions {
-		services := make(map[string]*client.AwsService)
-		for sk, s := range p.Services {
-			regions := make(map[string]*map[string]any)
-			for ek, e := range s.Endpoints {
-				if !e.Deprecated {
-					if e.CredentialScope.Region == "" {
-						regions[ek] = &map[string]any{}
-					} else {
-						regions[e.CredentialScope.Region] = &map[string]any{}
+	se := &serviceEndpoints{regions: make(map[string]map[string]*client.AwsRegion)}
+	for _, elt := range partitions.Elts {
+		p, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		id := stringField(p, "ID")
+		regions := make(map[string]*client.AwsRegion)
+		if defaults, ok := field(p, "Defaults").(*ast.CompositeLit); ok && se.prefix == "" {
+			se.prefix = endpointPrefix(defaults)
+		}
+		if endpoints, ok := field(p, "Endpoints").(*ast.CompositeLit); ok {
+			for _, elt := range endpoints.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.CompositeLit)
+				if !ok {
+					continue
+				}
+				endpoint, _ := kv.Value.(*ast.CompositeLit)
+				if endpoint != nil && isTrue(field(endpoint, "Deprecated")) {
+					continue
+				}
+				region := stringField(key, "Region")
+				if endpoint != nil {
+					if scope, ok := field(endpoint, "CredentialScope").(*ast.CompositeLit); ok {
+						if r := stringField(scope, "Region"); r != "" {
+							region = r
+						}
 					}
 				}
+				if regions[region] == nil {
+					regions[region] = &client.AwsRegion{}
+				}
+				if tags := variantTagsOf(field(key, "Variant")); len(tags) > 0 {
+					regions[region].Variants = appendVariant(regions[region].Variants, tags)
+				}
 			}
-			services[sk] = &client.AwsService{
-				Regions: regions,
+		}
+		se.regions[id] = regions
+	}
+	if se.prefix == "" {
+		return nil, fmt.Errorf("endpoint prefix not found in %s", file)
+	}
+	return se, nil
+}
+
+// findVar returns the composite literal the package level variable is initialized with.
+func findVar(f *ast.File, name string) *ast.CompositeLit {
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, n := range vs.Names {
+				if n.Name == name && i < len(vs.Values) {
+					lit, _ := vs.Values[i].(*ast.CompositeLit)
+					return lit
+				}
 			}
 		}
+	}
+	return nil
+}
 
-		awsPartitions[p.PartitionId] = client.AwsPartition{
-			Id:       p.PartitionId,
-			Name:     p.PartitionName,
-			Services: services,
+// field returns the value of the field of the composite literal, or nil if it isn't set.
+func field(lit *ast.CompositeLit, name string) ast.Expr {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if id, ok := kv.Key.(*ast.Ident); ok && id.Name == name {
+			return kv.Value
 		}
 	}
+	return nil
+}
 
-	return &client.SupportedServiceRegionsData{
-		Partitions: awsPartitions,
-	}, nil
+func stringField(lit *ast.CompositeLit, name string) string {
+	bl, ok := field(lit, name).(*ast.BasicLit)
+	if !ok || bl.Kind != token.STRING {
+		return ""
+	}
+	s, err := strconv.Unquote(bl.Value)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+func isTrue(e ast.Expr) bool {
+	sel, ok := e.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "TrueTernary"
+}
+
+// variantTagsOf returns the tags of the variant expression, e.g. `endpoints.FIPSVariant | endpoints.DualStackVariant`.
+func variantTagsOf(e ast.Expr) []string {
+	if e == nil {
+		return nil
+	}
+	var tags []string
+	ast.Inspect(e, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if tag, ok := variantTags[sel.Sel.Name]; ok {
+				tags = append(tags, tag)
+			}
+		}
+		return true
+	})
+	return tags
+}
+
+// endpointPrefix returns the endpoint prefix of the service from the hostname of its default endpoint,
+// e.g. "api.ecr" from "api.ecr.{region}.amazonaws.com".
+func endpointPrefix(defaults *ast.CompositeLit) string {
+	for _, elt := range defaults.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		if v, ok := field(key, "Variant").(*ast.BasicLit); !ok || v.Value != "0" {
+			continue
+		}
+		value, ok := kv.Value.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		if prefix, _, ok := strings.Cut(stringField(value, "Hostname"), ".{region}"); ok {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// appendVariant adds the variant with the tags, sorted and joined by ",", to the sorted variants.
+func appendVariant(variants []string, tags []string) []string {
+	tags = append([]string(nil), tags...)
+	sort.Strings(tags)
+	variant := strings.Join(tags, ",")
+	for _, v := range variants {
+		if v == variant {
+			return variants
+		}
+	}
+	variants = append(variants, variant)
+	sort.Strings(variants)
+	return variants
 }
 
 func saveToJsonFile(data *client.SupportedServiceRegionsData, filePath string) error {
@@ -102,7 +349,7 @@ func partitionRegionServiceGenerator() error {
 		panic("api.amp has been added to the list and code should be updated")
 	}
 	data.Partitions["aws"].Services["amp"] = &client.AwsService{
-		Regions: map[string]*map[string]any{
+		Regions: map[string]*client.AwsRegion{
 			// US East (Ohio)
 			"us-east-2": {},
 			// US East (N. Virginia)
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudquery/cloudquery/plugins/source/aws/client/iampolicy"
+)
+
+type policyEditorConfig struct {
+	ServiceMap map[string]struct {
+		StringPrefix string   `json:"StringPrefix"`
+		Actions      []string `json:"Actions"`
+	} `json:"serviceMap"`
+}
+
+const (
+	// awsPolicyGeneratorFile is the configuration of the AWS Policy Generator, which lists the actions of all the services.
+	awsPolicyGeneratorFile = "https://awspolicygen.s3.amazonaws.com/js/policies.js"
+	policyEditorConfigVar  = "app.PolicyEditorConfig="
+)
+
+func getActions() (map[string][]string, error) {
+	req, err := http.NewRequest(http.MethodGet, awsPolicyGeneratorFile, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get aws policy generator configuration, status code: %d", resp.StatusCode)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var config policyEditorConfig
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(string(b), policyEditorConfigVar)), &config); err != nil {
+		return nil, err
+	}
+
+	// several services may share a prefix
+	actions := make(map[string]map[string]struct{})
+	for _, service := range config.ServiceMap {
+		if actions[service.StringPrefix] == nil {
+			actions[service.StringPrefix] = make(map[string]struct{})
+		}
+		for _, action := range service.Actions {
+			actions[service.StringPrefix][action] = struct{}{}
+		}
+	}
+
+	catalog := make(map[string][]string, len(actions))
+	for prefix, names := range actions {
+		for name := range names {
+			catalog[prefix] = append(catalog[prefix], name)
+		}
+		sort.Strings(catalog[prefix])
+	}
+	return catalog, nil
+}
+
+func main() {
+	catalog, err := getActions()
+	if err != nil {
+		panic(err)
+	}
+	b, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(filepath.Join("client", "iampolicy", iampolicy.ActionsFile), append(b, '\n'), 0644); err != nil {
+		panic(err)
+	}
+}
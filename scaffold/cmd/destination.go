@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"embed"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	scaffoldDestinationShort = "Create an empty destination plugin project"
+)
+
+func newCmdScaffoldDestination() *cobra.Command {
+	var outputDir string
+	cmd := &cobra.Command{
+		Use:   "destination [org] [name]",
+		Short: scaffoldDestinationShort,
+		Args:  cobra.MatchAll(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputDir == "" {
+				outputDir = "cq-destination-" + args[1]
+			}
+			return runScaffoldDestination(args[0], args[1], outputDir)
+		},
+	}
+	cmd.Flags().StringVar(&outputDir, "output", "", "output directory")
+	return cmd
+}
+
+var destinationTemplates = map[string]string{
+	"release.yaml.tpl":     ".github/workflows/release.yaml",
+	"test.yaml.tpl":        ".github/workflows/test.yaml",
+	".goreleaser.yaml.tpl": ".goreleaser.yaml",
+	"go.mod.tpl":           "go.mod",
+	"main.go.tpl":          "main.go",
+	"Makefile.tpl":         "Makefile",
+	"README.md.tpl":        "README.md",
+	"client.go.tpl":        "client/client.go",
+	"client_test.go.tpl":   "client/client_test.go",
+	"spec.go.tpl":          "client/spec.go",
+	"migrate.go.tpl":       "client/migrate.go",
+	"write.go.tpl":         "client/write.go",
+	"deletestale.go.tpl":   "client/deletestale.go",
+	"read.go.tpl":          "client/read.go",
+	"plugin.go.tpl":        "plugin/plugin.go",
+	".gitignore.tpl":       ".gitignore",
+}
+
+//go:embed templates/destination/*
+var destinationFS embed.FS
+
+func runScaffoldDestination(org string, name string, outputDir string) error {
+	return runScaffold(destinationFS, "destination", destinationTemplates, scaffoldData{Org: org, Name: name}, outputDir)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestDestination(t *testing.T) {
+	tmpDir := t.TempDir()
+	cmd := NewCmdRoot()
+	cmd.SetArgs([]string{"destination", "test-org", "test", "--output", tmpDir})
+	if err := cmd.Execute(); err != nil {
+		t.Error(err)
+	}
+	goModTidy := exec.Command("go", "mod", "tidy")
+	goModTidy.Dir = tmpDir
+	if out, err := goModTidy.CombinedOutput(); err != nil {
+		t.Error(string(out) + err.Error())
+	}
+	goVet := exec.Command("go", "vet", "./...")
+	goVet.Dir = tmpDir
+	if out, err := goVet.CombinedOutput(); err != nil {
+		t.Error(string(out) + err.Error())
+	}
+	// the generated skeleton must pass the destination test suite it's wired into
+	goTest := exec.Command("go", "test", "./...")
+	goTest.Dir = tmpDir
+	if out, err := goTest.CombinedOutput(); err != nil {
+		t.Error(string(out) + err.Error())
+	}
+}
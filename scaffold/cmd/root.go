@@ -29,6 +29,7 @@ func NewCmdRoot() *cobra.Command {
 	cmd.SetHelpCommand(&cobra.Command{Hidden: true})
 	cmd.AddCommand(
 		newCmdScaffoldSource(),
+		newCmdScaffoldDestination(),
 	)
 	cmd.CompletionOptions.HiddenDefaultCmd = true
 	cmd.DisableAutoGenTag = true
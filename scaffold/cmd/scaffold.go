@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+)
+
+type scaffoldData struct {
+	Org  string
+	Name string
+}
+
+// runScaffold renders the templates of the kind (source or destination) to the output directory.
+// templates maps the template names to the paths of the files they're rendered to.
+func runScaffold(templateFS embed.FS, kind string, templates map[string]string, data scaffoldData, outputDir string) error {
+	var patterns []string
+	err := fs.WalkDir(templateFS, "templates/"+kind, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(p, ".tpl") {
+			patterns = append(patterns, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+	tpl, err := template.New(kind).ParseFS(templateFS, patterns...)
+	if err != nil {
+		return fmt.Errorf("failed to parse templates: %w", err)
+	}
+
+	for templatePath, filePath := range templates {
+		var sb strings.Builder
+		if err := tpl.ExecuteTemplate(&sb, templatePath, data); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		content := []byte(sb.String())
+		fullPath := outputDir + "/" + filePath
+		baseDir := path.Dir(fullPath)
+		if err := os.MkdirAll(baseDir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", baseDir, err)
+		}
+		if strings.HasSuffix(filePath, ".go") {
+			formattedContent, err := format.Source(content)
+			if err != nil {
+				// we still write the file even if it's not formatted for easy debugging
+				_ = os.WriteFile(outputDir+"/"+filePath, content, 0644)
+				return fmt.Errorf("failed to format source %s: %w", filePath, err)
+			}
+			content = formattedContent
+		}
+		if err := os.WriteFile(outputDir+"/"+filePath, content, 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+# CloudQuery {{.Name}} Destination Plugin
+
+[![test](https://github.com/{{.Org}}/cq-destination-{{.Name}}/actions/workflows/test.yaml/badge.svg)](https://github.com/{{.Org}}/cq-destination-{{.Name}}/actions/workflows/test.yaml)
+[![Go Report Card](https://goreportcard.com/badge/github.com/{{.Org}}/cq-destination-{{.Name}})](https://goreportcard.com/report/github.com/{{.Org}}/cq-destination-{{.Name}})
+
+A {{.Name}} destination plugin for CloudQuery that loads data from any source supported by [CloudQuery](https://www.cloudquery.io/), such as AWS, GCP, Azure, and many more, to {{.Name}}.
+
+## Links
+
+ - [CloudQuery Quickstart Guide](https://www.cloudquery.io/docs/quickstart)
+
+## Configuration
+
+The following destination configuration file will sync data to {{.Name}}. See [the CloudQuery Quickstart](https://www.cloudquery.io/docs/quickstart) for more information on how to configure the source and destination.
+
+```yaml
+kind: destination
+spec:
+  name: "{{.Name}}"
+  path: "{{.Org}}/{{.Name}}"
+  version: "${VERSION}"
+  write_mode: "overwrite-delete-stale"
+  spec:
+    # plugin spec section
+    # batch_size: 1000 # optional
+    # batch_size_bytes: 5242880 # optional
+```
+
+## Development
+
+### Run tests
+
+```bash
+make test
+```
+
+### Run linter
+
+```bash
+make lint
+```
+
+### Release a new version
+
+1. Run `git tag v1.0.0` to create a new tag for the release (replace `v1.0.0` with the new version number)
+2. Run `git push origin v1.0.0` to push the tag to GitHub  
+
+Once the tag is pushed, a new GitHub Actions workflow will be triggered to build the release binaries and create the new release on GitHub.
+To customize the release notes, see the Go releaser [changelog configuration docs](https://goreleaser.com/customization/changelog/#changelog).
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/plugin"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/writers/batchwriter"
+	"github.com/rs/zerolog"
+)
+
+type Client struct {
+	logger zerolog.Logger
+	spec   Spec
+	writer *batchwriter.BatchWriter
+
+	// TODO: Replace the in-memory storage with the connection to the destination
+	mu      sync.RWMutex // protects tables and records
+	tables  map[string]*schema.Table
+	records map[string][]arrow.Record
+
+	plugin.UnimplementedSource
+}
+
+func New(_ context.Context, logger zerolog.Logger, spec []byte, opts plugin.NewClientOptions) (plugin.Client, error) {
+	c := &Client{
+		logger:  logger,
+		tables:  make(map[string]*schema.Table),
+		records: make(map[string][]arrow.Record),
+	}
+	if opts.NoConnection {
+		return c, nil
+	}
+
+	if err := json.Unmarshal(spec, &c.spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal spec: %w", err)
+	}
+	c.spec.SetDefaults()
+	if err := c.spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+
+	// TODO: Add your client initialization here, e.g. connect to the destination
+
+	var err error
+	c.writer, err = batchwriter.New(c,
+		batchwriter.WithLogger(logger),
+		batchwriter.WithBatchSize(c.spec.BatchSize),
+		batchwriter.WithBatchSizeBytes(c.spec.BatchSizeBytes),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch writer: %w", err)
+	}
+	return c, nil
+}
+
+// Write batches the messages per table, calling MigrateTables, WriteTableBatch and DeleteStale with them.
+func (c *Client) Write(ctx context.Context, msgs <-chan message.WriteMessage) error {
+	if err := c.writer.Write(ctx, msgs); err != nil {
+		return err
+	}
+	return c.writer.Flush(ctx)
+}
+
+func (c *Client) Close(ctx context.Context) error {
+	if c.writer == nil {
+		return nil
+	}
+	if err := c.writer.Close(ctx); err != nil {
+		return fmt.Errorf("failed to close batch writer: %w", err)
+	}
+	// TODO: Add your client cleanup here
+	return nil
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudquery/plugin-sdk/v4/plugin"
+)
+
+func TestPlugin(t *testing.T) {
+	ctx := context.Background()
+	p := plugin.NewPlugin("{{.Name}}", "development", New)
+	// TODO: Point the spec to a test instance of the destination
+	spec, err := json.Marshal(Spec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Init(ctx, spec, plugin.NewClientOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := p.Close(ctx); err != nil {
+			t.Error(err)
+		}
+	})
+	plugin.TestWriterSuiteRunner(t,
+		p,
+		plugin.WriterTestSuiteTests{
+			// Set the Skip* fields for the features the destination doesn't support,
+			// e.g. SkipUpsert if it doesn't support primary keys
+		},
+	)
+}
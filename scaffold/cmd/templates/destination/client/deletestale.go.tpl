@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// DeleteStale deletes the rows of the tables that were written by the source before the sync time,
+// i.e. the rows with the same _cq_source_name and an older _cq_sync_time.
+func (c *Client) DeleteStale(ctx context.Context, msgs message.WriteDeleteStales) error {
+	for _, msg := range msgs {
+		if err := c.deleteStale(ctx, msg); err != nil {
+			return fmt.Errorf("failed to delete stale rows of table %s: %w", msg.TableName, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) deleteStale(_ context.Context, msg *message.WriteDeleteStale) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// TODO: Delete the stale rows from the destination instead of the in-memory storage
+	table, ok := c.tables[msg.TableName]
+	if !ok {
+		return nil
+	}
+	sourceName := table.Columns.Index(schema.CqSourceNameColumn.Name)
+	syncTime := table.Columns.Index(schema.CqSyncTimeColumn.Name)
+	if sourceName == -1 || syncTime == -1 {
+		return nil
+	}
+
+	rows := c.records[msg.TableName][:0]
+	for _, row := range c.records[msg.TableName] {
+		source, ok := row.Column(sourceName).(*array.String)
+		if !ok {
+			return fmt.Errorf("unexpected type %s of column %s", row.Column(sourceName).DataType(), schema.CqSourceNameColumn.Name)
+		}
+		ts, ok := row.Column(syncTime).(*array.Timestamp)
+		if !ok {
+			return fmt.Errorf("unexpected type %s of column %s", row.Column(syncTime).DataType(), schema.CqSyncTimeColumn.Name)
+		}
+		stale := source.Value(0) == msg.SourceName && ts.Value(0).ToTime(arrow.Microsecond).Before(msg.SyncTime)
+		if !stale {
+			rows = append(rows, row)
+		}
+	}
+	c.records[msg.TableName] = rows
+	return nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+)
+
+// MigrateTables creates the tables, or migrates them to their current schema.
+// Changes that can't be applied in place are only allowed if msg.MigrateForce is set, in which case the table can be dropped and recreated.
+func (c *Client) MigrateTables(ctx context.Context, msgs message.WriteMigrateTables) error {
+	for _, msg := range msgs {
+		if err := c.migrateTable(ctx, msg); err != nil {
+			return fmt.Errorf("failed to migrate table %s: %w", msg.Table.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) migrateTable(_ context.Context, msg *message.WriteMigrateTable) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// TODO: Create the table in the destination, or apply the changes to it.
+	// The in-memory storage can't apply any change in place, so it drops the rows of the table instead.
+	if current, ok := c.tables[msg.Table.Name]; ok {
+		changes := msg.Table.GetChanges(current)
+		if len(changes) == 0 {
+			return nil
+		}
+		if !msg.MigrateForce {
+			return fmt.Errorf("the table has changes that require a forced migration (migrate_mode: forced)")
+		}
+	}
+	c.tables[msg.Table.Name] = msg.Table
+	c.records[msg.Table.Name] = []arrow.Record{}
+	return nil
+}
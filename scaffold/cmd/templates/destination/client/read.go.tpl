@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+)
+
+// Read sends the rows of the table to res as records with the schema of the table.
+// It's used by the destination test suite to verify the written rows.
+func (c *Client) Read(_ context.Context, table *schema.Table, res chan<- arrow.Record) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// TODO: Read the rows from the destination instead of the in-memory storage
+	for _, row := range c.records[table.Name] {
+		res <- row
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package client
+
+const (
+	defaultBatchSize      = 1000
+	defaultBatchSizeBytes = 5 * 1024 * 1024 // 5 MiB
+)
+
+type Spec struct {
+	// plugin spec goes here
+
+	BatchSize      int `json:"batch_size,omitempty"`
+	BatchSizeBytes int `json:"batch_size_bytes,omitempty"`
+}
+
+func (s *Spec) SetDefaults() {
+	if s.BatchSize == 0 {
+		s.BatchSize = defaultBatchSize
+	}
+	if s.BatchSizeBytes == 0 {
+		s.BatchSizeBytes = defaultBatchSizeBytes
+	}
+}
+
+func (s *Spec) Validate() error {
+	// TODO: Validate the spec, e.g. check the required fields are set
+	return nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/message"
+)
+
+// WriteTableBatch writes the records of the messages (msg.Record) to the table.
+// If the table has primary keys, rows with the same primary key values should be replaced.
+func (c *Client) WriteTableBatch(_ context.Context, name string, msgs message.WriteInserts) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// TODO: Write the rows to the destination instead of the in-memory storage
+	table, ok := c.tables[name]
+	if !ok {
+		return fmt.Errorf("table %s was not migrated", name)
+	}
+	pks := table.PrimaryKeysIndexes()
+	for _, msg := range msgs {
+		for i := 0; i < int(msg.Record.NumRows()); i++ {
+			row := msg.Record.NewSlice(int64(i), int64(i+1))
+			if j := indexOfRow(c.records[name], row, pks); j != -1 {
+				c.records[name][j] = row
+				continue
+			}
+			c.records[name] = append(c.records[name], row)
+		}
+	}
+	return nil
+}
+
+// indexOfRow returns the index of the row with the same primary key values as row, or -1 if there is none.
+// Rows of tables without primary keys are never replaced.
+func indexOfRow(rows []arrow.Record, row arrow.Record, pks []int) int {
+	if len(pks) == 0 {
+		return -1
+	}
+	for i, r := range rows {
+		matches := true
+		for _, pk := range pks {
+			if r.Column(pk).ValueStr(0) != row.Column(pk).ValueStr(0) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return i
+		}
+	}
+	return -1
+}
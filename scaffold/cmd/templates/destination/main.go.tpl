@@ -0,0 +1,16 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/{{.Org}}/cq-destination-{{.Name}}/plugin"
+
+	"github.com/cloudquery/plugin-sdk/v4/serve"
+)
+
+func main() {
+	if err := serve.Plugin(plugin.Plugin(), serve.WithDestinationV0V1Server()).Serve(context.Background()); err != nil {
+		log.Fatalf("failed to serve plugin: %v", err)
+	}
+}